@@ -42,6 +42,10 @@ var adminQuotaFlags = []cli.Flag{
 		Name:  "clear",
 		Usage: "clears bucket quota configured for bucket",
 	},
+	cli.Float64Flag{
+		Name:  "alert-threshold",
+		Usage: "exit with an error when the bucket usage exceeds this percentage of its quota",
+	},
 }
 
 // quotaMessage container for content message structure
@@ -51,6 +55,13 @@ type quotaMessage struct {
 	Bucket    string `json:"bucket"`
 	Quota     uint64 `json:"quota,omitempty"`
 	QuotaType string `json:"type,omitempty"`
+
+	// Usage and UsedPercent are only set when the bucket has a quota,
+	// an empty bucket still reports a zero usage.
+	Usage          *uint64  `json:"usage,omitempty"`
+	UsedPercent    *float64 `json:"usedPercent,omitempty"`
+	AlertThreshold float64  `json:"alertThreshold,omitempty"`
+	Alert          bool     `json:"alert,omitempty"`
 }
 
 func (q quotaMessage) String() string {
@@ -62,8 +73,17 @@ func (q quotaMessage) String() string {
 		return console.Colorize("QuotaMessage",
 			fmt.Sprintf("Successfully cleared bucket quota configured on `%s`", q.Bucket))
 	default:
-		return console.Colorize("QuotaInfo",
+		msg := console.Colorize("QuotaInfo",
 			fmt.Sprintf("Bucket `%s` has %s quota of %s", q.Bucket, q.QuotaType, humanize.IBytes(q.Quota)))
+		if q.Usage != nil && q.UsedPercent != nil {
+			msg += console.Colorize("QuotaInfo",
+				fmt.Sprintf(", %s used (%.1f%%)", humanize.IBytes(*q.Usage), *q.UsedPercent))
+		}
+		if q.Alert {
+			msg += console.Colorize("QuotaAlert",
+				fmt.Sprintf(", above the alert threshold of %.1f%%", q.AlertThreshold))
+		}
+		return msg
 	}
 }
 
@@ -85,7 +105,7 @@ var adminBucketQuotaCmd = cli.Command{
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}} TARGET [--fifo QUOTA | --hard QUOTA | --clear]
+  {{.HelpName}} TARGET [--fifo QUOTA | --hard QUOTA | --clear | --alert-threshold PCT]
 
 QUOTA
   quota accepts human-readable case-insensitive number
@@ -109,6 +129,9 @@ EXAMPLES:
 
   4. Clear bucket quota configured for bucket "mybucket" on MinIO.
      {{.Prompt}} {{.HelpName}} myminio/mybucket --clear
+
+  5. Exit with an error when "mybucket" uses more than 90% of its quota, e.g. from a cron job.
+     {{.Prompt}} {{.HelpName}} myminio/mybucket --alert-threshold 90
 `,
 }
 
@@ -127,6 +150,27 @@ func checkAdminBucketQuotaSyntax(ctx *cli.Context) {
 	if ctx.IsSet("clear") && len(ctx.Args()) == 0 {
 		fatalIf(errInvalidArgument().Trace(ctx.Args()...), "clear flag must be passed with target alone")
 	}
+	if ctx.IsSet("alert-threshold") {
+		if ctx.IsSet("hard") || ctx.IsSet("fifo") || ctx.IsSet("clear") {
+			fatalIf(errInvalidArgument().Trace(ctx.Args()...), "--alert-threshold can only be used to display the bucket quota")
+		}
+		if pct := ctx.Float64("alert-threshold"); pct <= 0 || pct > 100 {
+			fatalIf(errInvalidArgument().Trace(ctx.Args()...), "--alert-threshold must be a percentage between 0 and 100")
+		}
+	}
+}
+
+// getBucketUsage - returns the size of a bucket as last computed by the
+// data usage scanner.
+func getBucketUsage(client *madmin.AdminClient, bucket string) (uint64, *probe.Error) {
+	info, e := client.DataUsageInfo(globalContext)
+	if e != nil {
+		return 0, probe.NewError(e)
+	}
+	if usage, ok := info.BucketsUsage[bucket]; ok {
+		return usage.Size, nil
+	}
+	return info.BucketSizes[bucket], nil
 }
 
 // mainAdminBucketQuota is the handler for "mc admin bucket quota" command.
@@ -135,6 +179,7 @@ func mainAdminBucketQuota(ctx *cli.Context) error {
 
 	console.SetColor("QuotaMessage", color.New(color.FgGreen))
 	console.SetColor("QuotaInfo", color.New(color.FgBlue))
+	console.SetColor("QuotaAlert", color.New(color.FgRed, color.Bold))
 
 	// Get the alias parameter from cli
 	args := ctx.Args()
@@ -178,13 +223,34 @@ func mainAdminBucketQuota(ctx *cli.Context) error {
 	} else {
 		qCfg, e := client.GetBucketQuota(globalContext, targetURL)
 		fatalIf(probe.NewError(e).Trace(args...), "Unable to get bucket quota")
-		printMsg(quotaMessage{
+		msg := quotaMessage{
 			op:        "get",
 			Bucket:    targetURL,
 			Quota:     qCfg.Quota,
 			QuotaType: string(qCfg.Type),
 			Status:    "success",
-		})
+		}
+		if qCfg.Quota > 0 {
+			// Usage is informational, only fail when it is needed
+			// to check the alert threshold.
+			usage, err := getBucketUsage(client, targetURL)
+			if ctx.IsSet("alert-threshold") {
+				fatalIf(err.Trace(args...), "Unable to get bucket usage")
+			}
+			if err == nil {
+				usedPercent := float64(usage) * 100 / float64(qCfg.Quota)
+				msg.Usage = &usage
+				msg.UsedPercent = &usedPercent
+			}
+		}
+		if ctx.IsSet("alert-threshold") {
+			msg.AlertThreshold = ctx.Float64("alert-threshold")
+			msg.Alert = msg.UsedPercent != nil && *msg.UsedPercent > msg.AlertThreshold
+		}
+		printMsg(msg)
+		if msg.Alert {
+			return exitStatus(globalErrorExitStatus)
+		}
 	}
 
 	return nil
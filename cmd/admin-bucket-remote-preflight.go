@@ -0,0 +1,251 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	json "github.com/minio/colorjson"
+	"github.com/minio/madmin-go"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/pkg/console"
+)
+
+const (
+	// Requests signed with more skew than this are rejected.
+	preflightMaxClockSkew = 15 * time.Minute
+	// Skew above this is reported as a warning.
+	preflightWarnClockSkew = time.Minute
+)
+
+// preflightMessage reports one issue found before adding a remote
+// replication target. Unchecked messages tell a check could not be
+// run, e.g. for lack of permissions, and never stop the command.
+type preflightMessage struct {
+	Status    string `json:"status"`
+	Check     string `json:"check"`
+	Blocking  bool   `json:"blocking"`
+	Unchecked bool   `json:"unchecked,omitempty"`
+	Message   string `json:"message"`
+}
+
+func (p preflightMessage) String() string {
+	if p.Unchecked {
+		return console.Colorize("PreflightInfo", "INFO:    ") + p.Check + ": " + p.Message
+	}
+	if p.Blocking {
+		return console.Colorize("PreflightError", "ERROR:   ") + p.Check + ": " + p.Message
+	}
+	return console.Colorize("PreflightWarning", "WARNING: ") + p.Check + ": " + p.Message
+}
+
+func (p preflightMessage) JSON() string {
+	p.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(p, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// serverVersions returns the sorted distinct versions of all servers.
+func serverVersions(info madmin.InfoMessage) []string {
+	seen := make(map[string]bool)
+	var versions []string
+	for _, server := range info.Servers {
+		if server.Version != "" && !seen[server.Version] {
+			seen[server.Version] = true
+			versions = append(versions, server.Version)
+		}
+	}
+	sort.Strings(versions)
+	return versions
+}
+
+// compareVersions reports mismatching server versions between the
+// source and the target deployments.
+func compareVersions(source, target []string) *preflightMessage {
+	if len(source) == 0 || len(target) == 0 {
+		return nil
+	}
+	if strings.Join(source, ",") == strings.Join(target, ",") {
+		return nil
+	}
+	return &preflightMessage{
+		Check:   "version",
+		Message: fmt.Sprintf("source runs %s while target runs %s", strings.Join(source, ", "), strings.Join(target, ", ")),
+	}
+}
+
+// versioningIssue reports a missing bucket or a bucket without
+// versioning, failing to read the versioning status is not an issue.
+func versioningIssue(check, bucket, status string, err *probe.Error) *preflightMessage {
+	if err != nil {
+		if minio.ToErrorResponse(err.ToGoError()).Code == "NoSuchBucket" {
+			return &preflightMessage{Check: check, Blocking: true, Message: "bucket `" + bucket + "` does not exist"}
+		}
+		return &preflightMessage{Check: check, Unchecked: true, Message: "unable to get versioning of `" + bucket + "`: " + err.ToGoError().Error()}
+	}
+	if status != "Enabled" {
+		return &preflightMessage{Check: check, Blocking: true, Message: "versioning is not enabled on `" + bucket + "`"}
+	}
+	return nil
+}
+
+// compareClocks reports clock skew between the source and the target.
+func compareClocks(source, target time.Time) *preflightMessage {
+	skew := source.Sub(target)
+	if skew < 0 {
+		skew = -skew
+	}
+	if skew <= preflightWarnClockSkew {
+		return nil
+	}
+	return &preflightMessage{
+		Check:    "clock",
+		Blocking: skew > preflightMaxClockSkew,
+		Message:  fmt.Sprintf("clocks of source and target differ by %s", skew.Round(time.Second)),
+	}
+}
+
+// serverDate returns the time reported by the server at endpoint.
+func serverDate(ctx context.Context, endpoint string) (time.Time, *probe.Error) {
+	req, e := http.NewRequestWithContext(ctx, http.MethodGet, endpoint+"/minio/health/live", nil)
+	if e != nil {
+		return time.Time{}, probe.NewError(e)
+	}
+	client := httpClient(10 * time.Second)
+	client.Transport.(*http.Transport).TLSClientConfig = &tls.Config{
+		RootCAs:            globalRootCAs,
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: globalInsecure,
+	}
+	resp, e := client.Do(req)
+	if e != nil {
+		return time.Time{}, probe.NewError(e)
+	}
+	resp.Body.Close()
+	date, e := http.ParseTime(resp.Header.Get("Date"))
+	return date, probe.NewError(e)
+}
+
+// remoteTargetPreflight checks that the target can be used to
+// replicate sourceBucket, returning all issues found.
+func remoteTargetPreflight(ctx context.Context, client *madmin.AdminClient, aliasedURL, sourceBucket string, bktTarget *madmin.BucketTarget) (issues []preflightMessage) {
+	report := func(msg *preflightMessage) {
+		if msg != nil {
+			issues = append(issues, *msg)
+		}
+	}
+
+	// Replication requires versioning on both buckets.
+	srcClnt, err := newClient(aliasedURL)
+	fatalIf(err.Trace(aliasedURL), "Unable to initialize source client.")
+	cfg, err := srcClnt.GetVersion(ctx)
+	report(versioningIssue("source", sourceBucket, cfg.Status, err))
+
+	targetURL := bktTarget.URL().String()
+	tgtConfig := NewS3Config(urlJoinPath(targetURL, bktTarget.TargetBucket), &aliasConfigV10{
+		AccessKey: bktTarget.Credentials.AccessKey,
+		SecretKey: bktTarget.Credentials.SecretKey,
+		API:       "S3v4",
+		Path:      bktTarget.Path,
+	})
+	tgtClnt, err := S3New(tgtConfig)
+	fatalIf(err.Trace(targetURL), "Unable to initialize target client.")
+	cfg, err = tgtClnt.GetVersion(ctx)
+	report(versioningIssue("target", bktTarget.TargetBucket, cfg.Status, err))
+	if err == nil {
+		listCtx, cancel := context.WithCancel(ctx)
+		for content := range tgtClnt.List(listCtx, ListOptions{Recursive: true, ShowDir: DirNone}) {
+			if content.Err == nil {
+				report(&preflightMessage{Check: "target", Message: "bucket `" + bktTarget.TargetBucket + "` already holds objects"})
+			}
+			break
+		}
+		cancel()
+	}
+
+	// Peer versions, the target credentials may lack admin access.
+	srcInfo, e := client.ServerInfo(ctx)
+	if e != nil {
+		report(&preflightMessage{Check: "version", Unchecked: true, Message: "unable to get source server info: " + e.Error()})
+	}
+	tgtAdminConfig := NewS3Config(targetURL, &aliasConfigV10{
+		AccessKey: bktTarget.Credentials.AccessKey,
+		SecretKey: bktTarget.Credentials.SecretKey,
+	})
+	tgtAdmin, err := s3AdminNew(tgtAdminConfig)
+	fatalIf(err.Trace(targetURL), "Unable to initialize target admin client.")
+	tgtInfo, e := tgtAdmin.ServerInfo(ctx)
+	if e != nil {
+		report(&preflightMessage{Check: "version", Unchecked: true, Message: "unable to get target server info: " + e.Error()})
+	}
+	report(compareVersions(serverVersions(srcInfo), serverVersions(tgtInfo)))
+
+	_, sourceURL, _, err := expandAlias(aliasedURL)
+	fatalIf(err.Trace(aliasedURL), "Unable to expand alias.")
+	srcURL := newClientURL(sourceURL)
+	srcDate, err := serverDate(ctx, srcURL.Scheme+"://"+srcURL.Host)
+	if err != nil {
+		report(&preflightMessage{Check: "clock", Unchecked: true, Message: "unable to get source server time: " + err.ToGoError().Error()})
+		return issues
+	}
+	tgtDate, err := serverDate(ctx, targetURL)
+	if err != nil {
+		report(&preflightMessage{Check: "clock", Unchecked: true, Message: "unable to get target server time: " + err.ToGoError().Error()})
+		return issues
+	}
+	report(compareClocks(srcDate, tgtDate))
+	return issues
+}
+
+// checkRemoteTargetPreflight prints issues found by the pre-flight
+// checks and exits when any of them is blocking, or when force is not
+// set and warnings were found. Checks that could not be run are only
+// reported.
+func checkRemoteTargetPreflight(issues []preflightMessage, force bool) {
+	console.SetColor("PreflightError", color.New(color.FgRed, color.Bold))
+	console.SetColor("PreflightWarning", color.New(color.FgYellow, color.Bold))
+	console.SetColor("PreflightInfo", color.New(color.FgCyan))
+
+	var blocking, warnings int
+	for _, issue := range issues {
+		printMsg(issue)
+		switch {
+		case issue.Unchecked:
+		case issue.Blocking:
+			blocking++
+		default:
+			warnings++
+		}
+	}
+	if blocking > 0 {
+		fatalIf(errDummy().Trace(), fmt.Sprintf("Pre-flight checks found %d blocking issue(s).", blocking))
+	}
+	if warnings > 0 && !force {
+		fatalIf(errDummy().Trace(), fmt.Sprintf("Pre-flight checks found %d warning(s), use --force to proceed anyway.", warnings))
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/minio/madmin-go"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/minio-go/v7"
+)
+
+func TestPreflightVersions(t *testing.T) {
+	info := madmin.InfoMessage{Servers: []madmin.ServerProperties{
+		{Version: "2021-06-17T00:10:46Z"},
+		{Version: "2021-05-27T22:06:31Z"},
+		{Version: "2021-06-17T00:10:46Z"},
+	}}
+	versions := serverVersions(info)
+	if len(versions) != 2 || versions[0] != "2021-05-27T22:06:31Z" {
+		t.Fatalf("unexpected versions %v", versions)
+	}
+
+	testCases := []struct {
+		source, target []string
+		issue          bool
+	}{
+		{[]string{"a"}, []string{"a"}, false},
+		{[]string{"a"}, []string{"b"}, true},
+		{[]string{"a", "b"}, []string{"a"}, true},
+		{nil, []string{"a"}, false},
+	}
+	for i, testCase := range testCases {
+		if issue := compareVersions(testCase.source, testCase.target); (issue != nil) != testCase.issue {
+			t.Fatalf("Test %d: expected issue %v, got %v", i+1, testCase.issue, issue)
+		}
+	}
+}
+
+func TestPreflightClocks(t *testing.T) {
+	now := time.Now()
+	testCases := []struct {
+		skew     time.Duration
+		issue    bool
+		blocking bool
+	}{
+		{10 * time.Second, false, false},
+		{-5 * time.Minute, true, false},
+		{20 * time.Minute, true, true},
+	}
+	for i, testCase := range testCases {
+		issue := compareClocks(now, now.Add(testCase.skew))
+		if (issue != nil) != testCase.issue {
+			t.Fatalf("Test %d: expected issue %v, got %v", i+1, testCase.issue, issue)
+		}
+		if issue != nil && issue.Blocking != testCase.blocking {
+			t.Fatalf("Test %d: expected blocking %v, got %v", i+1, testCase.blocking, issue.Blocking)
+		}
+	}
+}
+
+func TestPreflightVersioning(t *testing.T) {
+	testCases := []struct {
+		status    string
+		err       *probe.Error
+		issue     bool
+		blocking  bool
+		unchecked bool
+	}{
+		{"Enabled", nil, false, false, false},
+		{"Suspended", nil, true, true, false},
+		{"", nil, true, true, false},
+		{"", probe.NewError(minio.ErrorResponse{Code: "NoSuchBucket"}), true, true, false},
+		{"", probe.NewError(minio.ErrorResponse{Code: "AccessDenied"}), true, false, true},
+	}
+	for i, testCase := range testCases {
+		issue := versioningIssue("target", "bucket", testCase.status, testCase.err)
+		if (issue != nil) != testCase.issue {
+			t.Fatalf("Test %d: expected issue %v, got %v", i+1, testCase.issue, issue)
+		}
+		if issue == nil {
+			continue
+		}
+		if issue.Blocking != testCase.blocking {
+			t.Fatalf("Test %d: expected blocking %v, got %v", i+1, testCase.blocking, issue.Blocking)
+		}
+		if issue.Unchecked != testCase.unchecked {
+			t.Fatalf("Test %d: expected unchecked %v, got %v", i+1, testCase.unchecked, issue.Unchecked)
+		}
+	}
+}
@@ -18,25 +18,40 @@
 package cmd
 
 import (
+	"bufio"
+	"bytes"
+	"fmt"
 	"strings"
 
 	"github.com/minio/cli"
 	json "github.com/minio/colorjson"
+	"github.com/minio/madmin-go"
 	"github.com/minio/mc/pkg/probe"
 )
 
+var adminConfigGetFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "env-format",
+		Usage: "print the config as MINIO_ environment variables",
+	},
+	cli.BoolFlag{
+		Name:  "show-secrets",
+		Usage: "print secret values with --env-format instead of redacting them",
+	},
+}
+
 var adminConfigGetCmd = cli.Command{
 	Name:         "get",
 	Usage:        "interactively retrieve a config key parameters",
 	Before:       setGlobalsFromContext,
 	Action:       mainAdminConfigGet,
 	OnUsageError: onUsageError,
-	Flags:        globalFlags,
+	Flags:        append(adminConfigGetFlags, globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}} TARGET
+  {{.HelpName}} [FLAGS] TARGET [SUBSYS]
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -53,6 +68,16 @@ EXAMPLES:
   3. Get the current compression settings on MinIO server
      {{.Prompt}} {{.HelpName}} myminio/ compression
      compression extensions=".txt,.csv" mime_types="text/*"
+
+  4. Print the whole config of MinIO server as environment variables, secrets and values
+     embedding credentials, such as URLs with a password, are redacted
+     {{.Prompt}} {{.HelpName}} --env-format myminio/ > minio.env
+
+  5. Print the current Webhook targets as environment variables including their secrets
+     {{.Prompt}} {{.HelpName}} --env-format --show-secrets myminio/ notify_webhook
+     MINIO_NOTIFY_WEBHOOK_ENABLE_TARGET1=on
+     MINIO_NOTIFY_WEBHOOK_ENDPOINT_TARGET1=http://localhost:8080
+     MINIO_NOTIFY_WEBHOOK_AUTH_TOKEN_TARGET1=secret
 `,
 }
 
@@ -76,11 +101,162 @@ func (u configGetMessage) JSON() string {
 	return string(statusJSONBytes)
 }
 
+// configEnvVar holds one config key translated to an environment variable.
+type configEnvVar struct {
+	Name     string `json:"name"`
+	Value    string `json:"value"`
+	Redacted bool   `json:"redacted,omitempty"`
+}
+
+// configEnvMessage container to hold the config as environment variables.
+type configEnvMessage struct {
+	Status string         `json:"status"`
+	Env    []configEnvVar `json:"env"`
+}
+
+// String prints one NAME=value line per variable, redacted secrets
+// are commented out.
+func (u configEnvMessage) String() string {
+	lines := make([]string, 0, len(u.Env))
+	for _, env := range u.Env {
+		if env.Redacted {
+			lines = append(lines, fmt.Sprintf("# %s=<redacted>", env.Name))
+			continue
+		}
+		lines = append(lines, env.Name+"="+shellQuote(env.Value))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// JSON jsonified config environment message.
+func (u configEnvMessage) JSON() string {
+	u.Status = "success"
+	statusJSONBytes, e := json.MarshalIndent(u, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(statusJSONBytes)
+}
+
+// configSecretKeys - substrings of config keys holding secrets.
+var configSecretKeys = []string{"secret", "password", "token", "private_key", "client_key"}
+
+func isConfigSecretKey(key string) bool {
+	for _, s := range configSecretKeys {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasConfigCredentials - tells whether a config value embeds credentials,
+// as the user:password of an URL or of a MySQL DSN, e.g. the AMQP url or
+// the Redis address, or the password of a PostgreSQL connection string.
+func hasConfigCredentials(value string) bool {
+	for _, field := range strings.Fields(value) {
+		if strings.HasPrefix(field, "password=") {
+			return true
+		}
+	}
+	userInfo := value
+	if i := strings.Index(userInfo, "://"); i >= 0 {
+		userInfo = userInfo[i+len("://"):]
+	}
+	i := strings.LastIndex(userInfo, "@")
+	if i < 0 {
+		return false
+	}
+	userInfo = userInfo[:i]
+	return strings.Contains(userInfo, ":") && !strings.ContainsAny(userInfo, "/ ")
+}
+
+// shellQuote - quotes value for a POSIX shell, unless it only holds
+// characters that need no quoting.
+func shellQuote(value string) string {
+	safe := value != ""
+	for _, c := range value {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || strings.ContainsRune("_-+=.,:/@%", c)) {
+			safe = false
+			break
+		}
+	}
+	if safe {
+		return value
+	}
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
+
+// splitConfigFields - splits a config line on spaces, except for the
+// spaces inside double quoted values.
+func splitConfigFields(line string) (fields []string) {
+	var field strings.Builder
+	quoted := false
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case c == '\\' && quoted && i+1 < len(line):
+			i++
+			field.WriteByte(line[i])
+		case c == '"':
+			quoted = !quoted
+		case c == ' ' && !quoted:
+			if field.Len() > 0 {
+				fields = append(fields, field.String())
+				field.Reset()
+			}
+		default:
+			field.WriteByte(c)
+		}
+	}
+	if field.Len() > 0 {
+		fields = append(fields, field.String())
+	}
+	return fields
+}
+
+// configToEnv - translates config lines of the form
+// "subsys[:target] k1=v1 k2=v2" into MINIO_SUBSYS_KEY[_TARGET]
+// environment variables, keys without a value are skipped.
+func configToEnv(buf []byte, showSecrets bool) (envs []configEnvVar) {
+	scanner := bufio.NewScanner(bytes.NewReader(buf))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, madmin.KvComment) {
+			continue
+		}
+		fields := splitConfigFields(line)
+		subSys, target := fields[0], ""
+		if i := strings.Index(subSys, madmin.SubSystemSeparator); i >= 0 {
+			subSys, target = subSys[:i], subSys[i+1:]
+		}
+		for _, field := range fields[1:] {
+			kv := strings.SplitN(field, madmin.KvSeparator, 2)
+			if len(kv) != 2 || kv[1] == "" {
+				continue
+			}
+			name := "MINIO_" + strings.ToUpper(subSys+"_"+kv[0])
+			if target != "" && target != madmin.Default {
+				name += "_" + strings.ToUpper(target)
+			}
+			env := configEnvVar{Name: name, Value: kv[1]}
+			if !showSecrets && (isConfigSecretKey(kv[0]) || hasConfigCredentials(kv[1])) {
+				env.Value = ""
+				env.Redacted = true
+			}
+			envs = append(envs, env)
+		}
+	}
+	return envs
+}
+
 // checkAdminConfigGetSyntax - validate all the passed arguments
 func checkAdminConfigGetSyntax(ctx *cli.Context) {
 	if !ctx.Args().Present() || len(ctx.Args()) < 1 {
 		cli.ShowCommandHelpAndExit(ctx, "get", 1) // last argument is exit code
 	}
+	if ctx.Bool("show-secrets") && !ctx.Bool("env-format") {
+		fatalIf(errInvalidArgument().Trace(ctx.Args()...), "--show-secrets requires --env-format.")
+	}
 }
 
 func mainAdminConfigGet(ctx *cli.Context) error {
@@ -95,6 +271,22 @@ func mainAdminConfigGet(ctx *cli.Context) error {
 	client, err := newAdminClient(aliasedURL)
 	fatalIf(err, "Unable to initialize admin connection.")
 
+	if ctx.Bool("env-format") {
+		var buf []byte
+		var e error
+		if len(ctx.Args()) == 1 {
+			buf, e = client.GetConfig(globalContext)
+		} else {
+			buf, e = client.GetConfigKV(globalContext, strings.Join(args.Tail(), " "))
+		}
+		fatalIf(probe.NewError(e), "Unable to get server config")
+
+		printMsg(configEnvMessage{
+			Env: configToEnv(buf, ctx.Bool("show-secrets")),
+		})
+		return nil
+	}
+
 	if len(ctx.Args()) == 1 {
 		// Call get config API
 		hr, e := client.HelpConfigKV(globalContext, "", "", false)
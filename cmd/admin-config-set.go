@@ -18,7 +18,10 @@
 package cmd
 
 import (
+	"bufio"
+	"bytes"
 	"fmt"
+	"io/ioutil"
 	"strings"
 
 	"github.com/fatih/color"
@@ -29,13 +32,38 @@ import (
 	"github.com/minio/pkg/console"
 )
 
+var adminConfigSetFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "from-file",
+		Usage: "read key=value pairs for the sub-system from a file, one per line",
+	},
+	cli.BoolFlag{
+		Name:  "yes",
+		Usage: "confirm changing settings with a significant impact on the deployment",
+	},
+}
+
+// configSetImpacts - impact of changing high impact settings, either
+// of a whole sub-system or of one of its keys.
+var configSetImpacts = map[string]string{
+	"storage_class":    "changes the parity of new writes only, existing objects keep their parity",
+	"compression":      "affects new writes only, existing objects are neither compressed nor decompressed",
+	"etcd":             "moves IAM and bucket DNS to etcd, requires a restart, existing IAM data is not migrated",
+	"identity_openid":  "changes how users authenticate, misconfiguration can lock users out",
+	"identity_ldap":    "changes how users authenticate, misconfiguration can lock users out",
+	"policy_opa":       "authorizes all requests with OPA, misconfiguration can deny access to everyone",
+	"region name":      "clients signing requests for the previous region are rejected",
+	"api requests_max": "limits the number of concurrent requests, excess requests are rejected",
+	"heal max_io":      "changes the load of background healing on the drives",
+}
+
 var adminConfigSetCmd = cli.Command{
 	Name:         "set",
 	Usage:        "interactively set a config key parameters",
 	Before:       setGlobalsFromContext,
 	Action:       mainAdminConfigSet,
 	OnUsageError: onUsageError,
-	Flags:        append(adminConfigEnvFlags, globalFlags...),
+	Flags:        append(append(adminConfigEnvFlags, adminConfigSetFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -54,12 +82,23 @@ EXAMPLES:
 
   3. Change healing settings on a distributed MinIO server setup.
      {{.Prompt}} {{.HelpName}} mydist/ heal max_delay=300ms max_io=50
+
+  4. Configure all webhook notification settings at once from a file with key=value pairs.
+     {{.Prompt}} {{.HelpName}} myminio/ notify_webhook --from-file webhook.conf
+
+  5. Change the parity of new objects, confirming this high impact change.
+     {{.Prompt}} {{.HelpName}} myminio/ storage_class standard=EC:4 --yes
+
+HIGH IMPACT SETTINGS:
+  Changing sub-systems or keys with a significant impact, such as storage_class, compression, etcd,
+  identity_openid, identity_ldap or region name, prints their impact and requires --yes.
 `,
 }
 
 // configSetMessage container to hold locks information.
 type configSetMessage struct {
-	Status      string `json:"status"`
+	Status      string   `json:"status"`
+	Impacts     []string `json:"impacts,omitempty"`
 	targetAlias string
 	restart     bool
 }
@@ -68,6 +107,9 @@ type configSetMessage struct {
 func (u configSetMessage) String() (msg string) {
 	msg += console.Colorize("SetConfigSuccess",
 		"Successfully applied new settings.")
+	for _, impact := range u.Impacts {
+		msg += console.Colorize("SetConfigImpact", "\nNote: "+impact+".")
+	}
 	if u.restart {
 		suggestion := color.RedString("mc admin service restart %s", u.targetAlias)
 		msg += console.Colorize("SetConfigSuccess",
@@ -92,6 +134,66 @@ func checkAdminConfigSetSyntax(ctx *cli.Context) {
 	}
 }
 
+// configSetImpact - returns the impact notes of applying input, e.g.
+// `storage_class standard=EC:4`, for its high impact settings.
+func configSetImpact(input string) []string {
+	fields := strings.Fields(input)
+	if len(fields) == 0 {
+		return nil
+	}
+	subSys := strings.SplitN(fields[0], madmin.SubSystemSeparator, 2)[0]
+
+	var impacts []string
+	if impact, ok := configSetImpacts[subSys]; ok {
+		impacts = append(impacts, subSys+": "+impact)
+	}
+	seen := make(map[string]bool)
+	for _, kv := range fields[1:] {
+		i := strings.Index(kv, madmin.KvSeparator)
+		if i <= 0 || seen[kv[:i]] {
+			continue
+		}
+		seen[kv[:i]] = true
+		if impact, ok := configSetImpacts[subSys+" "+kv[:i]]; ok {
+			impacts = append(impacts, subSys+" "+kv[:i]+": "+impact)
+		}
+	}
+	return impacts
+}
+
+// parseConfigSetFile parses key=value pairs, one per line, lines
+// starting with '#' and empty lines are ignored. Values with spaces
+// are quoted as expected by the server.
+func parseConfigSetFile(data []byte) ([]string, *probe.Error) {
+	var kvs []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		kv := strings.SplitN(line, madmin.KvSeparator, 2)
+		key := strings.TrimSpace(kv[0])
+		if len(kv) != 2 || key == "" {
+			return nil, errInvalidArgument().Trace(fmt.Sprintf("line %d: %s", lineNum, line))
+		}
+		value := strings.TrimSpace(kv[1])
+		if strings.ContainsAny(value, " \t") && !strings.HasPrefix(value, madmin.KvDoubleQuote) {
+			value = madmin.KvDoubleQuote + value + madmin.KvDoubleQuote
+		}
+		kvs = append(kvs, key+madmin.KvSeparator+value)
+	}
+	if e := scanner.Err(); e != nil {
+		return nil, probe.NewError(e)
+	}
+	if len(kvs) == 0 {
+		return nil, errInvalidArgument().Trace("no key=value pairs found")
+	}
+	return kvs, nil
+}
+
 // main config set function
 func mainAdminConfigSet(ctx *cli.Context) error {
 
@@ -100,6 +202,7 @@ func mainAdminConfigSet(ctx *cli.Context) error {
 
 	// Set color preference of command outputs
 	console.SetColor("SetConfigSuccess", color.New(color.FgGreen, color.Bold))
+	console.SetColor("SetConfigImpact", color.New(color.FgYellow))
 
 	// Get the alias parameter from cli
 	args := ctx.Args()
@@ -111,6 +214,19 @@ func mainAdminConfigSet(ctx *cli.Context) error {
 
 	input := strings.Join(args.Tail(), " ")
 
+	if ctx.IsSet("from-file") {
+		if len(args.Tail()) == 0 {
+			fatalIf(errInvalidArgument().Trace(args...), "Please specify the sub-system to configure with --from-file.")
+		}
+		data, e := ioutil.ReadFile(ctx.String("from-file"))
+		fatalIf(probe.NewError(e), "Unable to read the file `%s`", ctx.String("from-file"))
+		kvs, err := parseConfigSetFile(data)
+		fatalIf(err, "Unable to parse the file `%s`", ctx.String("from-file"))
+		// All keys are sent in a single request, so the sub-system
+		// is updated at once and restart status is reported once.
+		input = strings.Join(append(args.Tail(), kvs...), " ")
+	}
+
 	if !strings.Contains(input, madmin.KvSeparator) {
 		// Call get config API
 		hr, e := client.HelpConfigKV(globalContext, args.Get(1), args.Get(2), ctx.IsSet("env"))
@@ -126,12 +242,19 @@ func mainAdminConfigSet(ctx *cli.Context) error {
 
 	}
 
+	impacts := configSetImpact(input)
+	if len(impacts) > 0 && !ctx.Bool("yes") {
+		fatalIf(errInvalidArgument().Trace(input), "This change has a significant impact, "+
+			strings.Join(impacts, "; ")+". Please pass --yes to confirm it.")
+	}
+
 	// Call set config API
 	restart, e := client.SetConfigKV(globalContext, input)
 	fatalIf(probe.NewError(e), "Unable to set '%s' to server", input)
 
 	// Print set config result
 	printMsg(configSetMessage{
+		Impacts:     impacts,
 		targetAlias: aliasedURL,
 		restart:     restart,
 	})
@@ -0,0 +1,75 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseConfigSetFile(t *testing.T) {
+	testCases := []struct {
+		data     string
+		expected []string
+		wantErr  bool
+	}{
+		{"endpoint=http://localhost:8080\nauth_token=secret\n", []string{"endpoint=http://localhost:8080", "auth_token=secret"}, false},
+		{"# webhook\n\nqueue_dir = /tmp/events\n", []string{"queue_dir=/tmp/events"}, false},
+		{"comment=some value\n", []string{`comment="some value"`}, false},
+		{`comment="quoted value"`, []string{`comment="quoted value"`}, false},
+		{"endpoint\n", nil, true},
+		{"=value\n", nil, true},
+		{"# nothing\n", nil, true},
+	}
+	for i, testCase := range testCases {
+		kvs, err := parseConfigSetFile([]byte(testCase.data))
+		if testCase.wantErr {
+			if err == nil {
+				t.Fatalf("Test %d: expected error", i+1)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if !reflect.DeepEqual(kvs, testCase.expected) {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.expected, kvs)
+		}
+	}
+}
+
+func TestConfigSetImpact(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected []string
+	}{
+		{"", nil},
+		{"notify_webhook:1 endpoint=http://localhost:8080", nil},
+		{"storage_class standard=EC:4", []string{"storage_class: " + configSetImpacts["storage_class"]}},
+		{"region name=us-west-1", []string{"region name: " + configSetImpacts["region name"]}},
+		{"region comment=\"a b=c\"", nil},
+		{"api requests_max=100 requests_max=200", []string{"api requests_max: " + configSetImpacts["api requests_max"]}},
+		{"identity_openid:primary config_url=https://example.com", []string{"identity_openid: " + configSetImpacts["identity_openid"]}},
+	}
+
+	for i, testCase := range testCases {
+		if impacts := configSetImpact(testCase.input); !reflect.DeepEqual(impacts, testCase.expected) {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.expected, impacts)
+		}
+	}
+}
@@ -33,12 +33,28 @@ import (
 
 const logTimeFormat string = "15:04:05 MST 01/02/2006"
 
+// logIdleTimeout is how long to wait for more buffered log entries before
+// exiting with --no-follow.
+const logIdleTimeout = 2 * time.Second
+
 var adminConsoleFlags = []cli.Flag{
 	cli.IntFlag{
-		Name:  "limit, l",
+		Name:  "limit, l, lines",
 		Usage: "show last n log entries",
 		Value: 10,
 	},
+	cli.BoolFlag{
+		Name:  "no-follow",
+		Usage: "exit once the last n log entries are shown instead of streaming new ones",
+	},
+	cli.StringFlag{
+		Name:  "level",
+		Usage: "show only log entries of at least this severity. Valid options are '[error, warning, info]'",
+	},
+	cli.StringFlag{
+		Name:  "node",
+		Usage: "show only log entries of the specified node",
+	},
 	cli.StringFlag{
 		Name:  "type, t",
 		Usage: "list error logs by type. Valid options are '[minio, application, all]'",
@@ -48,6 +64,7 @@ var adminConsoleFlags = []cli.Flag{
 
 var adminConsoleCmd = cli.Command{
 	Name:            "console",
+	Aliases:         []string{"logs"},
 	Usage:           "show console logs for MinIO server",
 	Action:          mainAdminConsole,
 	OnUsageError:    onUsageError,
@@ -72,6 +89,12 @@ EXAMPLES:
 
   3. Show application error logs on MinIO server with alias 'play'
      {{.Prompt}} {{.HelpName}} --type application play
+
+  4. Show the last 20 error log entries of node 'node1' and keep following new ones
+     {{.Prompt}} {{.HelpName}} --lines 20 --level error --node node1 cluster1
+
+  5. Show the last 50 log entries of 'cluster1' and exit
+     {{.Prompt}} {{.HelpName}} --lines 50 --no-follow cluster1
 `,
 }
 
@@ -79,6 +102,29 @@ func checkAdminLogSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) == 0 || len(ctx.Args()) > 3 {
 		cli.ShowCommandHelpAndExit(ctx, "console", 1) // last argument is exit code
 	}
+	if ctx.IsSet("node") && len(ctx.Args()) > 1 && ctx.Args().Get(1) != ctx.String("node") {
+		fatalIf(errInvalidArgument().Trace(ctx.Args()...), "--node conflicts with the NODENAME argument.")
+	}
+	if logLevelSeverity(ctx.String("level")) < 0 {
+		fatalIf(errInvalidArgument().Trace(ctx.String("level")), "Invalid value for --level flag. Valid options are [error, warning, info]")
+	}
+}
+
+// logLevelSeverity - returns the severity of a log level, higher is more
+// severe. Entries without a level are informational, -1 is returned for
+// unknown levels.
+func logLevelSeverity(level string) int {
+	switch strings.ToLower(level) {
+	case "", "info":
+		return 0
+	case "warning":
+		return 1
+	case "error":
+		return 2
+	case "fatal":
+		return 3
+	}
+	return -1
 }
 
 // Extend madmin.LogInfo to add String() and JSON() methods
@@ -178,10 +224,11 @@ func mainAdminConsole(ctx *cli.Context) error {
 		console.SetColor(fmt.Sprintf("Node%d", c), color.New(c))
 	}
 	aliasedURL := ctx.Args().Get(0)
-	var node string
+	node := ctx.String("node")
 	if len(ctx.Args()) > 1 {
 		node = ctx.Args().Get(1)
 	}
+	minSeverity := logLevelSeverity(ctx.String("level"))
 	var limit int
 	if ctx.IsSet("limit") {
 		limit = ctx.Int("limit")
@@ -203,17 +250,39 @@ func mainAdminConsole(ctx *cli.Context) error {
 	ctxt, cancel := context.WithCancel(globalContext)
 	defer cancel()
 
+	// With --no-follow stop once the stream goes idle after the buffered
+	// entries, the server sends them right away. The idle timer only starts
+	// with the first entry, connecting may take longer.
+	noFollow := ctx.Bool("no-follow")
+	var idle <-chan time.Time
+
 	// Start listening on all console log activity.
 	logCh := client.GetLogs(ctxt, node, limit, logType)
-	for logInfo := range logCh {
+	for {
+		var logInfo madmin.LogInfo
+		var ok bool
+		select {
+		case logInfo, ok = <-logCh:
+			if noFollow {
+				idle = time.After(logIdleTimeout)
+			}
+		case <-idle:
+			return nil
+		}
+		if !ok {
+			return nil
+		}
 		if logInfo.Err != nil {
 			fatalIf(probe.NewError(logInfo.Err), "Unable to listen to console logs")
 		}
+		// entries of an unknown level are never filtered out
+		if severity := logLevelSeverity(logInfo.Level); severity >= 0 && severity < minSeverity {
+			continue
+		}
 		// drop nodeName from output if specified as cli arg
 		if node != "" {
 			logInfo.NodeName = ""
 		}
 		printMsg(logMessage{LogInfo: logInfo})
 	}
-	return nil
 }
@@ -0,0 +1,39 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import "testing"
+
+func TestLogLevelSeverity(t *testing.T) {
+	testCases := []struct {
+		level    string
+		severity int
+	}{
+		{"", 0},
+		{"INFO", 0},
+		{"warning", 1},
+		{"ERROR", 2},
+		{"FATAL", 3},
+		{"debug", -1},
+	}
+	for i, testCase := range testCases {
+		if severity := logLevelSeverity(testCase.level); severity != testCase.severity {
+			t.Fatalf("Test %d: expected severity %d for %q, got %d", i+1, testCase.severity, testCase.level, severity)
+		}
+	}
+}
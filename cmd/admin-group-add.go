@@ -29,18 +29,29 @@ import (
 	"github.com/minio/pkg/console"
 )
 
+var adminGroupAddFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "members",
+		Usage: "comma separated list of users to add to the group",
+	},
+	cli.StringFlag{
+		Name:  "policy",
+		Usage: "attach the given comma separated policies to the group",
+	},
+}
+
 var adminGroupAddCmd = cli.Command{
 	Name:         "add",
 	Usage:        "add users to a new or existing group",
 	Action:       mainAdminGroupAdd,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        globalFlags,
+	Flags:        append(adminGroupAddFlags, globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}} TARGET GROUPNAME MEMBERS...
+  {{.HelpName}} [FLAGS] TARGET GROUPNAME [MEMBERS...]
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -48,12 +59,15 @@ FLAGS:
 EXAMPLES:
   1. Add users 'fivecent' and 'tencent' to the group 'allcents':
      {{.Prompt}} {{.HelpName}} myminio allcents fivecent tencent
+
+  2. Create the group 'auditors' with its members and attach the 'readonly' policy in one step:
+     {{.Prompt}} {{.HelpName}} --members alice,bob --policy readonly myminio auditors
 `,
 }
 
 // checkAdminGroupAddSyntax - validate all the passed arguments
 func checkAdminGroupAddSyntax(ctx *cli.Context) {
-	if len(ctx.Args()) < 3 {
+	if len(ctx.Args()) < 2 || len(ctx.Args()) < 3 && ctx.String("members") == "" {
 		cli.ShowCommandHelpAndExit(ctx, "add", 1) // last argument is exit code
 	}
 }
@@ -67,14 +81,27 @@ type groupMessage struct {
 	Members     []string `json:"members,omitempty"`
 	GroupStatus string   `json:"groupStatus,omitempty"`
 	GroupPolicy string   `json:"groupPolicy,omitempty"`
+
+	GroupsStatus map[string]string `json:"groupsStatus,omitempty"`
 }
 
 func (u groupMessage) String() string {
 	switch u.op {
 	case "list":
+		var maxLen int
+		for _, g := range u.Groups {
+			if len(g) > maxLen {
+				maxLen = len(g)
+			}
+		}
 		var s []string
 		for _, g := range u.Groups {
-			s = append(s, console.Colorize("GroupMessage", g))
+			status, ok := u.GroupsStatus[g]
+			if !ok {
+				s = append(s, console.Colorize("GroupMessage", g))
+				continue
+			}
+			s = append(s, console.Colorize("GroupMessage", fmt.Sprintf("%-*s  %s", maxLen, g, status)))
 		}
 		return strings.Join(s, "\n")
 	case "disable":
@@ -128,6 +155,20 @@ func mainAdminGroupAdd(ctx *cli.Context) error {
 	for i := 2; i < ctx.NArg(); i++ {
 		members = append(members, args.Get(i))
 	}
+	if ctx.String("members") != "" {
+		members = append(members, strings.Split(ctx.String("members"), ",")...)
+	}
+
+	// Check the policies before creating the group, so that a missing
+	// policy does not leave behind a group without its policy.
+	policy := ctx.String("policy")
+	if policy != "" {
+		for _, name := range strings.Split(policy, ",") {
+			_, e := client.InfoCannedPolicy(globalContext, name)
+			fatalIf(probe.NewError(e).Trace(args...), "Unable to find policy `"+name+"`")
+		}
+	}
+
 	gAddRemove := madmin.GroupAddRemove{
 		Group:    args.Get(1),
 		Members:  members,
@@ -135,10 +176,28 @@ func mainAdminGroupAdd(ctx *cli.Context) error {
 	}
 	fatalIf(probe.NewError(client.UpdateGroupMembers(globalContext, gAddRemove)).Trace(args...), "Unable to add new group")
 
+	if policy == "" {
+		printMsg(groupMessage{
+			op:        "add",
+			GroupName: args.Get(1),
+			Members:   members,
+		})
+		return nil
+	}
+
+	e := client.SetPolicy(globalContext, policy, args.Get(1), true)
+	fatalIf(probe.NewError(e).Trace(args...), "Unable to attach policy `"+policy+"` to group `"+args.Get(1)+"`")
+
+	// Report the final state of the group.
+	gd, e := client.GetGroupDescription(globalContext, args.Get(1))
+	fatalIf(probe.NewError(e).Trace(args...), "Could not get group info")
+
 	printMsg(groupMessage{
-		op:        "add",
-		GroupName: args.Get(1),
-		Members:   members,
+		op:          "info",
+		GroupName:   args.Get(1),
+		GroupStatus: gd.Status,
+		GroupPolicy: gd.Policy,
+		Members:     gd.Members,
 	})
 
 	return nil
@@ -41,7 +41,7 @@ FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}
 EXAMPLES:
-  1. List all groups.
+  1. List all groups along with their status.
      {{.Prompt}} {{.HelpName}} myminio
 `,
 }
@@ -70,9 +70,21 @@ func mainAdminGroupList(ctx *cli.Context) error {
 	gs, err1 := client.ListGroups(globalContext)
 	fatalIf(probe.NewError(err1).Trace(args...), "Could not get group list")
 
+	// Groups which could not be described are listed without status.
+	groupsStatus := make(map[string]string, len(gs))
+	descs, errs := describeEachGroup(client, gs)
+	for i, g := range gs {
+		if errs[i] != nil {
+			errorIf(probe.NewError(errs[i]).Trace(append(args, g)...), "Could not get info of group `"+g+"`.")
+			continue
+		}
+		groupsStatus[g] = descs[i].Status
+	}
+
 	printMsg(groupMessage{
-		op:     "list",
-		Groups: gs,
+		op:           "list",
+		Groups:       gs,
+		GroupsStatus: groupsStatus,
 	})
 
 	return nil
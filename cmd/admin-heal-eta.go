@@ -0,0 +1,126 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	json "github.com/minio/colorjson"
+	"github.com/minio/madmin-go"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+// healTotals returns the number of objects and bytes a heal of bucket is
+// expected to scan, as last computed by the data usage scanner. Zero is
+// returned when unknown, e.g. when healing a prefix.
+func healTotals(client *madmin.AdminClient, bucket, prefix string) (objects, bytes int64) {
+	if prefix != "" {
+		return 0, 0
+	}
+	info, e := client.DataUsageInfo(globalContext)
+	if e != nil {
+		return 0, 0
+	}
+	if bucket == "" {
+		return int64(info.ObjectsTotalCount), int64(info.ObjectsTotalSize)
+	}
+	if usage, ok := info.BucketsUsage[bucket]; ok {
+		return int64(usage.ObjectsCount), int64(usage.Size)
+	}
+	return 0, int64(info.BucketSizes[bucket])
+}
+
+// healETA estimates the remaining time to scan total units at the rate
+// scanned units were processed so far, false is returned when unknown.
+func healETA(scanned, total int64, elapsed time.Duration) (time.Duration, bool) {
+	if total <= 0 || scanned <= 0 || elapsed <= 0 {
+		return 0, false
+	}
+	if scanned >= total {
+		// The usage scanner lags behind, nothing better to say.
+		return 0, true
+	}
+	rate := float64(scanned) / elapsed.Seconds()
+	return time.Duration(float64(total-scanned) / rate * float64(time.Second)), true
+}
+
+// healRate returns the number of units processed per second.
+func healRate(scanned int64, elapsed time.Duration) float64 {
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(scanned) / elapsed.Seconds()
+}
+
+// eta returns the estimated remaining heal time, based on bytes when
+// their total is known and objects otherwise.
+func (ui *uiData) eta() (time.Duration, bool) {
+	if ui.TotalBytes > 0 && ui.BytesScanned > 0 {
+		return healETA(ui.BytesScanned, ui.TotalBytes, ui.HealDuration)
+	}
+	return healETA(ui.ObjectsScanned, ui.TotalObjects, ui.HealDuration)
+}
+
+// getRateAndETA returns the heal throughput and ETA for display.
+func (ui *uiData) getRateAndETA() string {
+	str := fmt.Sprintf("%.1f objects/s, %s/s",
+		healRate(ui.ObjectsScanned, ui.HealDuration),
+		humanize.IBytes(uint64(healRate(ui.BytesScanned, ui.HealDuration))))
+	if eta, ok := ui.eta(); ok {
+		return str + "; ETA " + eta.Round(time.Second).String()
+	}
+	return str + "; ETA unknown"
+}
+
+// printProgressJSON prints the heal throughput and ETA as one record
+// of the JSON stream.
+func (ui *uiData) printProgressJSON() {
+	var progress struct {
+		Status         string  `json:"status"`
+		Type           string  `json:"type"`
+		ObjectsScanned int64   `json:"objects_scanned"`
+		BytesScanned   int64   `json:"bytes_scanned"`
+		TotalObjects   int64   `json:"total_objects,omitempty"`
+		TotalBytes     int64   `json:"total_bytes,omitempty"`
+		ObjectsPerSec  float64 `json:"objects_per_sec"`
+		BytesPerSec    float64 `json:"bytes_per_sec"`
+		ElapsedTime    int64   `json:"duration"`
+		ETA            *int64  `json:"eta,omitempty"`
+	}
+
+	progress.Status = "success"
+	progress.Type = "progress"
+	progress.ObjectsScanned = ui.ObjectsScanned
+	progress.BytesScanned = ui.BytesScanned
+	progress.TotalObjects = ui.TotalObjects
+	progress.TotalBytes = ui.TotalBytes
+	progress.ObjectsPerSec = healRate(ui.ObjectsScanned, ui.HealDuration)
+	progress.BytesPerSec = healRate(ui.BytesScanned, ui.HealDuration)
+	progress.ElapsedTime = int64(ui.HealDuration.Round(time.Second).Seconds())
+	if eta, ok := ui.eta(); ok {
+		seconds := int64(eta.Round(time.Second).Seconds())
+		progress.ETA = &seconds
+	}
+
+	jBytes, err := json.MarshalIndent(progress, "", " ")
+	fatalIf(probe.NewError(err), "Unable to marshal to JSON.")
+	console.Println(string(jBytes))
+}
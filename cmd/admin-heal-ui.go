@@ -87,6 +87,9 @@ type uiData struct {
 	// Counters for healed objects and all kinds of healed items
 	ObjectsHealed, ItemsHealed int64
 
+	// Counter and total size of dangling objects
+	DanglingObjects, DanglingSize int64
+
 	// Map from online drives to number of objects with that many
 	// online drives.
 	ObjectsByOnlineDrives map[int]int64
@@ -94,6 +97,10 @@ type uiData struct {
 	// health color code.
 	HealthCols map[col]int64
 
+	// Expected number of objects and bytes to scan, zero when
+	// unknown; used to estimate the remaining heal time.
+	TotalObjects, TotalBytes int64
+
 	// channel to receive a prompt string to indicate activity on
 	// the terminal
 	CurChan (<-chan string)
@@ -120,6 +127,13 @@ func (ui *uiData) updateStats(i madmin.HealResultItem) error {
 	}
 	ui.ObjectsByOnlineDrives[afterUp]++
 
+	if isDanglingHealItem(i) {
+		ui.DanglingObjects++
+		if i.ObjectSize > 0 {
+			ui.DanglingSize += i.ObjectSize
+		}
+	}
+
 	// Update health color stats:
 
 	// Fetch health color after heal:
@@ -140,6 +154,17 @@ func (ui *uiData) updateStats(i madmin.HealResultItem) error {
 	return nil
 }
 
+// isDanglingHealItem - reports whether an object had fewer healthy drives
+// than data blocks before healing, it can neither be read nor healed and
+// is removed when healing with --remove.
+func isDanglingHealItem(i madmin.HealResultItem) bool {
+	if i.Type != madmin.HealItemObject || i.DataBlocks == 0 {
+		return false
+	}
+	beforeUp, _ := i.GetOnlineCounts()
+	return beforeUp < i.DataBlocks
+}
+
 func (ui *uiData) updateDuration(s *madmin.HealTaskStatus) {
 	ui.HealDuration = UTCNow().Sub(s.StartTime)
 }
@@ -303,15 +328,17 @@ func (ui *uiData) printItemsJSON(s *madmin.HealTaskStatus) (err error) {
 
 func (ui *uiData) printStatsJSON(s *madmin.HealTaskStatus) {
 	var summary struct {
-		Status         string `json:"status"`
-		Error          string `json:"error,omitempty"`
-		Type           string `json:"type"`
-		ObjectsScanned int64  `json:"objects_scanned"`
-		ObjectsHealed  int64  `json:"objects_healed"`
-		ItemsScanned   int64  `json:"items_scanned"`
-		ItemsHealed    int64  `json:"items_healed"`
-		Size           int64  `json:"size"`
-		ElapsedTime    int64  `json:"duration"`
+		Status         string  `json:"status"`
+		Error          string  `json:"error,omitempty"`
+		Type           string  `json:"type"`
+		ObjectsScanned int64   `json:"objects_scanned"`
+		ObjectsHealed  int64   `json:"objects_healed"`
+		ItemsScanned   int64   `json:"items_scanned"`
+		ItemsHealed    int64   `json:"items_healed"`
+		Size           int64   `json:"size"`
+		ElapsedTime    int64   `json:"duration"`
+		ObjectsPerSec  float64 `json:"objects_per_sec"`
+		BytesPerSec    float64 `json:"bytes_per_sec"`
 	}
 
 	summary.Status = "success"
@@ -323,6 +350,8 @@ func (ui *uiData) printStatsJSON(s *madmin.HealTaskStatus) {
 	summary.ItemsHealed = ui.ItemsHealed
 	summary.Size = ui.BytesScanned
 	summary.ElapsedTime = int64(ui.HealDuration.Round(time.Second).Seconds())
+	summary.ObjectsPerSec = healRate(ui.ObjectsScanned, ui.HealDuration)
+	summary.BytesPerSec = healRate(ui.BytesScanned, ui.HealDuration)
 
 	jBytes, err := json.MarshalIndent(summary, "", " ")
 	fatalIf(probe.NewError(err), "Unable to marshal to JSON.")
@@ -350,6 +379,7 @@ func (ui *uiData) updateUI(s *madmin.HealTaskStatus) (err error) {
 	console.Print(console.Colorize("HealUpdateUI", fmt.Sprintf(" %s", <-ui.CurChan)))
 	console.PrintC(fmt.Sprintf("  %s\n", scannedStr))
 	console.PrintC(fmt.Sprintf("    %s\n", healedStr))
+	console.PrintC(fmt.Sprintf("    %s\n", ui.getRateAndETA()))
 
 	dspOrder := []col{colGreen, colYellow, colRed, colGrey}
 	printColors := []*color.Color{}
@@ -383,6 +413,9 @@ func (ui *uiData) UpdateDisplay(s *madmin.HealTaskStatus) (err error) {
 	switch {
 	case globalJSON:
 		err = ui.printItemsJSON(s)
+		if err == nil {
+			ui.printProgressJSON()
+		}
 	case globalQuiet:
 		err = ui.printItemsQuietly(s)
 	default:
@@ -420,7 +453,7 @@ func (ui *uiData) DisplayAndFollowHealStatus(aliasedURL string) (res madmin.Heal
 				firstIter = false
 			} else {
 				if !globalQuiet && !globalJSON {
-					console.RewindLines(8)
+					console.RewindLines(9)
 				}
 			}
 			err = ui.UpdateDisplay(&res)
@@ -0,0 +1,75 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/minio/madmin-go"
+)
+
+func TestIsDanglingHealItem(t *testing.T) {
+	item := func(itemType madmin.HealItemType, dataBlocks int, states ...string) madmin.HealResultItem {
+		i := madmin.HealResultItem{Type: itemType, DataBlocks: dataBlocks}
+		for _, state := range states {
+			i.Before.Drives = append(i.Before.Drives, madmin.HealDriveInfo{State: state})
+		}
+		return i
+	}
+	ok, missing := madmin.DriveStateOk, madmin.DriveStateMissing
+
+	testCases := []struct {
+		item     madmin.HealResultItem
+		expected bool
+	}{
+		{item(madmin.HealItemObject, 2, ok, ok, ok, missing), false},
+		{item(madmin.HealItemObject, 2, ok, ok, missing, missing), false},
+		{item(madmin.HealItemObject, 2, ok, missing, missing, missing), true},
+		{item(madmin.HealItemObject, 0, missing, missing), false},
+		{item(madmin.HealItemBucket, 2, missing, missing), false},
+	}
+	for i, testCase := range testCases {
+		if got := isDanglingHealItem(testCase.item); got != testCase.expected {
+			t.Fatalf("Test %d: expected %t, got %t", i+1, testCase.expected, got)
+		}
+	}
+}
+
+func TestHealETA(t *testing.T) {
+	testCases := []struct {
+		scanned, total int64
+		elapsed        time.Duration
+		eta            time.Duration
+		ok             bool
+	}{
+		{0, 100, time.Minute, 0, false},
+		{10, 0, time.Minute, 0, false},
+		{10, 100, 0, 0, false},
+		{10, 100, 10 * time.Second, 90 * time.Second, true},
+		{50, 100, time.Minute, time.Minute, true},
+		{150, 100, time.Minute, 0, true},
+	}
+
+	for i, testCase := range testCases {
+		eta, ok := healETA(testCase.scanned, testCase.total, testCase.elapsed)
+		if ok != testCase.ok || eta != testCase.eta {
+			t.Fatalf("Test %d: expected (%v, %v), got (%v, %v)", i+1, testCase.eta, testCase.ok, eta, ok)
+		}
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	humanize "github.com/dustin/go-humanize"
 	"github.com/fatih/color"
 	"github.com/minio/cli"
 	json "github.com/minio/colorjson"
@@ -61,6 +62,10 @@ var adminHealFlags = []cli.Flag{
 		Name:  "remove",
 		Usage: "[DEPRECATED] remove dangling objects in heal sequence",
 	},
+	cli.BoolFlag{
+		Name:  "dangling",
+		Usage: "heal recursively, removing dangling objects, and summarize the removed ones, preview with --dry-run",
+	},
 }
 
 var adminHealCmd = cli.Command{
@@ -84,8 +89,27 @@ SCAN MODES:
   normal (default): Heal objects which are missing on one or more disks.
   deep            : Heal objects which are missing or with silent data corruption on one or more disks.
 
+DANGLING OBJECTS:
+  Objects left with fewer healthy drives than data blocks, e.g. after a crash during
+  a write, cannot be read nor healed. --dangling is a full recursive heal of the target
+  that also removes such objects, as --recursive --remove, and then reports how many
+  dangling objects were removed and the space reclaimed. All other objects of the
+  target are healed as well; with --dry-run nothing is healed nor removed.
+
+PROGRESS:
+  The scan rate and an ETA are reported while healing, and as "progress" records with
+  --json. The ETA is estimated from the data usage scanner totals of the bucket or
+  the cluster, it is unknown when healing a prefix or before the first scanner cycle.
+
 DEPRECATED:
   MinIO server now supports auto-heal, this command will be removed in future.
+
+EXAMPLES:
+  1. Scan bucket 'mybucket' without healing, and preview its dangling objects and the space removing them would reclaim.
+     {{.Prompt}} {{.HelpName}} --dangling --dry-run myminio/mybucket
+
+  2. Heal bucket 'mybucket' recursively and remove its dangling objects.
+     {{.Prompt}} {{.HelpName}} --dangling myminio/mybucket
 `,
 }
 
@@ -121,6 +145,32 @@ func (s stopHealMessage) JSON() string {
 	return string(stopHealJSONBytes)
 }
 
+// danglingHealMessage is container for the dangling objects summary.
+type danglingHealMessage struct {
+	Status  string `json:"status"`
+	DryRun  bool   `json:"dryRun"`
+	Objects int64  `json:"danglingObjects"`
+	Size    int64  `json:"reclaimedSize"`
+}
+
+// String colorized dangling objects summary.
+func (d danglingHealMessage) String() string {
+	if d.DryRun {
+		return console.Colorize("Heal", fmt.Sprintf("Found %d dangling object(s), removing them would reclaim %s.",
+			d.Objects, humanize.IBytes(uint64(d.Size))))
+	}
+	return console.Colorize("Heal", fmt.Sprintf("Removed %d dangling object(s), reclaimed %s.",
+		d.Objects, humanize.IBytes(uint64(d.Size))))
+}
+
+// JSON jsonified dangling objects summary.
+func (d danglingHealMessage) JSON() string {
+	danglingJSONBytes, e := json.MarshalIndent(d, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(danglingJSONBytes)
+}
+
 // backgroundHealStatusMessage is container for stop heal success and failure messages.
 type backgroundHealStatusMessage struct {
 	Status   string `json:"status"`
@@ -196,9 +246,11 @@ func mainAdminHeal(ctx *cli.Context) error {
 		}
 	}
 
+	dangling := ctx.Bool("dangling")
+
 	// Return the background heal status when the user
 	// doesn't pass a bucket or --recursive flag.
-	if bucket == "" && !ctx.Bool("recursive") {
+	if bucket == "" && !ctx.Bool("recursive") && !dangling {
 		bgHealStatus, berr := client.BackgroundHealStatus(globalContext)
 		fatalIf(probe.NewError(berr), "Failed to get the status of the background heal.")
 		printMsg(backgroundHealStatusMessage{Status: "success", HealInfo: bgHealStatus})
@@ -207,8 +259,8 @@ func mainAdminHeal(ctx *cli.Context) error {
 
 	opts := madmin.HealOpts{
 		ScanMode:  transformScanArg(ctx.String("scan")),
-		Remove:    ctx.Bool("remove"),
-		Recursive: ctx.Bool("recursive"),
+		Remove:    ctx.Bool("remove") || dangling,
+		Recursive: ctx.Bool("recursive") || dangling,
 		DryRun:    ctx.Bool("dry-run"),
 	}
 
@@ -235,6 +287,7 @@ func mainAdminHeal(ctx *cli.Context) error {
 		HealthCols:            make(map[col]int64),
 		CurChan:               cursorAnimate(),
 	}
+	ui.TotalObjects, ui.TotalBytes = healTotals(client, bucket, prefix)
 
 	res, e := ui.DisplayAndFollowHealStatus(aliasedURL)
 	if e != nil {
@@ -246,5 +299,13 @@ func mainAdminHeal(ctx *cli.Context) error {
 			fatalIf(probe.NewError(e).Trace(aliasedURL), "Unable to display heal status.")
 		}
 	}
+	if dangling {
+		printMsg(danglingHealMessage{
+			Status:  "success",
+			DryRun:  opts.DryRun,
+			Objects: ui.DanglingObjects,
+			Size:    ui.DanglingSize,
+		})
+	}
 	return nil
 }
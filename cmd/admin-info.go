@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -35,33 +36,208 @@ import (
 	"github.com/minio/pkg/console"
 )
 
+var adminInfoFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "pools",
+		Usage: "display capacity and health per server pool",
+	},
+}
+
 var adminInfoCmd = cli.Command{
 	Name:         "info",
 	Usage:        "display MinIO server information",
 	Action:       mainAdminInfo,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        globalFlags,
+	Flags:        append(adminInfoFlags, globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}} TARGET
+  {{.HelpName}} [FLAGS] TARGET
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}
+JSON OUTPUT:
+  The 'schemaVersion' field of the JSON output is only increased when fields are
+  removed, renamed or change meaning. Adding fields does not change it.
+
 EXAMPLES:
   1. Get server information of the 'play' MinIO server.
      {{.Prompt}} {{.HelpName}} play/
+
+  2. Get capacity and drives health of each server pool of the 'myminio' MinIO cluster.
+     {{.Prompt}} {{.HelpName}} --pools myminio/
 `,
 }
 
+// adminInfoSchemaVersion is the version of the JSON output of admin
+// info, it is bumped on changes which break existing consumers only.
+const adminInfoSchemaVersion = 1
+
 // Wrap "Info" message together with fields "Status" and "Error"
 type clusterStruct struct {
-	Status string             `json:"status"`
-	Error  string             `json:"error,omitempty"`
-	Info   madmin.InfoMessage `json:"info,omitempty"`
+	SchemaVersion int                `json:"schemaVersion"`
+	Status        string             `json:"status"`
+	Error         string             `json:"error,omitempty"`
+	Info          madmin.InfoMessage `json:"info,omitempty"`
+	FlaggedDrives []flaggedDrive     `json:"flaggedDrives,omitempty"`
+	Pools         []poolInfo         `json:"pools,omitempty"`
+}
+
+// poolInfo - capacity and drives health of a server pool, object
+// counts are not reported per pool by the server.
+type poolInfo struct {
+	Index          int    `json:"index"`
+	Servers        int    `json:"servers"`
+	ServersOffline int    `json:"serversOffline"`
+	Sets           int    `json:"sets"`
+	DrivesOnline   int    `json:"drivesOnline"`
+	DrivesOffline  int    `json:"drivesOffline"`
+	DrivesHealing  int    `json:"drivesHealing"`
+	TotalSpace     uint64 `json:"totalSpace"`
+	UsedSpace      uint64 `json:"usedSpace"`
+	AvailableSpace uint64 `json:"availableSpace"`
+}
+
+// serverPoolIndex returns the pool of a server, from its drives or else
+// from its 1-based pool number, which offline servers may only report.
+// It returns -1 when the pool is unknown.
+func serverPoolIndex(srv madmin.ServerProperties) int {
+	for _, disk := range srv.Disks {
+		if disk.PoolIndex >= 0 {
+			return disk.PoolIndex
+		}
+	}
+	return srv.PoolNumber - 1
+}
+
+// getPoolsInfo aggregates the servers and their drives by pool, drives
+// not assigned to a pool yet are ignored.
+func getPoolsInfo(servers []madmin.ServerProperties) []poolInfo {
+	pools := make(map[int]*poolInfo)
+	poolServers := make(map[int]map[string]struct{})
+	poolSets := make(map[int]map[int]struct{})
+	getPool := func(idx int) *poolInfo {
+		pool, ok := pools[idx]
+		if !ok {
+			pool = &poolInfo{Index: idx}
+			pools[idx] = pool
+			poolServers[idx] = make(map[string]struct{})
+			poolSets[idx] = make(map[int]struct{})
+		}
+		return pool
+	}
+	for _, srv := range servers {
+		if idx := serverPoolIndex(srv); idx >= 0 && srv.State == "offline" {
+			getPool(idx).ServersOffline++
+			poolServers[idx][srv.Endpoint] = struct{}{}
+		}
+		for _, disk := range srv.Disks {
+			if disk.PoolIndex < 0 {
+				continue
+			}
+			pool := getPool(disk.PoolIndex)
+			poolServers[disk.PoolIndex][srv.Endpoint] = struct{}{}
+			poolSets[disk.PoolIndex][disk.SetIndex] = struct{}{}
+			switch disk.State {
+			case madmin.DriveStateOk, madmin.DriveStateUnformatted:
+				pool.DrivesOnline++
+			default:
+				pool.DrivesOffline++
+			}
+			if disk.Healing {
+				pool.DrivesHealing++
+			}
+			pool.TotalSpace += disk.TotalSpace
+			pool.UsedSpace += disk.UsedSpace
+			pool.AvailableSpace += disk.AvailableSpace
+		}
+	}
+
+	infos := make([]poolInfo, 0, len(pools))
+	for idx, pool := range pools {
+		pool.Servers = len(poolServers[idx])
+		pool.Sets = len(poolSets[idx])
+		infos = append(infos, *pool)
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Index < infos[j].Index
+	})
+	return infos
+}
+
+// A drive is flagged when its latency exceeds the median
+// latency of all the drives of the cluster by this factor.
+const abnormalDriveLatencyFactor = 3
+
+// flaggedDrive - drive with an abnormally high read or write latency.
+type flaggedDrive struct {
+	Endpoint     string  `json:"endpoint"`
+	Path         string  `json:"path"`
+	ReadLatency  float64 `json:"readLatency"`
+	WriteLatency float64 `json:"writeLatency"`
+	Ratio        float64 `json:"ratio"`
+}
+
+// medianOf returns the median of values, values are sorted in place.
+func medianOf(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sort.Float64s(values)
+	n := len(values)
+	if n%2 == 1 {
+		return values[n/2]
+	}
+	return (values[n/2-1] + values[n/2]) / 2
+}
+
+// getFlaggedDrives returns the drives whose read or write latency is abnormally
+// high compared to the rest of the cluster, the slowest drive comes first.
+func getFlaggedDrives(servers []madmin.ServerProperties) (flagged []flaggedDrive) {
+	var readLatencies, writeLatencies []float64
+	for _, srv := range servers {
+		for _, disk := range srv.Disks {
+			if disk.State != madmin.DriveStateOk {
+				continue
+			}
+			readLatencies = append(readLatencies, disk.ReadLatency)
+			writeLatencies = append(writeLatencies, disk.WriteLatency)
+		}
+	}
+	readMedian := medianOf(readLatencies)
+	writeMedian := medianOf(writeLatencies)
+
+	for _, srv := range servers {
+		for _, disk := range srv.Disks {
+			if disk.State != madmin.DriveStateOk {
+				continue
+			}
+			var ratio float64
+			if readMedian > 0 {
+				ratio = disk.ReadLatency / readMedian
+			}
+			if writeMedian > 0 && disk.WriteLatency/writeMedian > ratio {
+				ratio = disk.WriteLatency / writeMedian
+			}
+			if ratio < abnormalDriveLatencyFactor {
+				continue
+			}
+			flagged = append(flagged, flaggedDrive{
+				Endpoint:     srv.Endpoint,
+				Path:         disk.DrivePath,
+				ReadLatency:  disk.ReadLatency,
+				WriteLatency: disk.WriteLatency,
+				Ratio:        ratio,
+			})
+		}
+	}
+	sort.Slice(flagged, func(i, j int) bool {
+		return flagged[i].Ratio > flagged[j].Ratio
+	})
+	return flagged
 }
 
 // String provides colorized info messages depending on the type of a server
@@ -116,8 +292,53 @@ func (u clusterStruct) String() (msg string) {
 		coloredDot = console.Colorize("InfoWarning", dot)
 	}
 
+	// Drives with an abnormal latency come first
+	if len(u.FlaggedDrives) > 0 {
+		msg += console.Colorize("InfoWarning", "Drives with abnormal latency:") + "\n"
+		for _, d := range u.FlaggedDrives {
+			msg += fmt.Sprintf("%s  %s%s\n", console.Colorize("InfoWarning", dot), d.Endpoint, d.Path)
+			msg += fmt.Sprintf("   Latency: read %.2f, write %.2f (%.1fx the cluster median)\n",
+				d.ReadLatency, d.WriteLatency, d.Ratio)
+		}
+		msg += "\n"
+	}
+
+	// Pools view replaces the per server view
+	for _, pool := range u.Pools {
+		poolDot := coloredDot
+		if pool.DrivesOffline > 0 || pool.ServersOffline > 0 {
+			poolDot = console.Colorize("InfoWarning", dot)
+		}
+		msg += fmt.Sprintf("%s  %s\n", poolDot, console.Colorize("PrintB", fmt.Sprintf("Pool %d", pool.Index+1)))
+		msg += fmt.Sprintf("   Servers: %d", pool.Servers)
+		if pool.ServersOffline > 0 {
+			msg += " (" + console.Colorize("InfoFail", fmt.Sprintf("%d offline", pool.ServersOffline)) + ")"
+		}
+		msg += fmt.Sprintf(", Erasure sets: %d\n", pool.Sets)
+		msg += fmt.Sprintf("   Usage: %s of %s, %s available\n", humanize.IBytes(pool.UsedSpace),
+			humanize.IBytes(pool.TotalSpace), humanize.IBytes(pool.AvailableSpace))
+		msg += fmt.Sprintf("   Drives: %d/%d OK", pool.DrivesOnline, pool.DrivesOnline+pool.DrivesOffline)
+		if pool.DrivesHealing > 0 {
+			msg += ", " + console.Colorize("InfoWarning", english.Plural(pool.DrivesHealing, "drive", "")+" healing")
+		}
+		msg += "\n\n"
+		totalOnlineDisksCluster += pool.DrivesOnline
+		totalOfflineDisksCluster += pool.DrivesOffline
+	}
+
+	// Offline servers not reported in any pool are still shown
+	servers := u.Info.Servers
+	if len(u.Pools) > 0 {
+		servers = nil
+		for _, srv := range u.Info.Servers {
+			if srv.State == "offline" && serverPoolIndex(srv) < 0 {
+				servers = append(servers, srv)
+			}
+		}
+	}
+
 	// Loop through each server and put together info for each one
-	for _, srv := range u.Info.Servers {
+	for _, srv := range servers {
 		// Check if MinIO server is offline ("Mode" field),
 		// If offline, error out
 		if srv.State == "offline" {
@@ -234,6 +455,7 @@ func (u clusterStruct) String() (msg string) {
 
 // JSON jsonifies service status message.
 func (u clusterStruct) JSON() string {
+	u.SchemaVersion = adminInfoSchemaVersion
 	statusJSONBytes, e := json.MarshalIndent(u, "", "    ")
 	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
 
@@ -269,6 +491,10 @@ func mainAdminInfo(ctx *cli.Context) error {
 		clusterInfo.Error = ""
 	}
 	clusterInfo.Info = admInfo
+	clusterInfo.FlaggedDrives = getFlaggedDrives(admInfo.Servers)
+	if ctx.Bool("pools") {
+		clusterInfo.Pools = getPoolsInfo(admInfo.Servers)
+	}
 	printMsg(clusterStruct(clusterInfo))
 
 	return nil
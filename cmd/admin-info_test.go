@@ -0,0 +1,54 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/minio/madmin-go"
+)
+
+func TestGetPoolsInfo(t *testing.T) {
+	servers := []madmin.ServerProperties{
+		{
+			Endpoint: "minio1:9000",
+			Disks: []madmin.Disk{
+				{State: madmin.DriveStateOk, PoolIndex: 0, SetIndex: 0, TotalSpace: 100, UsedSpace: 40, AvailableSpace: 60},
+				{State: madmin.DriveStateOk, PoolIndex: 0, SetIndex: 1, TotalSpace: 100, UsedSpace: 20, AvailableSpace: 80, Healing: true},
+			},
+		},
+		{
+			Endpoint: "minio2:9000",
+			Disks: []madmin.Disk{
+				{State: madmin.DriveStateOffline, PoolIndex: 1, SetIndex: 0},
+				{State: madmin.DriveStateOk, PoolIndex: 1, SetIndex: 0, TotalSpace: 200, UsedSpace: 50, AvailableSpace: 150},
+				{State: madmin.DriveStateOk, PoolIndex: -1, SetIndex: -1, TotalSpace: 500},
+			},
+		},
+		{Endpoint: "minio3:9000", State: "offline", PoolNumber: 2},
+		{Endpoint: "minio4:9000", State: "offline"},
+	}
+	expected := []poolInfo{
+		{Index: 0, Servers: 1, Sets: 2, DrivesOnline: 2, DrivesHealing: 1, TotalSpace: 200, UsedSpace: 60, AvailableSpace: 140},
+		{Index: 1, Servers: 2, ServersOffline: 1, Sets: 1, DrivesOnline: 1, DrivesOffline: 1, TotalSpace: 200, UsedSpace: 50, AvailableSpace: 150},
+	}
+	if got := getPoolsInfo(servers); !reflect.DeepEqual(got, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, got)
+	}
+}
@@ -19,52 +19,94 @@ package cmd
 
 import (
 	"fmt"
-	"os"
 
 	"github.com/fatih/color"
 	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/pkg/console"
-	"golang.org/x/crypto/ssh/terminal"
 )
 
+var adminKMSCreateKeyFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "verify",
+		Usage: "request the status of the master key once created",
+	},
+}
+
 var adminKMSCreateKeyCmd = cli.Command{
 	Name:         "create",
 	Usage:        "creates a new master key at the KMS",
 	Action:       mainAdminKMSCreateKey,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        globalFlags,
+	Flags:        append(adminKMSCreateKeyFlags, globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}} TARGET [KEY_NAME]
+  {{.HelpName}} TARGET KEY_NAME
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}
 EXAMPLES:
-  1. Create a new master key named 'my-key' default master key.
+  1. Create a new master key named 'my-key'.
      $ {{.HelpName}} play my-key
+  2. Create a new master key named 'my-key' and verify it can be used for encryption and decryption.
+     $ {{.HelpName}} --verify play my-key
 `,
 }
 
+type kmsCreateKeyMsg struct {
+	KeyID  string `json:"keyId"`
+	Status string `json:"status"`
+}
+
+func (s kmsCreateKeyMsg) JSON() string {
+	s.Status = "success"
+	kmsBytes, e := json.MarshalIndent(s, "", "    ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(kmsBytes)
+}
+
+func (s kmsCreateKeyMsg) String() string {
+	return console.Colorize("StatusSuccess", fmt.Sprintf("Created master key `%s` successfully", s.KeyID))
+}
+
 // adminKMSCreateKeyCmd is the handler for the "mc admin kms key create" command.
 func mainAdminKMSCreateKey(ctx *cli.Context) error {
 	if len(ctx.Args()) != 2 {
 		cli.ShowCommandHelpAndExit(ctx, "create", 1) // last argument is exit code
 	}
 
+	console.SetColor("StatusSuccess", color.New(color.FgGreen, color.Bold))
+	console.SetColor("StatusError", color.New(color.FgRed, color.Bold))
+
 	client, err := newAdminClient(ctx.Args().Get(0))
-	fatalIf(err, "Cannot get a configured admin connection.")
+	fatalIf(err, "Unable to get a configured admin connection.")
 
 	keyID := ctx.Args().Get(1)
 	e := client.CreateKey(globalContext, keyID)
-	fatalIf(probe.NewError(e), "Failed to create master key")
+	fatalIf(probe.NewError(e), "Failed to create master key `%s`", keyID)
+
+	printMsg(kmsCreateKeyMsg{KeyID: keyID})
+
+	if ctx.Bool("verify") {
+		status, e := client.GetKeyStatus(globalContext, keyID)
+		fatalIf(probe.NewError(e), "Failed to get status information")
 
-	if terminal.IsTerminal(int(os.Stdout.Fd())) {
-		console.Println(color.GreenString(fmt.Sprintf("Created master key `%s` successfully", keyID)))
+		printMsg(kmsKeyStatusMsg{
+			KeyID:         status.KeyID,
+			Encryption:    status.EncryptionErr == "",
+			Decryption:    status.DecryptionErr == "",
+			EncryptionErr: status.EncryptionErr,
+			DecryptionErr: status.DecryptionErr,
+		})
+		if status.EncryptionErr != "" || status.DecryptionErr != "" {
+			return exitStatus(globalErrorExitStatus)
+		}
 	}
 	return nil
 }
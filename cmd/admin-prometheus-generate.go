@@ -18,8 +18,14 @@
 package cmd
 
 import (
+	"context"
+	gojson "encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/url"
+	"os"
+	"path"
+	"strings"
 	"time"
 
 	"github.com/fatih/color"
@@ -30,41 +36,228 @@ import (
 	jwtgo "github.com/dgrijalva/jwt-go"
 	json "github.com/minio/colorjson"
 	yaml "gopkg.in/yaml.v2"
+	"maze.io/x/duration"
 )
 
 const (
-	defaultJobName     = "minio-job"
-	legacyMetricsPath  = "/minio/prometheus/metrics"
-	defaultMetricsPath = "/minio/v2/metrics/cluster"
+	defaultJobName      = "minio-job"
+	legacyMetricsPath   = "/minio/prometheus/metrics"
+	defaultMetricsPath  = "/minio/v2/metrics/cluster"
+	nodeMetricsPath     = "/minio/v2/metrics/node"
+	bucketMetricsPath   = "/minio/v2/metrics/bucket"
+	resourceMetricsPath = "/minio/v2/metrics/resource"
+	v3MetricsPathPrefix = "/minio/metrics/v3"
+
+	// Servers older than these versions do not serve the v2 and v3
+	// metrics endpoints respectively.
+	v2MetricsMinVersion = "2021-01-30T00-20-58Z"
+	v3MetricsMinVersion = "2024-04-28T17-53-50Z"
 )
 
+var adminPrometheusGenerateFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "all-metrics",
+		Usage: "generate scrape configs for cluster, node and bucket metrics",
+	},
+	cli.StringFlag{
+		Name:  "metrics-type",
+		Usage: "generate scrape configs for the v3 metrics of this type (see METRICS TYPES). Valid options are '[cluster, node, bucket, resource]'",
+	},
+	cli.StringFlag{
+		Name:  "job-name",
+		Usage: "name of the generated scrape job, defaults to $MC_PROMETHEUS_JOB_NAME or '" + defaultJobName + "'",
+	},
+	cli.StringFlag{
+		Name:  "expiry",
+		Usage: "validity of the generated bearer token, e.g. '720h' or '30d', defaults to $MC_PROMETHEUS_EXPIRY or 100 years",
+	},
+	cli.BoolFlag{
+		Name:  "public",
+		Usage: "omit the bearer token, for servers with MINIO_PROMETHEUS_AUTH_TYPE=public",
+	},
+	cli.StringFlag{
+		Name:  "sign-method",
+		Usage: "signing method of the bearer token. Valid options are '[HS512, HS256, RS256]', defaults to 'HS512'",
+	},
+	cli.StringFlag{
+		Name:  "private-key",
+		Usage: "PEM encoded RSA private key signing the bearer token, for --sign-method RS256",
+	},
+	cli.BoolFlag{
+		Name:  "show-claims",
+		Usage: "print the claims of the generated bearer token to stderr",
+	},
+	cli.StringFlag{
+		Name:  "token-file",
+		Usage: "write the bearer token to this file and reference it with bearer_token_file",
+	},
+	cli.StringFlag{
+		Name:  "ca-cert",
+		Usage: "CA certificate file Prometheus verifies the server with, instead of skipping verification with --insecure",
+	},
+	cli.StringFlag{
+		Name:  "metrics-path",
+		Usage: "metrics path to scrape, overriding the one selected for the server version, with a single alias and metrics endpoint",
+	},
+	cli.StringFlag{
+		Name:  "scrape-interval",
+		Usage: "how often to scrape the metrics, e.g. '2m', defaults to the global Prometheus setting",
+	},
+	cli.StringFlag{
+		Name:  "scrape-timeout",
+		Usage: "timeout of a scrape, not greater than --scrape-interval, defaults to the global Prometheus setting",
+	},
+	cli.StringFlag{
+		Name:  "cluster-label",
+		Usage: "add a 'cluster' label with this value to all the scraped series",
+	},
+	cli.StringSliceFlag{
+		Name:  "label",
+		Usage: "add a 'KEY=VALUE' label to the scrape targets, can be repeated",
+	},
+	cli.BoolFlag{
+		Name:  "honor-labels",
+		Usage: "keep the 'job' and 'instance' labels exported by the server on conflicts",
+	},
+	cli.StringFlag{
+		Name:  "format",
+		Usage: "format of the generated config. Valid options are '[yaml, json, servicemonitor]'",
+	},
+	cli.StringFlag{
+		Name:  "namespace",
+		Usage: "namespace of the generated ServiceMonitor",
+	},
+	cli.StringFlag{
+		Name:  "secret-name",
+		Usage: "name of the secret holding the bearer token under the 'token' key, for --format servicemonitor",
+	},
+	cli.StringFlag{
+		Name:  "port",
+		Usage: "name of the service port to scrape, for --format servicemonitor, defaults to 'http-minio' or 'https-minio'",
+	},
+	cli.StringFlag{
+		Name:  "output",
+		Usage: "write the generated config to this file instead of stdout",
+	},
+	cli.StringFlag{
+		Name:  "merge",
+		Usage: "add the generated scrape configs to this prometheus.yml, replacing the jobs with the same name",
+	},
+	cli.BoolFlag{
+		Name:  "overwrite",
+		Usage: "overwrite the --output and --token-file files if they exist",
+	},
+	cli.BoolFlag{
+		Name:  "validate",
+		Usage: "scrape the generated targets once, failing unless they serve Prometheus metrics",
+	},
+}
+
 var adminPrometheusGenerateCmd = cli.Command{
 	Name:            "generate",
 	Usage:           "generates prometheus config",
 	Action:          mainAdminPrometheusGenerate,
 	OnUsageError:    onUsageError,
 	Before:          setGlobalsFromContext,
-	Flags:           globalFlags,
+	Flags:           append(adminPrometheusGenerateFlags, globalFlags...),
 	HideHelpCommand: true,
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}} TARGET
+  {{.HelpName}} TARGET [TARGET...]
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}
+ENVIRONMENT VARIABLES:
+  MC_PROMETHEUS_JOB_NAME:  default name of the generated scrape job, overridden by --job-name
+  MC_PROMETHEUS_EXPIRY:    default validity of the bearer token, overridden by --expiry
+
 EXAMPLES:
   1. Generate a default prometheus config.
      {{.Prompt}} {{.HelpName}} myminio
 
+  2. Generate a prometheus config scraping cluster, node and bucket metrics.
+     {{.Prompt}} {{.HelpName}} myminio --all-metrics
+
+  3. Generate a prometheus config scraping the v3 node metrics, falling back to v2 on older servers.
+     {{.Prompt}} {{.HelpName}} myminio --metrics-type node
+
+  4. Generate a prometheus config with a job name unique to the 'myminio' cluster.
+     {{.Prompt}} {{.HelpName}} myminio --job-name minio-job-myminio
+
+  5. Generate a prometheus config with a bearer token valid for 30 days.
+     {{.Prompt}} {{.HelpName}} myminio --expiry 30d
+
+  6. Write the generated prometheus config to a file, replacing the previous one.
+     {{.Prompt}} {{.HelpName}} myminio --output /etc/prometheus/minio.yml --overwrite
+
+  7. Generate a prometheus config for a server behind a reverse proxy serving metrics under '/minio-metrics'.
+     {{.Prompt}} {{.HelpName}} myminio --metrics-path /minio-metrics/v2/metrics/cluster
+
+  8. Generate a prometheus config for a server exposing its metrics publicly.
+     {{.Prompt}} {{.HelpName}} myminio --public
+
+  9. Generate a prometheus config for a server with a self-signed certificate, verified with its CA.
+     {{.Prompt}} {{.HelpName}} myminio --ca-cert /etc/prometheus/minio-ca.crt
+
+  10. Generate a prometheus config safe to keep in version control, reading its bearer token from a file.
+      {{.Prompt}} {{.HelpName}} myminio --token-file /etc/prometheus/minio.token --output prometheus/minio.yml
+
+  11. Generate a Prometheus Operator ServiceMonitor reading the bearer token from the 'minio-prometheus' secret.
+      {{.Prompt}} {{.HelpName}} myminio --format servicemonitor --namespace minio --secret-name minio-prometheus
+
+  12. Generate a prometheus config scraping a large cluster every 2 minutes, with a 90 seconds timeout.
+      {{.Prompt}} {{.HelpName}} myminio --scrape-interval 2m --scrape-timeout 90s
+
+  13. Generate a prometheus config with a bearer token signed by an RSA private key.
+      {{.Prompt}} {{.HelpName}} myminio --sign-method RS256 --private-key /etc/prometheus/minio-jwt.pem
+
+  14. Generate a single prometheus config scraping three clusters, with the jobs 'minio-job-minio1' to 'minio-job-minio3'.
+      {{.Prompt}} {{.HelpName}} minio1 minio2 minio3
+
+  15. Generate a prometheus config labeling all the series of 'myminio' with 'cluster="eu-west"'.
+      {{.Prompt}} {{.HelpName}} myminio --cluster-label eu-west
+
+  16. Add the scrape job of 'myminio' to an existing prometheus config, replacing the previous one.
+      {{.Prompt}} {{.HelpName}} myminio --job-name myminio --merge /etc/prometheus/prometheus.yml
+
+  17. Generate a prometheus config, checking the subject, issuer and expiry of its bearer token.
+      {{.Prompt}} {{.HelpName}} myminio --show-claims
+
+  18. Generate a prometheus config for a server behind a reverse proxy, checking that Prometheus can scrape it.
+      {{.Prompt}} {{.HelpName}} myminio --metrics-path /minio-metrics/v2/metrics/cluster --validate
+
+  19. Generate a prometheus config labeling the targets of a cluster shared by several tenants.
+      {{.Prompt}} {{.HelpName}} myminio --label tenant=acme --label env=prod
+
+METRICS TYPES:
+  --metrics-type scrapes the v3 metrics groups matching the v2 metrics of the type, one job per group:
+    cluster:  /minio/metrics/v3/cluster
+    node:     /minio/metrics/v3/api and /minio/metrics/v3/system
+    resource: /minio/metrics/v3/system
+    bucket:   /minio/metrics/v3/bucket/api/BUCKET and /minio/metrics/v3/bucket/replication/BUCKET
+              for each bucket existing when the config is generated
+  Servers without v3 metrics get the v2 endpoint of the type instead.
+
+MERGE:
+  --merge keeps the other settings and scrape configs of the file, but not its comments. The file is
+  replaced at once, a failure leaves it untouched.
+
+SERVICEMONITOR:
+  --format servicemonitor generates a ServiceMonitor scraping the services labeled 'app: minio'. The bearer
+  token is not inlined, store it under the 'token' key of the --secret-name secret, e.g. with --token-file.
+  The endpoint scrapes the service port named by --port, 'http-minio' or 'https-minio' after the alias
+  scheme by default, as named by the MinIO Operator. A --ca-cert file must be readable at the same path
+  from within the Prometheus pods.
+
 `,
 }
 
 // PrometheusConfig - container to hold the top level scrape config.
 type PrometheusConfig struct {
-	ScrapeConfigs []ScrapeConfig `yaml:"scrape_configs,omitempty"`
+	ScrapeConfigs []ScrapeConfig `yaml:"scrape_configs,omitempty" json:"scrapeConfigs"`
 }
 
 // String colorized prometheus config yaml.
@@ -76,16 +269,21 @@ func (c PrometheusConfig) String() string {
 	return console.Colorize("yaml", string(b))
 }
 
-// JSON jsonified prometheus config.
+// JSON jsonified prometheus config, holding every scrape config
+// like the YAML output does.
 func (c PrometheusConfig) JSON() string {
-	jsonMessageBytes, e := json.MarshalIndent(c.ScrapeConfigs[0], "", " ")
+	if c.ScrapeConfigs == nil {
+		c.ScrapeConfigs = []ScrapeConfig{}
+	}
+	jsonMessageBytes, e := json.MarshalIndent(c, "", " ")
 	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
 	return string(jsonMessageBytes)
 }
 
 // StatConfig - container to hold the targets config.
 type StatConfig struct {
-	Targets []string `yaml:",flow" json:"targets"`
+	Targets []string          `yaml:",flow" json:"targets"`
+	Labels  map[string]string `yaml:"labels,omitempty" json:"labels,omitempty"`
 }
 
 // String colorized stat config yaml.
@@ -99,63 +297,494 @@ func (t StatConfig) String() string {
 
 // JSON jsonified stat config.
 func (t StatConfig) JSON() string {
-	jsonMessageBytes, e := json.MarshalIndent(t.Targets, "", " ")
+	jsonMessageBytes, e := json.MarshalIndent(t, "", " ")
 	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
 	return string(jsonMessageBytes)
 }
 
 // ScrapeConfig configures a scraping unit for Prometheus.
 type ScrapeConfig struct {
-	JobName       string       `yaml:"job_name" json:"jobName"`
-	BearerToken   string       `yaml:"bearer_token" json:"bearerToken"`
-	MetricsPath   string       `yaml:"metrics_path,omitempty" json:"metricsPath"`
-	Scheme        string       `yaml:"scheme,omitempty" json:"scheme"`
-	StaticConfigs []StatConfig `yaml:"static_configs,omitempty" json:"staticConfigs"`
+	JobName         string          `yaml:"job_name" json:"jobName"`
+	HonorLabels     bool            `yaml:"honor_labels,omitempty" json:"honorLabels,omitempty"`
+	BearerToken     string          `yaml:"bearer_token,omitempty" json:"bearerToken,omitempty"`
+	BearerTokenFile string          `yaml:"bearer_token_file,omitempty" json:"bearerTokenFile,omitempty"`
+	MetricsPath     string          `yaml:"metrics_path,omitempty" json:"metricsPath"`
+	ScrapeInterval  string          `yaml:"scrape_interval,omitempty" json:"scrapeInterval,omitempty"`
+	ScrapeTimeout   string          `yaml:"scrape_timeout,omitempty" json:"scrapeTimeout,omitempty"`
+	Scheme          string          `yaml:"scheme,omitempty" json:"scheme"`
+	TLSConfig       *TLSConfig      `yaml:"tls_config,omitempty" json:"tlsConfig,omitempty"`
+	StaticConfigs   []StatConfig    `yaml:"static_configs,omitempty" json:"staticConfigs"`
+	RelabelConfigs  []RelabelConfig `yaml:"relabel_configs,omitempty" json:"relabelConfigs,omitempty"`
+}
+
+// RelabelConfig rewrites a label of the scraped targets.
+type RelabelConfig struct {
+	SourceLabels []string `yaml:"source_labels,omitempty" json:"sourceLabels,omitempty"`
+	TargetLabel  string   `yaml:"target_label" json:"targetLabel"`
+	Replacement  string   `yaml:"replacement" json:"replacement"`
+}
+
+// TLSConfig configures how Prometheus verifies the server certificate.
+type TLSConfig struct {
+	CAFile             string `yaml:"ca_file,omitempty" json:"caFile,omitempty"`
+	InsecureSkipVerify bool   `yaml:"insecure_skip_verify,omitempty" json:"insecureSkipVerify,omitempty"`
+}
+
+// prometheusTLSConfig - returns the TLS config scraping u needs, nil for
+// plain http or when the system CAs verify the server certificate.
+func prometheusTLSConfig(u *url.URL, caFile string, insecure bool) *TLSConfig {
+	if u.Scheme != "https" {
+		return nil
+	}
+	switch {
+	case caFile != "":
+		return &TLSConfig{CAFile: caFile}
+	case insecure:
+		return &TLSConfig{InsecureSkipVerify: true}
+	}
+	return nil
 }
 
 const (
 	defaultPrometheusJWTExpiry = 100 * 365 * 24 * time.Hour
 )
 
-var defaultConfig = PrometheusConfig{
-	ScrapeConfigs: []ScrapeConfig{
-		{
-			JobName:     defaultJobName,
-			MetricsPath: defaultMetricsPath,
-			StaticConfigs: []StatConfig{
-				{
-					Targets: []string{""},
-				},
-			},
-		},
-	},
+// Environment variables setting the default job name and token expiry,
+// the flags take precedence over them.
+const (
+	envPrometheusJobName = "MC_PROMETHEUS_JOB_NAME"
+	envPrometheusExpiry  = "MC_PROMETHEUS_EXPIRY"
+)
+
+// prometheusJobName - returns the job name set by --job-name, else by
+// MC_PROMETHEUS_JOB_NAME, defaulting to defaultJobName.
+func prometheusJobName(jobName string) string {
+	if jobName != "" {
+		return jobName
+	}
+	if jobName = strings.TrimSpace(os.Getenv(envPrometheusJobName)); jobName != "" {
+		return jobName
+	}
+	return defaultJobName
+}
+
+// prometheusExpiry - returns the token validity set by --expiry, else by
+// MC_PROMETHEUS_EXPIRY, defaulting to defaultPrometheusJWTExpiry.
+func prometheusExpiry(expiry string) (time.Duration, *probe.Error) {
+	source := "--expiry"
+	if expiry == "" {
+		expiry, source = os.Getenv(envPrometheusExpiry), envPrometheusExpiry
+	}
+	if expiry == "" {
+		return defaultPrometheusJWTExpiry, nil
+	}
+	d, e := duration.ParseDuration(expiry)
+	if e != nil || d <= 0 {
+		return 0, probe.NewError(fmt.Errorf("invalid %s `%s`, expected a duration such as '720h' or '30d'", source, expiry))
+	}
+	return time.Duration(d), nil
+}
+
+// metricsEndpoint - metrics path along with the job name suffix used to scrape it.
+type metricsEndpoint struct {
+	suffix string
+	path   string
+}
+
+// allMetricsEndpoints - all the metrics endpoints exposed by the server.
+var allMetricsEndpoints = []metricsEndpoint{
+	{suffix: "cluster", path: defaultMetricsPath},
+	{suffix: "node", path: nodeMetricsPath},
+	{suffix: "bucket", path: bucketMetricsPath},
+}
+
+// metricsTypes - v2 metrics path of each type accepted by --metrics-type.
+var metricsTypes = map[string]string{
+	"cluster":  defaultMetricsPath,
+	"node":     nodeMetricsPath,
+	"bucket":   bucketMetricsPath,
+	"resource": resourceMetricsPath,
+}
+
+// v3MetricsGroups - v3 metrics groups holding the metrics of each type:
+// the node metrics are split between the API and the system groups, the
+// system group holds the resource metrics, and the bucket groups are
+// served for one bucket at a time, named after the group path.
+var v3MetricsGroups = map[string][]string{
+	"cluster":  {"/cluster"},
+	"node":     {"/api", "/system"},
+	"resource": {"/system"},
+	"bucket":   {"/bucket/api", "/bucket/replication"},
+}
+
+// metricsTypeEndpoints - returns the metrics endpoints serving
+// metricsType, v3 when supported by the server. The v3 bucket metrics
+// get one endpoint per group for each of buckets.
+func metricsTypeEndpoints(metricsType string, v3 bool, buckets []string) ([]metricsEndpoint, bool) {
+	v2Path, ok := metricsTypes[metricsType]
+	if !ok {
+		return nil, false
+	}
+	if !v3 {
+		return []metricsEndpoint{{suffix: metricsType, path: v2Path}}, true
+	}
+	groups := v3MetricsGroups[metricsType]
+	var endpoints []metricsEndpoint
+	for _, group := range groups {
+		suffix := metricsType
+		if len(groups) > 1 {
+			suffix += "-" + path.Base(group)
+		}
+		if metricsType != "bucket" {
+			endpoints = append(endpoints, metricsEndpoint{suffix: suffix, path: v3MetricsPathPrefix + group})
+			continue
+		}
+		for _, bucket := range buckets {
+			endpoints = append(endpoints, metricsEndpoint{suffix: suffix + "-" + bucket, path: v3MetricsPathPrefix + group + "/" + bucket})
+		}
+	}
+	return endpoints, true
+}
+
+// listBucketNames - returns the names of the buckets of alias.
+func listBucketNames(ctx context.Context, alias string) ([]string, *probe.Error) {
+	clnt, err := newClient(alias)
+	if err != nil {
+		return nil, err.Trace(alias)
+	}
+	var buckets []string
+	for content := range clnt.List(ctx, ListOptions{ShowDir: DirFirst}) {
+		if content.Err != nil {
+			return nil, content.Err.Trace(alias)
+		}
+		if content.Type.IsDir() {
+			buckets = append(buckets, path.Base(content.URL.Path))
+		}
+	}
+	return buckets, nil
 }
-var legacyConfig = PrometheusConfig{
-	ScrapeConfigs: []ScrapeConfig{
-		{
-			JobName:     defaultJobName,
-			MetricsPath: legacyMetricsPath,
-			StaticConfigs: []StatConfig{
-				{
-					Targets: []string{""},
-				},
+
+// parseServerVersion - returns the release time of a server version, false
+// for development builds and other versions without a release time.
+func parseServerVersion(version string) (time.Time, bool) {
+	version = strings.TrimPrefix(version, "RELEASE.")
+	for _, layout := range []string{mcReleaseTagTimeLayout, time.RFC3339} {
+		if t, e := time.Parse(layout, version); e == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// serverVersionOlderThan - reports whether version predates the release
+// minVersion, versions which cannot be parsed are considered recent.
+func serverVersionOlderThan(version, minVersion string) bool {
+	releaseTime, ok := parseServerVersion(version)
+	if !ok {
+		return false
+	}
+	minTime, _ := parseServerVersion(minVersion)
+	return releaseTime.Before(minTime)
+}
+
+// newScrapeConfig - returns a scrape config for a single metrics path.
+func newScrapeConfig(jobName, metricsPath, token string, u *url.URL) ScrapeConfig {
+	return ScrapeConfig{
+		JobName:     jobName,
+		BearerToken: token,
+		MetricsPath: metricsPath,
+		Scheme:      u.Scheme,
+		StaticConfigs: []StatConfig{
+			{
+				Targets: []string{u.Host},
 			},
 		},
-	},
+	}
 }
 
 // checkAdminPrometheusSyntax - validate all the passed arguments
 func checkAdminPrometheusSyntax(ctx *cli.Context) {
-	if len(ctx.Args()) != 1 {
+	if len(ctx.Args()) < 1 {
 		cli.ShowCommandHelpAndExit(ctx, "generate", 1) // last argument is exit code
 	}
+	if len(ctx.Args()) > 1 {
+		// A single token cannot authenticate against several clusters.
+		if ctx.IsSet("token-file") {
+			fatalIf(errInvalidArgument().Trace(ctx.Args()...), "--token-file cannot be used with multiple aliases.")
+		}
+		if ctx.String("format") == "servicemonitor" {
+			fatalIf(errInvalidArgument().Trace(ctx.Args()...), "--format servicemonitor cannot be used with multiple aliases.")
+		}
+		if ctx.IsSet("cluster-label") {
+			fatalIf(errInvalidArgument().Trace(ctx.Args()...), "--cluster-label cannot be used with multiple aliases.")
+		}
+		seen := make(map[string]bool)
+		for _, arg := range ctx.Args() {
+			if alias := cleanAlias(arg); seen[alias] {
+				fatalIf(errInvalidArgument().Trace(arg), "Alias `"+alias+"` is given more than once.")
+			} else {
+				seen[alias] = true
+			}
+		}
+	}
+	if ctx.IsSet("job-name") && strings.TrimSpace(ctx.String("job-name")) == "" {
+		fatalIf(errInvalidArgument().Trace(ctx.String("job-name")), "Job name cannot be empty.")
+	}
+	if ctx.Bool("public") && ctx.IsSet("expiry") {
+		fatalIf(errInvalidArgument().Trace(ctx.String("expiry")), "--expiry cannot be used with --public.")
+	}
+	if ctx.IsSet("cluster-label") && strings.TrimSpace(ctx.String("cluster-label")) == "" {
+		fatalIf(errInvalidArgument().Trace(ctx.String("cluster-label")), "Cluster label cannot be empty.")
+	}
+	for _, label := range ctx.StringSlice("label") {
+		if ctx.IsSet("cluster-label") && strings.HasPrefix(label, "cluster=") {
+			fatalIf(errInvalidArgument().Trace(label), "--label cluster cannot be used with --cluster-label.")
+		}
+	}
+	if ctx.Bool("public") && (ctx.IsSet("sign-method") || ctx.IsSet("private-key")) {
+		fatalIf(errInvalidArgument().Trace(ctx.Args()...), "--sign-method and --private-key cannot be used with --public.")
+	}
+	switch ctx.String("sign-method") {
+	case "", "HS512", "HS256":
+		if ctx.IsSet("private-key") {
+			fatalIf(errInvalidArgument().Trace(ctx.String("private-key")), "--private-key requires --sign-method RS256.")
+		}
+	case "RS256":
+		if ctx.String("private-key") == "" {
+			fatalIf(errInvalidArgument().Trace(ctx.Args()...), "--sign-method RS256 requires --private-key.")
+		}
+	default:
+		fatalIf(errInvalidArgument().Trace(ctx.String("sign-method")), "Invalid signing method. Valid options are `[HS512, HS256, RS256]`.")
+	}
+	if ctx.Bool("public") && ctx.Bool("show-claims") {
+		fatalIf(errInvalidArgument().Trace(ctx.Args()...), "--show-claims cannot be used with --public.")
+	}
+	if ctx.Bool("public") && ctx.IsSet("token-file") {
+		fatalIf(errInvalidArgument().Trace(ctx.String("token-file")), "--token-file cannot be used with --public.")
+	}
+	interval := parseScrapeDuration(ctx, "scrape-interval")
+	timeout := parseScrapeDuration(ctx, "scrape-timeout")
+	if interval > 0 && timeout > interval {
+		fatalIf(errInvalidArgument().Trace(ctx.String("scrape-timeout"), ctx.String("scrape-interval")),
+			"--scrape-timeout cannot be greater than --scrape-interval.")
+	}
+
+	if ctx.IsSet("merge") && (ctx.IsSet("output") || ctx.IsSet("format")) {
+		fatalIf(errInvalidArgument().Trace(ctx.String("merge")), "--merge cannot be used with --output or --format.")
+	}
+	if ctx.IsSet("merge") && globalJSON {
+		fatalIf(errInvalidArgument().Trace(ctx.String("merge")), "--merge cannot be used with --json.")
+	}
+	switch ctx.String("format") {
+	case "", "yaml", "json":
+		if ctx.IsSet("namespace") || ctx.IsSet("secret-name") || ctx.IsSet("port") {
+			fatalIf(errInvalidArgument().Trace(ctx.Args()...), "--namespace, --secret-name and --port require --format servicemonitor.")
+		}
+	case "servicemonitor":
+		if !ctx.Bool("public") && ctx.String("secret-name") == "" {
+			fatalIf(errInvalidArgument().Trace(ctx.Args()...), "--format servicemonitor requires --secret-name, or --public.")
+		}
+	default:
+		fatalIf(errInvalidArgument().Trace(ctx.String("format")), "Invalid format. Valid options are `[yaml, json, servicemonitor]`.")
+	}
+	if ctx.IsSet("metrics-path") {
+		metricsPath := ctx.String("metrics-path")
+		if !strings.HasPrefix(metricsPath, "/") {
+			fatalIf(errInvalidArgument().Trace(metricsPath), "Metrics path must begin with `/`.")
+		}
+		if ctx.Bool("all-metrics") {
+			fatalIf(errInvalidArgument().Trace(metricsPath), "--metrics-path cannot be used with --all-metrics.")
+		}
+	}
+}
+
+// prometheusSigningKey - returns the signing method and key of the bearer
+// token, HMAC methods sign with secretKey and RS256 with the PEM encoded
+// RSA private key in privateKeyFile.
+func prometheusSigningKey(method, privateKeyFile, secretKey string) (jwtgo.SigningMethod, interface{}, *probe.Error) {
+	switch method {
+	case "", "HS512":
+		return jwtgo.SigningMethodHS512, []byte(secretKey), nil
+	case "HS256":
+		return jwtgo.SigningMethodHS256, []byte(secretKey), nil
+	case "RS256":
+		data, e := ioutil.ReadFile(privateKeyFile)
+		if e != nil {
+			return nil, nil, probe.NewError(e)
+		}
+		key, e := jwtgo.ParseRSAPrivateKeyFromPEM(data)
+		if e != nil {
+			return nil, nil, probe.NewError(e)
+		}
+		return jwtgo.SigningMethodRS256, key, nil
+	}
+	return nil, nil, errInvalidArgument().Trace(method)
+}
+
+// generatePrometheusToken - returns a bearer token signed with the
+// credentials of hostConfig or --private-key, valid for --expiry.
+func generatePrometheusToken(ctx *cli.Context, hostConfig *aliasConfigV10) (string, error) {
+	expiry, perr := prometheusExpiry(ctx.String("expiry"))
+	fatalIf(perr, "Invalid token expiry.")
+	expiresAt := UTCNow().Add(expiry)
+
+	privateKeyFile := ctx.String("private-key")
+	method, key, perr := prometheusSigningKey(ctx.String("sign-method"), privateKeyFile, hostConfig.SecretKey)
+	fatalIf(perr.Trace(privateKeyFile), "Unable to load the RSA private key, --private-key expects a PEM encoded RSA private key.")
+
+	jwt := jwtgo.NewWithClaims(method, jwtgo.StandardClaims{
+		ExpiresAt: expiresAt.Unix(),
+		Subject:   hostConfig.AccessKey,
+		Issuer:    "prometheus",
+	})
+
+	token, err := jwt.SignedString(key)
+	if err != nil {
+		return "", err
+	}
+	// Keep stdout for the config itself.
+	expiryMsg := tokenExpiryMessage{URL: hostConfig.URL, ExpiresAt: expiresAt.UTC()}
+	if globalJSON {
+		fmt.Fprintln(os.Stderr, expiryMsg.JSON())
+	} else {
+		fmt.Fprintln(os.Stderr, expiryMsg.String())
+	}
+	if ctx.Bool("show-claims") {
+		msg, e := newTokenClaimsMessage(token, hostConfig.URL)
+		if e != nil {
+			return "", e
+		}
+		if globalJSON {
+			fmt.Fprintln(os.Stderr, msg.JSON())
+		} else {
+			fmt.Fprintln(os.Stderr, msg.String())
+		}
+	}
+	return token, nil
+}
+
+// tokenExpiryMessage - expiration date of a generated bearer token.
+type tokenExpiryMessage struct {
+	Status    string    `json:"status"`
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// String colorized token expiry message.
+func (m tokenExpiryMessage) String() string {
+	return console.Colorize("Expiry", "Bearer token expires on "+m.ExpiresAt.Format(printDate)+".")
+}
+
+// JSON jsonified token expiry message.
+func (m tokenExpiryMessage) JSON() string {
+	m.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonMessageBytes)
+}
+
+// tokenClaimsMessage - claims of a generated bearer token, for --show-claims.
+type tokenClaimsMessage struct {
+	Status    string    `json:"status"`
+	URL       string    `json:"url"`
+	Subject   string    `json:"subject"`
+	Issuer    string    `json:"issuer"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// newTokenClaimsMessage - decodes the claims of token, generated for url.
+func newTokenClaimsMessage(token, url string) (tokenClaimsMessage, error) {
+	var claims jwtgo.StandardClaims
+	if _, _, e := new(jwtgo.Parser).ParseUnverified(token, &claims); e != nil {
+		return tokenClaimsMessage{}, e
+	}
+	return tokenClaimsMessage{
+		URL:       url,
+		Subject:   claims.Subject,
+		Issuer:    claims.Issuer,
+		ExpiresAt: time.Unix(claims.ExpiresAt, 0).UTC(),
+	}, nil
+}
+
+// String colorized token claims message.
+func (m tokenClaimsMessage) String() string {
+	return console.Colorize("Claims", fmt.Sprintf("Bearer token claims for `%s`: subject `%s`, issuer `%s`, expires on %s.",
+		m.URL, m.Subject, m.Issuer, m.ExpiresAt.Format(printDate)))
+}
+
+// JSON jsonified token claims message.
+func (m tokenClaimsMessage) JSON() string {
+	m.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonMessageBytes)
+}
+
+// parseScrapeDuration - returns the duration passed to flag, zero when unset.
+func parseScrapeDuration(ctx *cli.Context, flag string) duration.Duration {
+	value := ctx.String(flag)
+	if value == "" {
+		return 0
+	}
+	d, e := duration.ParseDuration(value)
+	if e != nil || d <= 0 {
+		fatalIf(errInvalidArgument().Trace(value), "Invalid --"+flag+" `"+value+"`.")
+	}
+	return d
 }
 
 func generatePrometheusConfig(ctx *cli.Context) error {
-	// Get the alias parameter from cli
+	jobName := prometheusJobName(ctx.String("job-name"))
+
+	// Get the alias parameters from cli, each alias gets its own jobs
+	// named after it when there are several of them.
 	args := ctx.Args()
-	alias := cleanAlias(args.Get(0))
+	var config PrometheusConfig
+	for _, arg := range args {
+		alias := cleanAlias(arg)
+		aliasJobName := jobName
+		if len(args) > 1 {
+			aliasJobName = jobName + "-" + alias
+		}
+		scrapeConfigs, err := aliasScrapeConfigs(ctx, alias, aliasJobName)
+		if err != nil {
+			return err
+		}
+		config.ScrapeConfigs = append(config.ScrapeConfigs, scrapeConfigs...)
+	}
+	// A single path would scrape the same endpoint under several jobs.
+	if ctx.IsSet("metrics-path") && len(config.ScrapeConfigs) > 1 {
+		fatalIf(errInvalidArgument().Trace(ctx.String("metrics-path")), "--metrics-path cannot be used when several metrics endpoints are generated, by several aliases or by --metrics-type.")
+	}
+	config = config.withScrapeTimes(ctx.String("scrape-interval"), ctx.String("scrape-timeout")).
+		withLabels(ctx.String("cluster-label"), ctx.Bool("honor-labels"))
+	labels, perr := parseTargetLabels(ctx.StringSlice("label"))
+	fatalIf(perr, "Invalid --label, each label is given once as `KEY=VALUE`.")
+	config = config.withTargetLabels(labels)
+	if ctx.Bool("validate") {
+		err := validateScrapeConfigs(globalContext, config, ctx.String("metrics-path"))
+		fatalIf(err, "Unable to validate the generated prometheus config.")
+	}
+	if tokenFile := ctx.String("token-file"); tokenFile != "" {
+		if len(config.ScrapeConfigs) == 0 {
+			fatalIf(errInvalidArgument().Trace(tokenFile), "No metrics endpoint to scrape was generated, there is no bearer token to write.")
+		}
+		token := config.ScrapeConfigs[0].BearerToken
+		err := writePrivateFile(tokenFile, []byte(token), ctx.Bool("overwrite"))
+		if err != nil && os.IsExist(err.ToGoError()) {
+			fatalIf(err.Trace(tokenFile), "File `"+tokenFile+"` already exists, use --overwrite to replace it.")
+		}
+		fatalIf(err.Trace(tokenFile), "Unable to write the bearer token.")
+		config = config.withBearerTokenFile(tokenFile)
+	}
+	outputPrometheusConfig(ctx, config)
+
+	return nil
+}
 
+// aliasScrapeConfigs - returns the scrape configs of alias, with the
+// metrics paths served by its server version and its own bearer token.
+func aliasScrapeConfigs(ctx *cli.Context, alias, jobName string) ([]ScrapeConfig, error) {
 	if !isValidAlias(alias) {
 		fatalIf(errInvalidAlias(alias), "Invalid alias.")
 	}
@@ -163,24 +792,35 @@ func generatePrometheusConfig(ctx *cli.Context) error {
 	hostConfig := mustGetHostConfig(alias)
 	if hostConfig == nil {
 		fatalIf(errInvalidAliasedURL(alias), "No such alias `"+alias+"` found.")
-		return nil
+		return nil, nil
 	}
 
 	u, err := url.Parse(hostConfig.URL)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if caFile := ctx.String("ca-cert"); caFile != "" && u.Scheme != "https" {
+		fatalIf(errInvalidArgument().Trace(caFile, alias), "--ca-cert requires an alias with an https URL.")
 	}
+	tlsConfig := prometheusTLSConfig(u, ctx.String("ca-cert"), globalInsecure)
 
-	jwt := jwtgo.NewWithClaims(jwtgo.SigningMethodHS512, jwtgo.StandardClaims{
-		ExpiresAt: UTCNow().Add(defaultPrometheusJWTExpiry).Unix(),
-		Subject:   hostConfig.AccessKey,
-		Issuer:    "prometheus",
-	})
+	var token string
+	if !ctx.Bool("public") {
+		if token, err = generatePrometheusToken(ctx, hostConfig); err != nil {
+			return nil, err
+		}
+	}
 
-	token, err := jwt.SignedString([]byte(hostConfig.SecretKey))
-	if err != nil {
-		return err
+	metricsType := ctx.String("metrics-type")
+	if metricsType != "" {
+		if _, ok := metricsTypes[metricsType]; !ok {
+			fatalIf(errInvalidArgument().Trace(metricsType), "Invalid metrics type. Valid options are `[cluster, node, bucket, resource]`.")
+		}
+		if ctx.Bool("all-metrics") {
+			fatalIf(errInvalidArgument().Trace(alias), "--metrics-type cannot be used with --all-metrics.")
+		}
 	}
+
 	client, cerr := newAdminClient(alias)
 	fatalIf(cerr, "Unable to initialize admin connection.")
 
@@ -188,31 +828,260 @@ func generatePrometheusConfig(ctx *cli.Context) error {
 	if e != nil {
 		fatalIf(probe.NewError(e), "Failed to get server info.")
 	}
-	if info.Servers[0].Version < "2021-01-30T00-20-58Z" {
-		legacyConfig.ScrapeConfigs[0].BearerToken = token
-		legacyConfig.ScrapeConfigs[0].Scheme = u.Scheme
-		legacyConfig.ScrapeConfigs[0].StaticConfigs[0].Targets[0] = u.Host
-		printMsg(legacyConfig)
-		return nil
+	// Without server info, e.g. when all servers are down, assume a
+	// recent server.
+	var version string
+	if len(info.Servers) == 0 {
+		errorIf(errDummy().Trace(alias), "Server returned no server info, cannot determine metrics endpoint, using the default one.")
+	} else {
+		version = info.Servers[0].Version
 	}
+	var config PrometheusConfig
+	switch {
+	case serverVersionOlderThan(version, v2MetricsMinVersion):
+		if ctx.Bool("all-metrics") {
+			fatalIf(errInvalidArgument().Trace(alias), "--all-metrics is not supported by this server version.")
+		}
+		if metricsType != "" {
+			fatalIf(errInvalidArgument().Trace(alias), "--metrics-type is not supported by this server version.")
+		}
+		config.ScrapeConfigs = []ScrapeConfig{newScrapeConfig(jobName, legacyMetricsPath, token, u)}
+	case metricsType != "":
+		v3 := !serverVersionOlderThan(version, v3MetricsMinVersion)
+		if !v3 {
+			errorIf(errDummy().Trace(alias), "Server version `"+version+"` does not serve v3 metrics, using the v2 `"+metricsType+"` metrics instead.")
+		}
+		var buckets []string
+		if v3 && metricsType == "bucket" {
+			var err *probe.Error
+			buckets, err = listBucketNames(globalContext, alias)
+			fatalIf(err, "Unable to list the buckets of `"+alias+"`.")
+			if len(buckets) == 0 {
+				errorIf(errDummy().Trace(alias), "No buckets found, the v3 bucket metrics are served for one bucket at a time.")
+			}
+		}
+		endpoints, _ := metricsTypeEndpoints(metricsType, v3, buckets)
+		for _, endpoint := range endpoints {
+			config.ScrapeConfigs = append(config.ScrapeConfigs, newScrapeConfig(jobName+"-"+endpoint.suffix, endpoint.path, token, u))
+		}
+	case ctx.Bool("all-metrics"):
+		for _, endpoint := range allMetricsEndpoints {
+			config.ScrapeConfigs = append(config.ScrapeConfigs, newScrapeConfig(jobName+"-"+endpoint.suffix, endpoint.path, token, u))
+		}
+	default:
+		config.ScrapeConfigs = []ScrapeConfig{newScrapeConfig(jobName, defaultMetricsPath, token, u)}
+	}
+	return config.withTLSConfig(tlsConfig).ScrapeConfigs, nil
+}
 
-	// Setting the values
-	defaultConfig.ScrapeConfigs[0].BearerToken = token
-	defaultConfig.ScrapeConfigs[0].Scheme = u.Scheme
-	defaultConfig.ScrapeConfigs[0].StaticConfigs[0].Targets[0] = u.Host
+// prometheusOutputMessage - printed once the config is written to --output.
+type prometheusOutputMessage struct {
+	Status string `json:"status"`
+	Path   string `json:"path"`
+}
 
-	printMsg(defaultConfig)
+// String colorized output message.
+func (m prometheusOutputMessage) String() string {
+	return console.Colorize("Output", "Prometheus config written to `"+m.Path+"`.")
+}
 
-	return nil
+// JSON jsonified output message.
+func (m prometheusOutputMessage) JSON() string {
+	m.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonMessageBytes)
+}
+
+// withMetricsPath - returns config scraping metricsPath in all its scrape configs.
+func (c PrometheusConfig) withMetricsPath(metricsPath string) PrometheusConfig {
+	scrapeConfigs := make([]ScrapeConfig, len(c.ScrapeConfigs))
+	for i, scrapeConfig := range c.ScrapeConfigs {
+		scrapeConfig.MetricsPath = metricsPath
+		scrapeConfigs[i] = scrapeConfig
+	}
+	return PrometheusConfig{ScrapeConfigs: scrapeConfigs}
+}
+
+// withBearerTokenFile - returns config reading the bearer token of all
+// its scrape configs from tokenFile instead of embedding it.
+func (c PrometheusConfig) withBearerTokenFile(tokenFile string) PrometheusConfig {
+	scrapeConfigs := make([]ScrapeConfig, len(c.ScrapeConfigs))
+	for i, scrapeConfig := range c.ScrapeConfigs {
+		scrapeConfig.BearerToken = ""
+		scrapeConfig.BearerTokenFile = tokenFile
+		scrapeConfigs[i] = scrapeConfig
+	}
+	return PrometheusConfig{ScrapeConfigs: scrapeConfigs}
+}
+
+// withScrapeTimes - returns config with the scrape interval and timeout
+// of all its scrape configs set, empty values are left unset.
+func (c PrometheusConfig) withScrapeTimes(interval, timeout string) PrometheusConfig {
+	scrapeConfigs := make([]ScrapeConfig, len(c.ScrapeConfigs))
+	for i, scrapeConfig := range c.ScrapeConfigs {
+		scrapeConfig.ScrapeInterval = interval
+		scrapeConfig.ScrapeTimeout = timeout
+		scrapeConfigs[i] = scrapeConfig
+	}
+	return PrometheusConfig{ScrapeConfigs: scrapeConfigs}
+}
+
+// withLabels - returns config labeling the series of all its scrape configs
+// with cluster, when not empty, and honoring the labels of the server when
+// honorLabels is set.
+func (c PrometheusConfig) withLabels(cluster string, honorLabels bool) PrometheusConfig {
+	scrapeConfigs := make([]ScrapeConfig, len(c.ScrapeConfigs))
+	for i, scrapeConfig := range c.ScrapeConfigs {
+		scrapeConfig.HonorLabels = honorLabels
+		if cluster != "" {
+			scrapeConfig.RelabelConfigs = []RelabelConfig{{TargetLabel: "cluster", Replacement: cluster}}
+		}
+		scrapeConfigs[i] = scrapeConfig
+	}
+	return PrometheusConfig{ScrapeConfigs: scrapeConfigs}
+}
+
+// parseTargetLabels - returns the labels of 'KEY=VALUE' values, nil
+// when there are none.
+func parseTargetLabels(values []string) (map[string]string, *probe.Error) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+	labels := make(map[string]string, len(values))
+	for _, value := range values {
+		kv := strings.SplitN(value, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, errInvalidArgument().Trace(value)
+		}
+		if _, ok := labels[kv[0]]; ok {
+			return nil, probe.NewError(fmt.Errorf("label `%s` is given more than once", kv[0]))
+		}
+		labels[kv[0]] = kv[1]
+	}
+	return labels, nil
+}
+
+// withTargetLabels - returns config with labels attached to the targets of
+// all its scrape configs, when not empty.
+func (c PrometheusConfig) withTargetLabels(labels map[string]string) PrometheusConfig {
+	scrapeConfigs := make([]ScrapeConfig, len(c.ScrapeConfigs))
+	for i, scrapeConfig := range c.ScrapeConfigs {
+		if len(labels) > 0 {
+			staticConfigs := make([]StatConfig, len(scrapeConfig.StaticConfigs))
+			for j, staticConfig := range scrapeConfig.StaticConfigs {
+				staticConfig.Labels = labels
+				staticConfigs[j] = staticConfig
+			}
+			scrapeConfig.StaticConfigs = staticConfigs
+		}
+		scrapeConfigs[i] = scrapeConfig
+	}
+	return PrometheusConfig{ScrapeConfigs: scrapeConfigs}
+}
+
+// withTLSConfig - returns config with tlsConfig in all its scrape configs.
+func (c PrometheusConfig) withTLSConfig(tlsConfig *TLSConfig) PrometheusConfig {
+	scrapeConfigs := make([]ScrapeConfig, len(c.ScrapeConfigs))
+	for i, scrapeConfig := range c.ScrapeConfigs {
+		scrapeConfig.TLSConfig = tlsConfig
+		scrapeConfigs[i] = scrapeConfig
+	}
+	return PrometheusConfig{ScrapeConfigs: scrapeConfigs}
+}
+
+// outputPrometheusConfig - prints config, or writes it to the --output file,
+// after applying the --metrics-path override.
+func outputPrometheusConfig(ctx *cli.Context, config PrometheusConfig) {
+	if metricsPath := ctx.String("metrics-path"); metricsPath != "" {
+		config = config.withMetricsPath(metricsPath)
+	}
+
+	var output message = config
+	if ctx.String("format") == "servicemonitor" {
+		output = newServiceMonitor(config, prometheusJobName(ctx.String("job-name")), ctx.String("namespace"), ctx.String("secret-name"), ctx.String("port"))
+	}
+
+	if path := ctx.String("merge"); path != "" {
+		existing, e := ioutil.ReadFile(path)
+		fatalIf(probe.NewError(e).Trace(path), "Unable to read prometheus config.")
+		data, err := mergePrometheusConfig(existing, config)
+		fatalIf(err.Trace(path), "Unable to parse `"+path+"`, --merge expects a prometheus config in YAML.")
+		fi, e := os.Stat(path)
+		fatalIf(probe.NewError(e).Trace(path), "Unable to read prometheus config.")
+		fatalIf(writeFileAtomic(path, data, fi.Mode().Perm()).Trace(path), "Unable to write prometheus config.")
+		printMsg(prometheusOutputMessage{Path: path})
+		return
+	}
+
+	path := ctx.String("output")
+	if path == "" {
+		printMsg(output)
+		return
+	}
+	err := writePrometheusConfig(output, path, globalJSON, ctx.Bool("overwrite"))
+	if err != nil && os.IsExist(err.ToGoError()) {
+		fatalIf(err.Trace(path), "File `"+path+"` already exists, use --overwrite to replace it.")
+	}
+	fatalIf(err.Trace(path), "Unable to write prometheus config.")
+	printMsg(prometheusOutputMessage{Path: path})
+}
+
+// writePrometheusConfig - writes a generated config to path as uncolored
+// YAML, or JSON, readable by its owner only since it holds a bearer token.
+func writePrometheusConfig(config message, path string, asJSON, overwrite bool) *probe.Error {
+	var data []byte
+	var e error
+	if asJSON {
+		data, e = gojson.MarshalIndent(config, "", " ")
+	} else {
+		data, e = yaml.Marshal(config)
+	}
+	if e != nil {
+		return probe.NewError(e)
+	}
+	return writePrivateFile(path, data, overwrite)
+}
+
+// writePrivateFile - writes data to a file readable by its owner only,
+// failing if it exists unless overwrite is set.
+func writePrivateFile(path string, data []byte, overwrite bool) *probe.Error {
+	flags := os.O_WRONLY | os.O_CREATE | os.O_EXCL
+	if overwrite {
+		flags = os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	}
+	f, e := os.OpenFile(path, flags, 0600)
+	if e != nil {
+		return probe.NewError(e)
+	}
+	// An overwritten file keeps its mode otherwise.
+	if e = f.Chmod(0600); e != nil {
+		f.Close()
+		return probe.NewError(e)
+	}
+	if _, e = f.Write(data); e != nil {
+		f.Close()
+		return probe.NewError(e)
+	}
+	return probe.NewError(f.Close())
 }
 
 // mainAdminPrometheus is the handle for "mc admin prometheus generate" sub-command.
 func mainAdminPrometheusGenerate(ctx *cli.Context) error {
 
 	console.SetColor("yaml", color.New(color.FgGreen))
+	console.SetColor("Expiry", color.New(color.FgYellow))
+	console.SetColor("Claims", color.New(color.FgCyan))
+	console.SetColor("Output", color.New(color.FgGreen))
+	console.SetColor("Validated", color.New(color.FgGreen))
 
 	checkAdminPrometheusSyntax(ctx)
 
+	// --format json is an alias of --json.
+	if ctx.String("format") == "json" {
+		globalJSON = true
+	}
+
 	if err := generatePrometheusConfig(ctx); err != nil {
 		return nil
 	}
@@ -0,0 +1,587 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	jwtgo "github.com/dgrijalva/jwt-go"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// v3MetricsRoutes - metrics collector paths served by the server under
+// /minio/metrics/v3, a parent path serves all the metrics below it and
+// the bucket ones are followed by the bucket name.
+var v3MetricsRoutes = []string{
+	"/api/requests",
+	"/bucket/api",
+	"/bucket/replication",
+	"/system/drive",
+	"/system/memory",
+	"/system/cpu",
+	"/system/network/internode",
+	"/system/process",
+	"/cluster/health",
+	"/cluster/iam",
+	"/cluster/config",
+	"/cluster/usage/objects",
+	"/cluster/usage/buckets",
+	"/cluster/erasure-set",
+	"/debug/go",
+	"/ilm",
+	"/audit",
+	"/logger/webhook",
+	"/notification",
+	"/replication",
+	"/scanner",
+}
+
+// servedByV3 - tells whether the server serves metricsPath.
+func servedByV3(metricsPath string) bool {
+	if !strings.HasPrefix(metricsPath, v3MetricsPathPrefix+"/") {
+		return false
+	}
+	route := strings.TrimPrefix(metricsPath, v3MetricsPathPrefix)
+	if strings.HasPrefix(route, "/bucket/") {
+		route = route[:strings.LastIndex(route, "/")]
+	}
+	for _, r := range v3MetricsRoutes {
+		if r == route || strings.HasPrefix(r, route+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func TestMetricsTypeEndpoints(t *testing.T) {
+	testCases := []struct {
+		metricsType string
+		v3          bool
+		endpoints   []metricsEndpoint
+		ok          bool
+	}{
+		{"cluster", true, []metricsEndpoint{{"cluster", "/minio/metrics/v3/cluster"}}, true},
+		{"cluster", false, []metricsEndpoint{{"cluster", defaultMetricsPath}}, true},
+		{"node", true, []metricsEndpoint{{"node-api", "/minio/metrics/v3/api"}, {"node-system", "/minio/metrics/v3/system"}}, true},
+		{"node", false, []metricsEndpoint{{"node", nodeMetricsPath}}, true},
+		{"resource", true, []metricsEndpoint{{"resource", "/minio/metrics/v3/system"}}, true},
+		{"resource", false, []metricsEndpoint{{"resource", resourceMetricsPath}}, true},
+		{"bucket", true, []metricsEndpoint{
+			{"bucket-api-photos", "/minio/metrics/v3/bucket/api/photos"},
+			{"bucket-api-logs", "/minio/metrics/v3/bucket/api/logs"},
+			{"bucket-replication-photos", "/minio/metrics/v3/bucket/replication/photos"},
+			{"bucket-replication-logs", "/minio/metrics/v3/bucket/replication/logs"},
+		}, true},
+		{"bucket", false, []metricsEndpoint{{"bucket", bucketMetricsPath}}, true},
+		{"disk", true, nil, false},
+	}
+
+	for i, testCase := range testCases {
+		endpoints, ok := metricsTypeEndpoints(testCase.metricsType, testCase.v3, []string{"photos", "logs"})
+		if ok != testCase.ok || !reflect.DeepEqual(endpoints, testCase.endpoints) {
+			t.Fatalf("Test %d: expected %v %v, got %v %v", i+1, testCase.endpoints, testCase.ok, endpoints, ok)
+		}
+	}
+
+	for metricsType := range metricsTypes {
+		endpoints, _ := metricsTypeEndpoints(metricsType, true, []string{"photos"})
+		for _, endpoint := range endpoints {
+			if !servedByV3(endpoint.path) {
+				t.Fatalf("%s metrics path %s is not served by the server", metricsType, endpoint.path)
+			}
+		}
+	}
+}
+
+func TestPrometheusConfigJSON(t *testing.T) {
+	testCases := []struct {
+		config   PrometheusConfig
+		expected string
+	}{
+		{PrometheusConfig{}, `{"scrapeConfigs":[]}`},
+		{
+			PrometheusConfig{ScrapeConfigs: []ScrapeConfig{
+				{JobName: "minio-job-cluster", MetricsPath: defaultMetricsPath},
+				{JobName: "minio-job-node", MetricsPath: nodeMetricsPath},
+			}},
+			`{"scrapeConfigs":[` +
+				`{"jobName":"minio-job-cluster","metricsPath":"/minio/v2/metrics/cluster","scheme":"","staticConfigs":null},` +
+				`{"jobName":"minio-job-node","metricsPath":"/minio/v2/metrics/node","scheme":"","staticConfigs":null}]}`,
+		},
+	}
+
+	for i, testCase := range testCases {
+		var buf bytes.Buffer
+		if e := json.Compact(&buf, []byte(testCase.config.JSON())); e != nil {
+			t.Fatalf("Test %d: %v", i+1, e)
+		}
+		if buf.String() != testCase.expected {
+			t.Fatalf("Test %d: expected %s, got %s", i+1, testCase.expected, buf.String())
+		}
+	}
+}
+
+func TestWritePrometheusConfig(t *testing.T) {
+	config := PrometheusConfig{ScrapeConfigs: []ScrapeConfig{{JobName: "minio-job", BearerToken: "secret"}}}
+	root, e := ioutil.TempDir(os.TempDir(), "prometheus-")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(root)
+	path := filepath.Join(root, "prometheus.yml")
+
+	if err := writePrometheusConfig(config, path, false, false); err != nil {
+		t.Fatalf("Test 1: unexpected error: %v", err)
+	}
+	fi, e := os.Stat(path)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if fi.Mode().Perm() != 0600 {
+		t.Fatalf("Test 1: expected mode 0600, got %v", fi.Mode().Perm())
+	}
+	if data, _ := ioutil.ReadFile(path); string(data) != "scrape_configs:\n- job_name: minio-job\n  bearer_token: secret\n" {
+		t.Fatalf("Test 1: unexpected content %q", data)
+	}
+
+	if err := writePrometheusConfig(config, path, true, false); err == nil || !os.IsExist(err.ToGoError()) {
+		t.Fatalf("Test 2: expected file exists error, got %v", err)
+	}
+
+	if err := os.Chmod(path, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := writePrometheusConfig(config, path, true, true); err != nil {
+		t.Fatalf("Test 3: unexpected error: %v", err)
+	}
+	if fi, _ = os.Stat(path); fi.Mode().Perm() != 0600 {
+		t.Fatalf("Test 3: expected mode 0600, got %v", fi.Mode().Perm())
+	}
+	if data, _ := ioutil.ReadFile(path); !bytes.HasPrefix(data, []byte(`{`)) {
+		t.Fatalf("Test 3: expected JSON content, got %q", data)
+	}
+}
+
+func TestPrometheusConfigWithMetricsPath(t *testing.T) {
+	config := PrometheusConfig{ScrapeConfigs: []ScrapeConfig{
+		{JobName: "minio-job", MetricsPath: defaultMetricsPath},
+		{JobName: "minio-job-node", MetricsPath: nodeMetricsPath},
+	}}
+
+	overridden := config.withMetricsPath("/proxy/metrics")
+	for i, scrapeConfig := range overridden.ScrapeConfigs {
+		if scrapeConfig.MetricsPath != "/proxy/metrics" {
+			t.Fatalf("Test %d: expected /proxy/metrics, got %s", i+1, scrapeConfig.MetricsPath)
+		}
+		if scrapeConfig.JobName != config.ScrapeConfigs[i].JobName {
+			t.Fatalf("Test %d: expected job %s, got %s", i+1, config.ScrapeConfigs[i].JobName, scrapeConfig.JobName)
+		}
+	}
+	if config.ScrapeConfigs[0].MetricsPath != defaultMetricsPath {
+		t.Fatalf("Original config was modified: %s", config.ScrapeConfigs[0].MetricsPath)
+	}
+}
+
+func TestServerVersionOlderThan(t *testing.T) {
+	testCases := []struct {
+		version  string
+		expected bool
+	}{
+		{"", false},
+		{"DEVELOPMENT.GOGET", false},
+		{"DEVELOPMENT.2023-01-02T03-04-05Z", false},
+		{"1.2.3", false},
+		{"2020-12-31T23-59-59Z", true},
+		{"RELEASE.2020-12-31T23-59-59Z", true},
+		{"2020-12-31T23:59:59Z", true},
+		{v2MetricsMinVersion, false},
+		{"RELEASE." + v2MetricsMinVersion, false},
+		{"2021-01-30T00:20:57Z", true},
+		{"2021-01-30T00-20-59Z", false},
+		{"2023-06-19T19-52-50Z", false},
+	}
+
+	for i, testCase := range testCases {
+		if older := serverVersionOlderThan(testCase.version, v2MetricsMinVersion); older != testCase.expected {
+			t.Fatalf("Test %d: expected %v for %q, got %v", i+1, testCase.expected, testCase.version, older)
+		}
+	}
+}
+
+func TestScrapeConfigPublic(t *testing.T) {
+	u := &url.URL{Scheme: "https", Host: "minio.example.com:9000"}
+	testCases := []struct {
+		token    string
+		expected string
+	}{
+		{"", "job_name: minio-job\nmetrics_path: /minio/v2/metrics/cluster\nscheme: https\nstatic_configs:\n- targets: ['minio.example.com:9000']\n"},
+		{"secret", "job_name: minio-job\nbearer_token: secret\nmetrics_path: /minio/v2/metrics/cluster\nscheme: https\nstatic_configs:\n- targets: ['minio.example.com:9000']\n"},
+	}
+
+	for i, testCase := range testCases {
+		data, e := yaml.Marshal(newScrapeConfig("minio-job", defaultMetricsPath, testCase.token, u))
+		if e != nil {
+			t.Fatalf("Test %d: %v", i+1, e)
+		}
+		if string(data) != testCase.expected {
+			t.Fatalf("Test %d: expected %q, got %q", i+1, testCase.expected, data)
+		}
+	}
+}
+
+func TestPrometheusTLSConfig(t *testing.T) {
+	httpsURL := &url.URL{Scheme: "https", Host: "minio.example.com:9000"}
+	httpURL := &url.URL{Scheme: "http", Host: "minio.example.com:9000"}
+	testCases := []struct {
+		u        *url.URL
+		caFile   string
+		insecure bool
+		expected string
+	}{
+		{httpURL, "", true, ""},
+		{httpsURL, "", false, ""},
+		{httpsURL, "", true, "tls_config:\n  insecure_skip_verify: true\n"},
+		{httpsURL, "/etc/prometheus/ca.crt", true, "tls_config:\n  ca_file: /etc/prometheus/ca.crt\n"},
+	}
+
+	for i, testCase := range testCases {
+		scrapeConfig := newScrapeConfig("minio-job", defaultMetricsPath, "", testCase.u)
+		scrapeConfig.TLSConfig = prometheusTLSConfig(testCase.u, testCase.caFile, testCase.insecure)
+		data, e := yaml.Marshal(scrapeConfig)
+		if e != nil {
+			t.Fatalf("Test %d: %v", i+1, e)
+		}
+		if got := string(data); strings.Contains(got, "tls_config") != (testCase.expected != "") || !strings.Contains(got, testCase.expected) {
+			t.Fatalf("Test %d: expected %q in %q", i+1, testCase.expected, got)
+		}
+	}
+}
+
+func TestPrometheusConfigWithBearerTokenFile(t *testing.T) {
+	u := &url.URL{Scheme: "http", Host: "minio.example.com:9000"}
+	config := PrometheusConfig{ScrapeConfigs: []ScrapeConfig{
+		newScrapeConfig("minio-job", defaultMetricsPath, "secret", u),
+	}}.withBearerTokenFile("/etc/prometheus/minio.token")
+
+	data, e := yaml.Marshal(config)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if strings.Contains(string(data), "bearer_token:") || strings.Contains(string(data), "secret") {
+		t.Fatalf("Expected no inline bearer token, got %q", data)
+	}
+	if !strings.Contains(string(data), "bearer_token_file: /etc/prometheus/minio.token\n") {
+		t.Fatalf("Expected bearer_token_file, got %q", data)
+	}
+	if jsonData := config.JSON(); strings.Contains(jsonData, `"bearerToken"`) || !strings.Contains(jsonData, `"bearerTokenFile"`) {
+		t.Fatalf("Expected only bearerTokenFile in JSON, got %s", jsonData)
+	}
+}
+
+func TestPrometheusConfigWithScrapeTimes(t *testing.T) {
+	u := &url.URL{Scheme: "http", Host: "minio.example.com:9000"}
+	config := PrometheusConfig{ScrapeConfigs: []ScrapeConfig{newScrapeConfig("minio-job", defaultMetricsPath, "", u)}}
+
+	testCases := []struct {
+		interval, timeout string
+		expected          []string
+		unexpected        []string
+	}{
+		{"", "", nil, []string{"scrape_interval", "scrape_timeout"}},
+		{"2m", "90s", []string{"scrape_interval: 2m\n", "scrape_timeout: 90s\n"}, nil},
+		{"2m", "", []string{"scrape_interval: 2m\n"}, []string{"scrape_timeout"}},
+	}
+
+	for i, testCase := range testCases {
+		data, e := yaml.Marshal(config.withScrapeTimes(testCase.interval, testCase.timeout))
+		if e != nil {
+			t.Fatalf("Test %d: %v", i+1, e)
+		}
+		for _, s := range testCase.expected {
+			if !strings.Contains(string(data), s) {
+				t.Fatalf("Test %d: expected %q in %q", i+1, s, data)
+			}
+		}
+		for _, s := range testCase.unexpected {
+			if strings.Contains(string(data), s) {
+				t.Fatalf("Test %d: unexpected %q in %q", i+1, s, data)
+			}
+		}
+	}
+}
+
+func TestPrometheusSigningKey(t *testing.T) {
+	dir, e := ioutil.TempDir("", "prometheus-signing-key")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+
+	rsaKey, e := rsa.GenerateKey(rand.Reader, 2048)
+	if e != nil {
+		t.Fatal(e)
+	}
+	keyFile := filepath.Join(dir, "key.pem")
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(rsaKey)})
+	if e = ioutil.WriteFile(keyFile, keyPEM, 0600); e != nil {
+		t.Fatal(e)
+	}
+	badKeyFile := filepath.Join(dir, "bad.pem")
+	if e = ioutil.WriteFile(badKeyFile, []byte("not a key"), 0600); e != nil {
+		t.Fatal(e)
+	}
+
+	testCases := []struct {
+		method, privateKeyFile string
+		expected               jwtgo.SigningMethod
+		success                bool
+	}{
+		{"", "", jwtgo.SigningMethodHS512, true},
+		{"HS512", "", jwtgo.SigningMethodHS512, true},
+		{"HS256", "", jwtgo.SigningMethodHS256, true},
+		{"RS256", keyFile, jwtgo.SigningMethodRS256, true},
+		{"RS256", badKeyFile, nil, false},
+		{"RS256", filepath.Join(dir, "missing.pem"), nil, false},
+		{"ES256", "", nil, false},
+	}
+
+	for i, testCase := range testCases {
+		method, key, err := prometheusSigningKey(testCase.method, testCase.privateKeyFile, "secret")
+		if (err == nil) != testCase.success {
+			t.Fatalf("Test %d: expected success %t, got %v", i+1, testCase.success, err)
+		}
+		if !testCase.success {
+			continue
+		}
+		if method != testCase.expected {
+			t.Fatalf("Test %d: expected %s, got %s", i+1, testCase.expected.Alg(), method.Alg())
+		}
+		token, e := jwtgo.NewWithClaims(method, jwtgo.StandardClaims{Issuer: "prometheus"}).SignedString(key)
+		if e != nil {
+			t.Fatalf("Test %d: %v", i+1, e)
+		}
+		_, e = jwtgo.Parse(token, func(*jwtgo.Token) (interface{}, error) {
+			if method == jwtgo.SigningMethodRS256 {
+				return &rsaKey.PublicKey, nil
+			}
+			return []byte("secret"), nil
+		})
+		if e != nil {
+			t.Fatalf("Test %d: unable to verify token: %v", i+1, e)
+		}
+	}
+}
+
+func TestPrometheusConfigWithLabels(t *testing.T) {
+	u, e := url.Parse("https://minio.example.com:9000")
+	if e != nil {
+		t.Fatal(e)
+	}
+	config := PrometheusConfig{ScrapeConfigs: []ScrapeConfig{newScrapeConfig("minio-job", defaultMetricsPath, "secret", u)}}
+	expected, e := yaml.Marshal(config)
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	// Without any label option the config is unchanged.
+	data, e := yaml.Marshal(config.withLabels("", false))
+	if e != nil {
+		t.Fatal(e)
+	}
+	if !bytes.Equal(data, expected) {
+		t.Fatalf("Expected %q, got %q", expected, data)
+	}
+
+	data, e = yaml.Marshal(config.withLabels("eu-west", true))
+	if e != nil {
+		t.Fatal(e)
+	}
+	for _, s := range []string{"honor_labels: true\n", "  relabel_configs:\n  - target_label: cluster\n    replacement: eu-west\n"} {
+		if !strings.Contains(string(data), s) {
+			t.Fatalf("Expected %q in %q", s, data)
+		}
+	}
+
+	sm := newServiceMonitor(config.withLabels("eu-west", true), "minio-job", "", "", "")
+	endpoint := sm.Spec.Endpoints[0]
+	if !endpoint.HonorLabels || len(endpoint.Relabelings) != 1 || endpoint.Relabelings[0].Replacement != "eu-west" {
+		t.Fatalf("Unexpected ServiceMonitor endpoint %+v", endpoint)
+	}
+}
+
+func TestTokenClaimsMessage(t *testing.T) {
+	expiresAt := time.Date(2031, time.March, 1, 12, 0, 0, 0, time.UTC)
+	token, e := jwtgo.NewWithClaims(jwtgo.SigningMethodHS512, jwtgo.StandardClaims{
+		ExpiresAt: expiresAt.Unix(),
+		Subject:   "minio",
+		Issuer:    "prometheus",
+	}).SignedString([]byte("minio123"))
+	if e != nil {
+		t.Fatal(e)
+	}
+	msg, e := newTokenClaimsMessage(token, "http://localhost:9000")
+	if e != nil {
+		t.Fatal(e)
+	}
+	if msg.Subject != "minio" || msg.Issuer != "prometheus" || !msg.ExpiresAt.Equal(expiresAt) {
+		t.Fatalf("Unexpected claims %+v", msg)
+	}
+	var decoded map[string]interface{}
+	if e = json.Unmarshal([]byte(msg.JSON()), &decoded); e != nil {
+		t.Fatal(e)
+	}
+	if decoded["expiresAt"] != "2031-03-01T12:00:00Z" || decoded["subject"] != "minio" {
+		t.Fatalf("Unexpected JSON claims %v", decoded)
+	}
+
+	if _, e = newTokenClaimsMessage("not-a-token", "http://localhost:9000"); e == nil {
+		t.Fatal("Expected an invalid token to fail")
+	}
+}
+
+func TestTokenExpiryMessage(t *testing.T) {
+	msg := tokenExpiryMessage{URL: "http://localhost:9000", ExpiresAt: time.Date(2031, time.March, 1, 12, 0, 0, 0, time.UTC)}
+	var decoded map[string]interface{}
+	if e := json.Unmarshal([]byte(msg.JSON()), &decoded); e != nil {
+		t.Fatal(e)
+	}
+	if decoded["status"] != "success" || decoded["expiresAt"] != "2031-03-01T12:00:00Z" || decoded["url"] != "http://localhost:9000" {
+		t.Fatalf("Unexpected JSON expiry %v", decoded)
+	}
+}
+
+func TestPrometheusJobNameAndExpiry(t *testing.T) {
+	for _, env := range []string{envPrometheusJobName, envPrometheusExpiry} {
+		if value, ok := os.LookupEnv(env); ok {
+			defer os.Setenv(env, value)
+		} else {
+			defer os.Unsetenv(env)
+		}
+	}
+
+	testCases := []struct {
+		flagJobName, envJobName string
+		flagExpiry, envExpiry   string
+		jobName                 string
+		expiry                  time.Duration
+		expectErr               bool
+	}{
+		// Constants as the final fallback.
+		{"", "", "", "", defaultJobName, defaultPrometheusJWTExpiry, false},
+		// Environment over the constants.
+		{"", "ci-job", "", "72h", "ci-job", 72 * time.Hour, false},
+		{"", "ci-job", "", "30d", "ci-job", 30 * 24 * time.Hour, false},
+		// Flags over the environment.
+		{"myminio", "ci-job", "24h", "72h", "myminio", 24 * time.Hour, false},
+		{"myminio", "", "24h", "", "myminio", 24 * time.Hour, false},
+		// Invalid values are not ignored.
+		{"", "", "", "soon", defaultJobName, 0, true},
+		{"", "", "-1h", "72h", defaultJobName, 0, true},
+	}
+	for i, testCase := range testCases {
+		os.Setenv(envPrometheusJobName, testCase.envJobName)
+		os.Setenv(envPrometheusExpiry, testCase.envExpiry)
+		if jobName := prometheusJobName(testCase.flagJobName); jobName != testCase.jobName {
+			t.Fatalf("Test %d: expected job name %s, got %s", i+1, testCase.jobName, jobName)
+		}
+		expiry, err := prometheusExpiry(testCase.flagExpiry)
+		if testCase.expectErr {
+			if err == nil {
+				t.Fatalf("Test %d: expected an error, got expiry %v", i+1, expiry)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error: %v", i+1, err)
+		}
+		if expiry != testCase.expiry {
+			t.Fatalf("Test %d: expected expiry %v, got %v", i+1, testCase.expiry, expiry)
+		}
+	}
+}
+
+func TestPrometheusConfigWithTargetLabels(t *testing.T) {
+	u, e := url.Parse("https://minio.example.com:9000")
+	if e != nil {
+		t.Fatal(e)
+	}
+	config := PrometheusConfig{ScrapeConfigs: []ScrapeConfig{newScrapeConfig("minio-job", defaultMetricsPath, "secret", u)}}
+
+	for i, values := range [][]string{{"tenant"}, {"=acme"}, {"tenant=acme", "tenant=other"}} {
+		if _, err := parseTargetLabels(values); err == nil {
+			t.Fatalf("Test %d: expected an error for %v", i+1, values)
+		}
+	}
+
+	// Without any label the labels key is omitted.
+	labels, err := parseTargetLabels(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, e := yaml.Marshal(config.withTargetLabels(labels))
+	if e != nil {
+		t.Fatal(e)
+	}
+	if strings.Contains(string(data), "labels") {
+		t.Fatalf("Unexpected labels in %q", data)
+	}
+
+	labels, err = parseTargetLabels([]string{"tenant=acme", "env=prod", "query=a=b"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	labeled := config.withTargetLabels(labels)
+	if len(config.ScrapeConfigs[0].StaticConfigs[0].Labels) != 0 {
+		t.Fatalf("Original config was modified: %v", config.ScrapeConfigs[0].StaticConfigs[0].Labels)
+	}
+	data, e = yaml.Marshal(labeled)
+	if e != nil {
+		t.Fatal(e)
+	}
+	expected := "  - targets: ['minio.example.com:9000']\n    labels:\n      env: prod\n      query: a=b\n      tenant: acme\n"
+	if !strings.Contains(string(data), expected) {
+		t.Fatalf("Expected %q in %q", expected, data)
+	}
+
+	var buf bytes.Buffer
+	if e = json.Compact(&buf, []byte(labeled.ScrapeConfigs[0].StaticConfigs[0].JSON())); e != nil {
+		t.Fatal(e)
+	}
+	if s := `{"targets":["minio.example.com:9000"],"labels":{"env":"prod","query":"a=b","tenant":"acme"}}`; buf.String() != s {
+		t.Fatalf("Expected %s, got %s", s, buf.String())
+	}
+
+	sm := newServiceMonitor(labeled, "minio-job", "", "", "")
+	relabelings := sm.Spec.Endpoints[0].Relabelings
+	if len(relabelings) != 3 || relabelings[0].TargetLabel != "env" || relabelings[2].Replacement != "acme" {
+		t.Fatalf("Unexpected ServiceMonitor relabelings %+v", relabelings)
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/minio/mc/pkg/probe"
+	yaml "gopkg.in/yaml.v2"
+)
+
+// mergePrometheusConfig - returns the existing prometheus.yml with the scrape
+// configs of config replacing the ones with the same job name, or appended
+// to them. The other settings of the file and their order are kept.
+func mergePrometheusConfig(existing []byte, config PrometheusConfig) ([]byte, *probe.Error) {
+	var doc yaml.MapSlice
+	if e := yaml.Unmarshal(existing, &doc); e != nil {
+		return nil, probe.NewError(e)
+	}
+
+	index := -1
+	var scrapeConfigs []interface{}
+	for i, item := range doc {
+		if item.Key != "scrape_configs" {
+			continue
+		}
+		index = i
+		if item.Value != nil {
+			var ok bool
+			if scrapeConfigs, ok = item.Value.([]interface{}); !ok {
+				return nil, probe.NewError(fmt.Errorf("scrape_configs is not a list"))
+			}
+		}
+	}
+
+	for _, scrapeConfig := range config.ScrapeConfigs {
+		// Convert to the generic form of the other scrape configs.
+		data, e := yaml.Marshal(scrapeConfig)
+		if e != nil {
+			return nil, probe.NewError(e)
+		}
+		var item yaml.MapSlice
+		if e = yaml.Unmarshal(data, &item); e != nil {
+			return nil, probe.NewError(e)
+		}
+		replaced := false
+		for i, existing := range scrapeConfigs {
+			if scrapeJobName(existing) == scrapeConfig.JobName {
+				scrapeConfigs[i] = item
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			scrapeConfigs = append(scrapeConfigs, item)
+		}
+	}
+
+	if index < 0 {
+		doc = append(doc, yaml.MapItem{Key: "scrape_configs", Value: scrapeConfigs})
+	} else {
+		doc[index].Value = scrapeConfigs
+	}
+	data, e := yaml.Marshal(doc)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	return data, nil
+}
+
+// scrapeJobName - returns the job_name of a generic scrape config.
+func scrapeJobName(scrapeConfig interface{}) string {
+	m, ok := scrapeConfig.(yaml.MapSlice)
+	if !ok {
+		return ""
+	}
+	for _, item := range m {
+		if item.Key == "job_name" {
+			name, _ := item.Value.(string)
+			return name
+		}
+	}
+	return ""
+}
+
+// writeFileAtomic - writes data to a temporary file next to path, then
+// renames it to path, so that path is never left partially written.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) *probe.Error {
+	f, e := ioutil.TempFile(filepath.Dir(path), "."+filepath.Base(path)+".tmp")
+	if e != nil {
+		return probe.NewError(e)
+	}
+	tmpPath := f.Name()
+	cleanup := func(e error) *probe.Error {
+		f.Close()
+		os.Remove(tmpPath)
+		return probe.NewError(e)
+	}
+	if e = f.Chmod(perm); e != nil {
+		return cleanup(e)
+	}
+	if _, e = f.Write(data); e != nil {
+		return cleanup(e)
+	}
+	if e = f.Sync(); e != nil {
+		return cleanup(e)
+	}
+	if e = f.Close(); e != nil {
+		os.Remove(tmpPath)
+		return probe.NewError(e)
+	}
+	if e = os.Rename(tmpPath, path); e != nil {
+		os.Remove(tmpPath)
+		return probe.NewError(e)
+	}
+	return nil
+}
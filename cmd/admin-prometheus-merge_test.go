@@ -0,0 +1,112 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMergePrometheusConfig(t *testing.T) {
+	existing := `global:
+  scrape_interval: 15s
+scrape_configs:
+- job_name: node
+  static_configs:
+  - targets: ['localhost:9100']
+- job_name: minio-job
+  metrics_path: /minio/prometheus/metrics
+rule_files:
+- rules.yml
+`
+	config := PrometheusConfig{ScrapeConfigs: []ScrapeConfig{
+		{JobName: "minio-job", MetricsPath: defaultMetricsPath, Scheme: "http"},
+		{JobName: "minio-job-node", MetricsPath: nodeMetricsPath, Scheme: "http"},
+	}}
+	data, err := mergePrometheusConfig([]byte(existing), config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `global:
+  scrape_interval: 15s
+scrape_configs:
+- job_name: node
+  static_configs:
+  - targets:
+    - localhost:9100
+- job_name: minio-job
+  metrics_path: /minio/v2/metrics/cluster
+  scheme: http
+- job_name: minio-job-node
+  metrics_path: /minio/v2/metrics/node
+  scheme: http
+rule_files:
+- rules.yml
+`
+	if string(data) != expected {
+		t.Fatalf("Expected\n%s\ngot\n%s", expected, data)
+	}
+
+	// A file without scrape configs gets them appended.
+	data, err = mergePrometheusConfig([]byte("global:\n  scrape_interval: 15s\n"), config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := "global:\n  scrape_interval: 15s\nscrape_configs:\n- job_name: minio-job\n"; string(data[:len(expected)]) != expected {
+		t.Fatalf("Expected %q prefix, got %q", expected, data)
+	}
+
+	for _, invalid := range []string{"scrape_configs: [", "scrape_configs: foo\n", "- foo\n"} {
+		if _, err = mergePrometheusConfig([]byte(invalid), config); err == nil {
+			t.Fatalf("Expected %q to fail", invalid)
+		}
+	}
+}
+
+func TestWriteFileAtomic(t *testing.T) {
+	dir, e := ioutil.TempDir("", "prometheus-merge")
+	if e != nil {
+		t.Fatal(e)
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "prometheus.yml")
+	if err := writeFileAtomic(path, []byte("scrape_configs: []\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	data, e := ioutil.ReadFile(path)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if string(data) != "scrape_configs: []\n" {
+		t.Fatalf("Unexpected content %q", data)
+	}
+	fi, e := os.Stat(path)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if fi.Mode().Perm() != 0644 {
+		t.Fatalf("Expected mode 0644, got %v", fi.Mode().Perm())
+	}
+	// No temporary file is left behind.
+	if files, _ := ioutil.ReadDir(dir); len(files) != 1 {
+		t.Fatalf("Expected a single file, got %d", len(files))
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+	yaml "gopkg.in/yaml.v2"
+)
+
+const (
+	// serviceMonitorTokenKey - key of the bearer token in its secret.
+	serviceMonitorTokenKey = "token"
+
+	// defaultServiceMonitorInterval - scrape interval of the generated
+	// endpoints, the default one of Prometheus.
+	defaultServiceMonitorInterval = "1m"
+)
+
+// ServiceMonitor - Prometheus Operator resource describing how to scrape
+// the services it selects.
+type ServiceMonitor struct {
+	APIVersion string                 `yaml:"apiVersion" json:"apiVersion"`
+	Kind       string                 `yaml:"kind" json:"kind"`
+	Metadata   ServiceMonitorMetadata `yaml:"metadata" json:"metadata"`
+	Spec       ServiceMonitorSpec     `yaml:"spec" json:"spec"`
+}
+
+// ServiceMonitorMetadata - name and namespace of a ServiceMonitor.
+type ServiceMonitorMetadata struct {
+	Name      string `yaml:"name" json:"name"`
+	Namespace string `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+}
+
+// ServiceMonitorSpec - services selected by a ServiceMonitor and their
+// scraped endpoints.
+type ServiceMonitorSpec struct {
+	Selector  ServiceMonitorSelector   `yaml:"selector" json:"selector"`
+	Endpoints []ServiceMonitorEndpoint `yaml:"endpoints" json:"endpoints"`
+}
+
+// ServiceMonitorSelector - labels of the services to scrape.
+type ServiceMonitorSelector struct {
+	MatchLabels map[string]string `yaml:"matchLabels" json:"matchLabels"`
+}
+
+// ServiceMonitorEndpoint - a scraped metrics endpoint.
+type ServiceMonitorEndpoint struct {
+	Port              string                   `yaml:"port,omitempty" json:"port,omitempty"`
+	Path              string                   `yaml:"path" json:"path"`
+	Scheme            string                   `yaml:"scheme,omitempty" json:"scheme,omitempty"`
+	Interval          string                   `yaml:"interval,omitempty" json:"interval,omitempty"`
+	ScrapeTimeout     string                   `yaml:"scrapeTimeout,omitempty" json:"scrapeTimeout,omitempty"`
+	BearerTokenSecret *ServiceMonitorSecretRef `yaml:"bearerTokenSecret,omitempty" json:"bearerTokenSecret,omitempty"`
+	TLSConfig         *ServiceMonitorTLSConfig `yaml:"tlsConfig,omitempty" json:"tlsConfig,omitempty"`
+	HonorLabels       bool                     `yaml:"honorLabels,omitempty" json:"honorLabels,omitempty"`
+	Relabelings       []ServiceMonitorRelabel  `yaml:"relabelings,omitempty" json:"relabelings,omitempty"`
+}
+
+// ServiceMonitorRelabel - rewrites a label of the scraped targets.
+type ServiceMonitorRelabel struct {
+	SourceLabels []string `yaml:"sourceLabels,omitempty" json:"sourceLabels,omitempty"`
+	TargetLabel  string   `yaml:"targetLabel" json:"targetLabel"`
+	Replacement  string   `yaml:"replacement" json:"replacement"`
+}
+
+// ServiceMonitorSecretRef - key of a secret in the ServiceMonitor namespace.
+type ServiceMonitorSecretRef struct {
+	Name string `yaml:"name" json:"name"`
+	Key  string `yaml:"key" json:"key"`
+}
+
+// ServiceMonitorTLSConfig - server certificate verification settings.
+type ServiceMonitorTLSConfig struct {
+	CAFile             string `yaml:"caFile,omitempty" json:"caFile,omitempty"`
+	InsecureSkipVerify bool   `yaml:"insecureSkipVerify,omitempty" json:"insecureSkipVerify,omitempty"`
+}
+
+// newServiceMonitor - returns a ServiceMonitor scraping the endpoints of
+// config from the port named port of the services labeled app=minio,
+// reading the bearer token from secretName when not empty.
+func newServiceMonitor(config PrometheusConfig, name, namespace, secretName, port string) ServiceMonitor {
+	sm := ServiceMonitor{
+		APIVersion: "monitoring.coreos.com/v1",
+		Kind:       "ServiceMonitor",
+		Metadata:   ServiceMonitorMetadata{Name: name, Namespace: namespace},
+		Spec: ServiceMonitorSpec{
+			Selector:  ServiceMonitorSelector{MatchLabels: map[string]string{"app": "minio"}},
+			Endpoints: []ServiceMonitorEndpoint{},
+		},
+	}
+	for _, scrapeConfig := range config.ScrapeConfigs {
+		endpoint := ServiceMonitorEndpoint{
+			Path:          scrapeConfig.MetricsPath,
+			Scheme:        scrapeConfig.Scheme,
+			Interval:      scrapeConfig.ScrapeInterval,
+			ScrapeTimeout: scrapeConfig.ScrapeTimeout,
+			HonorLabels:   scrapeConfig.HonorLabels,
+		}
+		// Services are discovered, their targets are labeled by relabelings.
+		if len(scrapeConfig.StaticConfigs) > 0 {
+			labels := scrapeConfig.StaticConfigs[0].Labels
+			keys := make([]string, 0, len(labels))
+			for k := range labels {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				endpoint.Relabelings = append(endpoint.Relabelings, ServiceMonitorRelabel{TargetLabel: k, Replacement: labels[k]})
+			}
+		}
+		for _, relabel := range scrapeConfig.RelabelConfigs {
+			endpoint.Relabelings = append(endpoint.Relabelings, ServiceMonitorRelabel(relabel))
+		}
+		if endpoint.Interval == "" {
+			endpoint.Interval = defaultServiceMonitorInterval
+		}
+		endpoint.Port = port
+		if endpoint.Port == "" {
+			endpoint.Port = servicePortName(scrapeConfig.Scheme)
+		}
+		if secretName != "" {
+			endpoint.BearerTokenSecret = &ServiceMonitorSecretRef{Name: secretName, Key: serviceMonitorTokenKey}
+		}
+		if tlsConfig := scrapeConfig.TLSConfig; tlsConfig != nil {
+			endpoint.TLSConfig = &ServiceMonitorTLSConfig{CAFile: tlsConfig.CAFile, InsecureSkipVerify: tlsConfig.InsecureSkipVerify}
+		}
+		sm.Spec.Endpoints = append(sm.Spec.Endpoints, endpoint)
+	}
+	return sm
+}
+
+// servicePortName - returns the name the MinIO Operator gives to the
+// service port serving scheme, the port of the alias URL is usually not
+// the one of the service.
+func servicePortName(scheme string) string {
+	if scheme == "https" {
+		return "https-minio"
+	}
+	return "http-minio"
+}
+
+// String colorized ServiceMonitor yaml.
+func (sm ServiceMonitor) String() string {
+	b, err := yaml.Marshal(sm)
+	if err != nil {
+		return fmt.Sprintf("error creating service monitor string: %s", err)
+	}
+	return console.Colorize("yaml", string(b))
+}
+
+// JSON jsonified ServiceMonitor.
+func (sm ServiceMonitor) JSON() string {
+	jsonMessageBytes, e := json.MarshalIndent(sm, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(jsonMessageBytes)
+}
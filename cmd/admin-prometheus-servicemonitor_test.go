@@ -0,0 +1,86 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"net/url"
+	"testing"
+
+	yaml "gopkg.in/yaml.v2"
+)
+
+func TestNewServiceMonitor(t *testing.T) {
+	u := &url.URL{Scheme: "https", Host: "minio.example.com:9000"}
+	scrapeConfig := newScrapeConfig("minio-job", defaultMetricsPath, "secret", u)
+	scrapeConfig.TLSConfig = &TLSConfig{InsecureSkipVerify: true}
+	config := PrometheusConfig{ScrapeConfigs: []ScrapeConfig{scrapeConfig}}
+
+	data, e := yaml.Marshal(newServiceMonitor(config, "minio-job", "minio", "minio-prometheus", ""))
+	if e != nil {
+		t.Fatal(e)
+	}
+	expected := `apiVersion: monitoring.coreos.com/v1
+kind: ServiceMonitor
+metadata:
+  name: minio-job
+  namespace: minio
+spec:
+  selector:
+    matchLabels:
+      app: minio
+  endpoints:
+  - port: https-minio
+    path: /minio/v2/metrics/cluster
+    scheme: https
+    interval: 1m
+    bearerTokenSecret:
+      name: minio-prometheus
+      key: token
+    tlsConfig:
+      insecureSkipVerify: true
+`
+	if string(data) != expected {
+		t.Fatalf("Expected:\n%s\ngot:\n%s", expected, data)
+	}
+
+	scrapeConfig.TLSConfig = &TLSConfig{CAFile: "/etc/prometheus/minio-ca.crt"}
+	config = PrometheusConfig{ScrapeConfigs: []ScrapeConfig{scrapeConfig}}
+	endpoint := newServiceMonitor(config, "minio-job", "minio", "minio-prometheus", "api").Spec.Endpoints[0]
+	if endpoint.Port != "api" {
+		t.Fatalf("Expected port %q, got %q", "api", endpoint.Port)
+	}
+	if endpoint.TLSConfig == nil || endpoint.TLSConfig.CAFile != "/etc/prometheus/minio-ca.crt" || endpoint.TLSConfig.InsecureSkipVerify {
+		t.Fatalf("Expected the CA file in the TLS config, got %+v", endpoint.TLSConfig)
+	}
+}
+
+func TestServicePortName(t *testing.T) {
+	testCases := []struct {
+		scheme, port string
+	}{
+		{"https", "https-minio"},
+		{"http", "http-minio"},
+		{"", "http-minio"},
+	}
+
+	for i, testCase := range testCases {
+		if port := servicePortName(testCase.scheme); port != testCase.port {
+			t.Fatalf("Test %d: expected %q, got %q", i+1, testCase.port, port)
+		}
+	}
+}
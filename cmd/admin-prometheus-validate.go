@@ -0,0 +1,126 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+// prometheusValidateBodyLimit - the part of a scraped body which is
+// inspected, and printed when it is not in the Prometheus format.
+const prometheusValidateBodyLimit = 512
+
+// validateScrapeConfigs - scrapes all the targets of config like Prometheus
+// would, with metricsPath when not empty, failing at the first target which
+// does not answer 200 with metrics in the Prometheus text format.
+func validateScrapeConfigs(ctx context.Context, config PrometheusConfig, metricsPath string) *probe.Error {
+	for _, scrapeConfig := range config.ScrapeConfigs {
+		if metricsPath != "" {
+			scrapeConfig.MetricsPath = metricsPath
+		}
+		for _, staticConfig := range scrapeConfig.StaticConfigs {
+			for _, target := range staticConfig.Targets {
+				targetURL := url.URL{Scheme: scrapeConfig.Scheme, Host: target, Path: scrapeConfig.MetricsPath}
+				if err := scrapeTarget(ctx, targetURL.String(), scrapeConfig.BearerToken); err != nil {
+					return err.Trace(targetURL.String())
+				}
+				fmt.Fprintln(os.Stderr, console.Colorize("Validated", "Scraped `"+targetURL.String()+"` successfully."))
+			}
+		}
+	}
+	return nil
+}
+
+// scrapeTarget - gets the metrics served at targetURL, authenticated with
+// token when not empty.
+func scrapeTarget(ctx context.Context, targetURL, token string) *probe.Error {
+	req, e := http.NewRequestWithContext(ctx, http.MethodGet, targetURL, nil)
+	if e != nil {
+		return probe.NewError(e)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	client := httpClient(10 * time.Second)
+	client.Transport.(*http.Transport).TLSClientConfig = &tls.Config{
+		RootCAs:            globalRootCAs,
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: globalInsecure,
+	}
+	resp, e := client.Do(req)
+	if e != nil {
+		return probe.NewError(e)
+	}
+	defer resp.Body.Close()
+
+	body, e := ioutil.ReadAll(io.LimitReader(resp.Body, prometheusValidateBodyLimit))
+	if e != nil {
+		return probe.NewError(e)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return probe.NewError(fmt.Errorf("`%s` returned %s: %s", targetURL, resp.Status, bodySnippet(body)))
+	}
+	if !isPrometheusFormat(resp.Header.Get("Content-Type"), body) {
+		return probe.NewError(fmt.Errorf("`%s` did not return Prometheus metrics: %s", targetURL, bodySnippet(body)))
+	}
+	return nil
+}
+
+// isPrometheusFormat - reports whether a response with contentType starting
+// with body holds metrics in the Prometheus text or OpenMetrics format.
+func isPrometheusFormat(contentType string, body []byte) bool {
+	if !strings.HasPrefix(contentType, "text/plain") && !strings.HasPrefix(contentType, "application/openmetrics-text") {
+		return false
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		// Comments, e.g. # HELP and # TYPE, or a sample starting with
+		// its metric name.
+		c := line[0]
+		return c == '#' || c == '_' || c == ':' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z'
+	}
+	// An empty body is valid, e.g. a bucket metrics endpoint without buckets.
+	return true
+}
+
+// bodySnippet - returns body printable on a single line.
+func bodySnippet(body []byte) string {
+	snippet := strings.Join(strings.Fields(string(body)), " ")
+	if snippet == "" {
+		return "empty body"
+	}
+	return snippet
+}
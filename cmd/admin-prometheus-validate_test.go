@@ -0,0 +1,86 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestScrapeTarget(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			http.Error(w, "Access Denied.", http.StatusForbidden)
+			return
+		}
+		switch r.URL.Path {
+		case defaultMetricsPath:
+			w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+			w.Write([]byte("# HELP minio_cluster_nodes_online_total Total number of MinIO nodes online.\n"))
+		default:
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte("<html>\n  <body>Login</body>\n</html>\n"))
+		}
+	}))
+	defer server.Close()
+
+	testCases := []struct {
+		path        string
+		token       string
+		errContains string
+	}{
+		{defaultMetricsPath, "token", ""},
+		{defaultMetricsPath, "", "403 Forbidden: Access Denied."},
+		{"/login", "token", "did not return Prometheus metrics: <html> <body>Login</body> </html>"},
+	}
+	for i, testCase := range testCases {
+		err := scrapeTarget(context.Background(), server.URL+testCase.path, testCase.token)
+		if testCase.errContains == "" {
+			if err != nil {
+				t.Fatalf("Test %d: unexpected error: %v", i+1, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.ToGoError().Error(), testCase.errContains) {
+			t.Fatalf("Test %d: expected error containing %q, got %v", i+1, testCase.errContains, err)
+		}
+	}
+}
+
+func TestIsPrometheusFormat(t *testing.T) {
+	testCases := []struct {
+		contentType string
+		body        string
+		expected    bool
+	}{
+		{"text/plain; version=0.0.4", "# HELP minio_node_file_descriptor_open_total Total number of open file descriptors.\n", true},
+		{"text/plain; version=0.0.4", "\nminio_bucket_usage_total_bytes{bucket=\"photos\"} 1024\n", true},
+		{"application/openmetrics-text; version=1.0.0", "# TYPE minio_cluster_nodes_online_total gauge\n", true},
+		{"text/plain", "", true},
+		{"text/plain", "{\"error\":\"denied\"}", false},
+		{"application/json", "# HELP", false},
+	}
+	for i, testCase := range testCases {
+		if ok := isPrometheusFormat(testCase.contentType, []byte(testCase.body)); ok != testCase.expected {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.expected, ok)
+		}
+	}
+}
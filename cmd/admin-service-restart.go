@@ -29,18 +29,29 @@ import (
 	"github.com/minio/pkg/console"
 )
 
+var adminServiceRestartFlags = []cli.Flag{
+	cli.DurationFlag{
+		Name:  "timeout",
+		Usage: "fail if the servers are not back online within this duration (e.g. `5m`), waits forever by default",
+	},
+}
+
 var adminServiceRestartCmd = cli.Command{
 	Name:         "restart",
 	Usage:        "restart all MinIO servers",
 	Action:       mainAdminServiceRestart,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        globalFlags,
+	Flags:        append(adminServiceRestartFlags, globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}} TARGET
+  {{.HelpName}} [FLAGS] TARGET
+
+DESCRIPTION:
+  After sending the restart command, the servers are polled until they are back online
+  and serving requests, so the command can be safely used in scripts.
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -48,6 +59,9 @@ FLAGS:
 EXAMPLES:
   1. Restart MinIO server represented by its alias 'play'.
      {{.Prompt}} {{.HelpName}} play/
+
+  2. Restart MinIO server 'myminio' and exit with an error if it is not back online within 5 minutes.
+     {{.Prompt}} {{.HelpName}} --timeout 5m myminio/
 `,
 }
 
@@ -137,10 +151,22 @@ func mainAdminServiceRestart(ctx *cli.Context) error {
 	printProgress()
 	mark = "."
 
+	var timeoutCh <-chan time.Time
+	if timeout := ctx.Duration("timeout"); timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		timeoutCh = timer.C
+	}
+
 	for {
 		select {
 		case <-globalContext.Done():
 			return globalContext.Err()
+		case <-timeoutCh:
+			if !globalQuiet && !globalJSON {
+				console.Println()
+			}
+			fatalIf(errDummy().Trace(aliasedURL), "Timed out waiting for `"+aliasedURL+"` to be back online after restart.")
 		case <-time.NewTimer(3 * time.Second).C:
 			ctx, cancel := context.WithTimeout(globalContext, 1*time.Second)
 			// Fetch the service status of the specified MinIO server
@@ -150,7 +176,7 @@ func mainAdminServiceRestart(ctx *cli.Context) error {
 			case e == nil && info.Mode == string(madmin.ItemOnline):
 				printMsg(serviceRestartMessage{Status: "success", ServerURL: aliasedURL})
 				return nil
-			case err == nil && info.Mode == string(madmin.ItemInitializing):
+			case e == nil && info.Mode == string(madmin.ItemInitializing):
 				coloring = color.New(color.FgYellow)
 				mark = "!"
 				fallthrough
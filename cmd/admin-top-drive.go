@@ -0,0 +1,252 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/madmin-go"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+var topDriveFlags = []cli.Flag{
+	cli.DurationFlag{
+		Name:  "interval",
+		Usage: "refresh interval of the drive statistics",
+		Value: 2 * time.Second,
+	},
+	cli.IntFlag{
+		Name:  "count",
+		Usage: "number of drives to show, 0 shows all drives",
+		Value: 10,
+	},
+}
+
+var adminTopDriveCmd = cli.Command{
+	Name:         "drive",
+	Usage:        "show a live view of the busiest drives on a MinIO cluster",
+	Before:       setGlobalsFromContext,
+	Action:       mainAdminTopDrive,
+	OnUsageError: onUsageError,
+	Flags:        append(globalFlags, topDriveFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} [FLAGS] TARGET
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+DESCRIPTION:
+  Drives are sorted by operations per second since the previous refresh, the
+  latency is the slowest average latency of the storage calls of a drive.
+  Throughput is not shown, the server does not report it per drive.
+
+EXAMPLES:
+  1. Show the 10 busiest drives on a MinIO cluster, refreshed every 2 seconds.
+     {{.Prompt}} {{.HelpName}} myminio/
+
+  2. Print a JSON snapshot of all drives every 10 seconds.
+     {{.Prompt}} {{.HelpName}} --json --count 0 --interval 10s myminio/
+`,
+}
+
+// topDriveStat holds the IO statistics of one drive.
+type topDriveStat struct {
+	Endpoint  string  `json:"endpoint"`
+	State     string  `json:"state"`
+	OpsPerSec float64 `json:"opsPerSec"`
+	Latency   string  `json:"latency,omitempty"`
+
+	latency time.Duration
+}
+
+// topDriveMessage is one snapshot of the busiest drives.
+type topDriveMessage struct {
+	Status string         `json:"status"`
+	Drives []topDriveStat `json:"drives"`
+}
+
+// String renders the drives as a table, the busiest drive first.
+func (t topDriveMessage) String() string {
+	table := newPrettyTable("  ",
+		Field{"OpsPerSec", 10},
+		Field{"Latency", 12},
+		Field{"DriveState", 8},
+		Field{"Drive", -1},
+	)
+	lines := []string{console.Colorize("Headers", newPrettyTable("  ",
+		Field{"", 10}, Field{"", 12}, Field{"", 8}, Field{"", -1},
+	).buildRow("Ops/s", "Latency", "State", "Drive"))}
+	for _, d := range t.Drives {
+		latency := d.Latency
+		if latency == "" {
+			latency = "-"
+		}
+		lines = append(lines, table.buildRow(
+			fmt.Sprintf("%.1f", d.OpsPerSec),
+			latency,
+			d.State,
+			d.Endpoint,
+		))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// JSON jsonified drives snapshot.
+func (t topDriveMessage) JSON() string {
+	t.Status = "success"
+	statusJSONBytes, e := json.MarshalIndent(t, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(statusJSONBytes)
+}
+
+// topDriveCalls returns the total number of storage calls served by a drive.
+func topDriveCalls(disk madmin.Disk) (calls uint64) {
+	if disk.Metrics == nil {
+		return 0
+	}
+	for _, n := range disk.Metrics.APICalls {
+		calls += n
+	}
+	return calls
+}
+
+// topDriveLatency returns the slowest latency reported for a drive.
+func topDriveLatency(disk madmin.Disk) (latency time.Duration) {
+	if disk.Metrics == nil {
+		return 0
+	}
+	for _, v := range disk.Metrics.APILatencies {
+		if d, e := time.ParseDuration(v); e == nil && d > latency {
+			latency = d
+		}
+	}
+	return latency
+}
+
+// topDrives computes the per drive statistics between two server info
+// snapshots, the busiest drives come first.
+func topDrives(prev, curr map[string]madmin.Disk, elapsed time.Duration, count int) []topDriveStat {
+	drives := make([]topDriveStat, 0, len(curr))
+	for endpoint, disk := range curr {
+		stat := topDriveStat{
+			Endpoint: endpoint,
+			State:    disk.State,
+			latency:  topDriveLatency(disk),
+		}
+		if p, ok := prev[endpoint]; ok && elapsed > 0 {
+			if calls, prevCalls := topDriveCalls(disk), topDriveCalls(p); calls >= prevCalls {
+				stat.OpsPerSec = float64(calls-prevCalls) / elapsed.Seconds()
+			}
+		}
+		if stat.latency > 0 {
+			stat.Latency = stat.latency.String()
+		}
+		drives = append(drives, stat)
+	}
+	sort.Slice(drives, func(i, j int) bool {
+		if drives[i].OpsPerSec != drives[j].OpsPerSec {
+			return drives[i].OpsPerSec > drives[j].OpsPerSec
+		}
+		if drives[i].latency != drives[j].latency {
+			return drives[i].latency > drives[j].latency
+		}
+		return drives[i].Endpoint < drives[j].Endpoint
+	})
+	if count > 0 && len(drives) > count {
+		drives = drives[:count]
+	}
+	return drives
+}
+
+// checkAdminTopDriveSyntax - validate all the passed arguments
+func checkAdminTopDriveSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "drive", 1) // last argument is exit code
+	}
+	if ctx.Duration("interval") <= 0 || ctx.Int("count") < 0 {
+		fatalIf(errInvalidArgument().Trace(ctx.Args()...), "--interval must be positive and --count cannot be negative.")
+	}
+}
+
+func mainAdminTopDrive(ctx *cli.Context) error {
+	checkAdminTopDriveSyntax(ctx)
+
+	aliasedURL := ctx.Args().Get(0)
+
+	// Create a new MinIO Admin Client
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	console.SetColor("Headers", color.New(color.FgGreen, color.Bold))
+	console.SetColor("OpsPerSec", color.New(color.FgYellow, color.Bold))
+	console.SetColor("Latency", color.New(color.FgMagenta))
+	console.SetColor("DriveState", color.New(color.FgGreen))
+	console.SetColor("Drive", color.New(color.Bold))
+
+	interval := ctx.Duration("interval")
+	count := ctx.Int("count")
+
+	var (
+		prev      map[string]madmin.Disk
+		prevTime  time.Time
+		rewind    int
+		ticker    = time.NewTicker(interval)
+		firstLoop = true
+	)
+	defer ticker.Stop()
+
+	for {
+		info, e := client.ServerInfo(globalContext)
+		fatalIf(probe.NewError(e), "Unable to get server info.")
+
+		now := time.Now()
+		curr := make(map[string]madmin.Disk)
+		for _, server := range info.Servers {
+			for _, disk := range server.Disks {
+				curr[disk.Endpoint] = disk
+			}
+		}
+
+		// Rates need two snapshots, skip the output of the first one.
+		if !firstLoop {
+			msg := topDriveMessage{Drives: topDrives(prev, curr, now.Sub(prevTime), count)}
+			if !globalJSON {
+				console.RewindLines(rewind)
+				rewind = len(msg.Drives) + 1
+			}
+			printMsg(msg)
+		}
+		prev, prevTime, firstLoop = curr, now, false
+
+		select {
+		case <-globalContext.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
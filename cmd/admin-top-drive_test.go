@@ -0,0 +1,73 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/minio/madmin-go"
+)
+
+func TestTopDrives(t *testing.T) {
+	disk := func(calls uint64, latency string) madmin.Disk {
+		return madmin.Disk{
+			State: "ok",
+			Metrics: &madmin.DiskMetrics{
+				APICalls:     map[string]uint64{"ReadFile": calls / 2, "CreateFile": calls - calls/2},
+				APILatencies: map[string]string{"ReadFile": latency, "CreateFile": "1ms"},
+			},
+		}
+	}
+	prev := map[string]madmin.Disk{
+		"http://node1/disk1": disk(100, "2ms"),
+		"http://node1/disk2": disk(100, "2ms"),
+		"http://node2/disk1": disk(100, "2ms"),
+	}
+	curr := map[string]madmin.Disk{
+		"http://node1/disk1": disk(120, "2ms"),
+		"http://node1/disk2": disk(300, "40ms"),
+		"http://node2/disk1": disk(100, "5ms"),
+		"http://node2/disk2": disk(50, "3ms"),
+	}
+
+	drives := topDrives(prev, curr, 2*time.Second, 0)
+	testCases := []struct {
+		endpoint  string
+		opsPerSec float64
+		latency   string
+	}{
+		{"http://node1/disk2", 100, "40ms"},
+		{"http://node1/disk1", 10, "2ms"},
+		{"http://node2/disk1", 0, "5ms"},
+		{"http://node2/disk2", 0, "3ms"},
+	}
+	if len(drives) != len(testCases) {
+		t.Fatalf("expected %d drives, got %d", len(testCases), len(drives))
+	}
+	for i, testCase := range testCases {
+		d := drives[i]
+		if d.Endpoint != testCase.endpoint || d.OpsPerSec != testCase.opsPerSec || d.Latency != testCase.latency {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase, d)
+		}
+	}
+
+	if drives = topDrives(prev, curr, 2*time.Second, 2); len(drives) != 2 {
+		t.Fatalf("expected 2 drives, got %d", len(drives))
+	}
+}
@@ -21,6 +21,7 @@ import "github.com/minio/cli"
 
 var adminTopSubcommands = []cli.Command{
 	adminTopLocksCmd,
+	adminTopDriveCmd,
 }
 
 var adminTopCmd = cli.Command{
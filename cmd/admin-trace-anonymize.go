@@ -0,0 +1,207 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/minio/madmin-go"
+)
+
+// traceRedacted replaces the values which cannot be hashed meaningfully.
+const traceRedacted = "*REDACTED*"
+
+// Headers and query parameters carrying credentials or user data,
+// redacted as a whole.
+var traceSecretKeys = []string{
+	"Referer",
+	"X-Amz-Tagging",
+	"Authorization",
+	"Cookie",
+	"Set-Cookie",
+	"X-Amz-Security-Token",
+	"X-Amz-Credential",
+	"X-Amz-Signature",
+	"X-Amz-Server-Side-Encryption-Customer-Key",
+	"X-Amz-Copy-Source-Server-Side-Encryption-Customer-Key",
+	"Signature",
+	"AWSAccessKeyId",
+}
+
+// Headers and query parameters holding object names, hashed like paths.
+var tracePathKeys = []string{
+	"X-Amz-Copy-Source",
+	"Location",
+	"Content-Disposition",
+	"prefix",
+	"marker",
+	"start-after",
+	"key-marker",
+	"continuation-token",
+}
+
+// Headers holding a host name, which the bucket is part of with
+// virtual host style requests, hashed as a whole.
+var traceHostKeys = []string{
+	"Host",
+	"X-Forwarded-Host",
+}
+
+// traceAnonymizer hashes bucket and object names with a key chosen for
+// each run, the same name is always replaced by the same token while
+// tokens cannot be matched against guessed names.
+type traceAnonymizer struct {
+	key []byte
+}
+
+func newTraceAnonymizer() (*traceAnonymizer, error) {
+	key := make([]byte, 32)
+	if _, e := rand.Read(key); e != nil {
+		return nil, e
+	}
+	return &traceAnonymizer{key: key}, nil
+}
+
+// token returns the anonymized form of a name.
+func (a *traceAnonymizer) token(name string) string {
+	if name == "" {
+		return ""
+	}
+	mac := hmac.New(sha256.New, a.key)
+	mac.Write([]byte(name))
+	return hex.EncodeToString(mac.Sum(nil)[:8])
+}
+
+// path anonymizes "/bucket/object" into "/<bucket token>/<object token>",
+// keeping the bucket apart from the object so that per bucket patterns
+// remain visible.
+func (a *traceAnonymizer) path(p string) string {
+	if p == "" {
+		return ""
+	}
+	bucket, object := splitTracePath(p)
+	anonymized := "/" + a.token(bucket)
+	if object != "" {
+		anonymized += "/" + a.token(object)
+	}
+	return anonymized
+}
+
+// host anonymizes the host of "host:port", keeping the port, so that a
+// host gets the same token with and without port.
+func (a *traceAnonymizer) host(hostPort string) string {
+	host, port, e := net.SplitHostPort(hostPort)
+	if e != nil {
+		return a.token(hostPort)
+	}
+	return net.JoinHostPort(a.token(host), port)
+}
+
+func splitTracePath(p string) (bucket, object string) {
+	parts := strings.SplitN(strings.TrimPrefix(p, "/"), "/", 2)
+	bucket = parts[0]
+	if len(parts) > 1 {
+		object = parts[1]
+	}
+	return bucket, object
+}
+
+func matchTraceKey(keys []string, key string) bool {
+	for _, k := range keys {
+		if strings.EqualFold(k, key) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *traceAnonymizer) headers(h http.Header) http.Header {
+	if h == nil {
+		return nil
+	}
+	anonymized := http.Header{}
+	for k, values := range h {
+		for _, v := range values {
+			switch {
+			case matchTraceKey(traceSecretKeys, k):
+				v = traceRedacted
+			case matchTraceKey(tracePathKeys, k):
+				v = a.path(v)
+			case matchTraceKey(traceHostKeys, k):
+				v = a.host(v)
+			case strings.HasPrefix(strings.ToLower(k), "x-amz-meta-"):
+				// User metadata may hold anything.
+				v = traceRedacted
+			}
+			anonymized[k] = append(anonymized[k], v)
+		}
+	}
+	return anonymized
+}
+
+func (a *traceAnonymizer) query(rawQuery string) string {
+	if rawQuery == "" {
+		return ""
+	}
+	values, e := url.ParseQuery(rawQuery)
+	if e != nil {
+		return traceRedacted
+	}
+	for k, vs := range values {
+		for i, v := range vs {
+			switch {
+			case matchTraceKey(traceSecretKeys, k):
+				vs[i] = traceRedacted
+			case matchTraceKey(tracePathKeys, k):
+				vs[i] = a.path(v)
+			}
+		}
+	}
+	return values.Encode()
+}
+
+func anonymizedTraceBody(body []byte) []byte {
+	if len(body) == 0 {
+		return body
+	}
+	return []byte(fmt.Sprintf("<%d bytes anonymized>", len(body)))
+}
+
+// trace returns a copy of the trace with names, credentials and bodies
+// anonymized.
+func (a *traceAnonymizer) trace(t madmin.TraceInfo) madmin.TraceInfo {
+	t.NodeName = a.host(t.NodeName)
+	t.ReqInfo.Client = a.host(t.ReqInfo.Client)
+	t.ReqInfo.Path = a.path(t.ReqInfo.Path)
+	t.ReqInfo.RawQuery = a.query(t.ReqInfo.RawQuery)
+	t.ReqInfo.Headers = a.headers(t.ReqInfo.Headers)
+	t.ReqInfo.Body = anonymizedTraceBody(t.ReqInfo.Body)
+	t.RespInfo.Headers = a.headers(t.RespInfo.Headers)
+	t.RespInfo.Body = anonymizedTraceBody(t.RespInfo.Body)
+	t.StorageStats.Path = a.token(t.StorageStats.Path)
+	t.OSStats.Path = a.token(t.OSStats.Path)
+	return t
+}
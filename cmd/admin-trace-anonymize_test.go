@@ -0,0 +1,88 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/minio/madmin-go"
+)
+
+func TestTraceAnonymizer(t *testing.T) {
+	a, e := newTraceAnonymizer()
+	if e != nil {
+		t.Fatal(e)
+	}
+
+	if a.path("/photos/2021/cat.jpg") != a.path("/photos/2021/cat.jpg") {
+		t.Fatalf("Test 1: expected the same path to be anonymized the same way")
+	}
+	if a.path("/photos/2021/cat.jpg") == a.path("/photos/2021/dog.jpg") {
+		t.Fatalf("Test 2: expected different paths to be anonymized differently")
+	}
+	if bucket, _ := splitTracePath(a.path("/photos/2021/cat.jpg")); bucket != a.token("photos") {
+		t.Fatalf("Test 3: expected the bucket token to be kept apart from the object token")
+	}
+
+	trace := a.trace(madmin.TraceInfo{
+		NodeName: "minio-1.example.com:9000",
+		ReqInfo: madmin.TraceRequestInfo{
+			Client:   "192.168.1.10:51234",
+			Path:     "/photos/2021/cat.jpg",
+			RawQuery: "prefix=2021%2F&max-keys=10&X-Amz-Signature=abcd",
+			Headers: http.Header{
+				"Authorization":  []string{"AWS4-HMAC-SHA256 Credential=minio/..."},
+				"X-Amz-Meta-Tag": []string{"private"},
+				"Content-Type":   []string{"image/jpeg"},
+				"Host":           []string{"photos.minio.example.com"},
+				"Referer":        []string{"https://example.com/photos/"},
+				"X-Amz-Tagging":  []string{"owner=alice"},
+			},
+			Body: []byte("<ListBucketResult>"),
+		},
+	})
+	if strings.Contains(trace.ReqInfo.Path, "photos") || strings.Contains(trace.ReqInfo.Path, "cat") {
+		t.Fatalf("Test 4: path %q leaks names", trace.ReqInfo.Path)
+	}
+	query, e := url.ParseQuery(trace.ReqInfo.RawQuery)
+	if e != nil {
+		t.Fatal(e)
+	}
+	if query.Get("prefix") != a.path("2021/") || query.Get("max-keys") != "10" || query.Get("X-Amz-Signature") != traceRedacted {
+		t.Fatalf("Test 5: unexpected query %q", trace.ReqInfo.RawQuery)
+	}
+	headers := trace.ReqInfo.Headers
+	if headers.Get("Authorization") != traceRedacted || headers.Get("X-Amz-Meta-Tag") != traceRedacted || headers.Get("Content-Type") != "image/jpeg" {
+		t.Fatalf("Test 6: unexpected headers %v", headers)
+	}
+	if headers.Get("Host") != a.token("photos.minio.example.com") || headers.Get("Referer") != traceRedacted || headers.Get("X-Amz-Tagging") != traceRedacted {
+		t.Fatalf("Test 7: unexpected headers %v", headers)
+	}
+	if strings.Contains(string(trace.ReqInfo.Body), "ListBucketResult") {
+		t.Fatalf("Test 8: body was not anonymized")
+	}
+	if trace.NodeName != a.token("minio-1.example.com")+":9000" || trace.ReqInfo.Client != a.token("192.168.1.10")+":51234" {
+		t.Fatalf("Test 9: unexpected node %q and client %q", trace.NodeName, trace.ReqInfo.Client)
+	}
+	if a.host("minio-1.example.com") != a.token("minio-1.example.com") {
+		t.Fatalf("Test 10: expected a host without port to be hashed as a whole")
+	}
+}
@@ -0,0 +1,229 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	json "github.com/minio/colorjson"
+	"github.com/minio/madmin-go"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+// traceStatsSlowest is the number of slowest calls kept in a stats report.
+const traceStatsSlowest = 10
+
+// traceAPIStats aggregates all calls of one API seen during a stats window.
+type traceAPIStats struct {
+	API    string        `json:"api"`
+	Type   string        `json:"type"`
+	Count  int           `json:"count"`
+	Errors int           `json:"errors"`
+	Rx     int64         `json:"rx"`
+	Tx     int64         `json:"tx"`
+	Avg    time.Duration `json:"avg"`
+	P50    time.Duration `json:"p50"`
+	P90    time.Duration `json:"p90"`
+	P99    time.Duration `json:"p99"`
+	Max    time.Duration `json:"max"`
+
+	durations []time.Duration
+}
+
+// traceSlowCall is one of the slowest calls seen during a stats window.
+type traceSlowCall struct {
+	Time       time.Time     `json:"time"`
+	Host       string        `json:"host"`
+	API        string        `json:"api"`
+	Path       string        `json:"path"`
+	StatusCode int           `json:"statusCode,omitempty"`
+	Duration   time.Duration `json:"duration"`
+}
+
+// traceStatsMessage is the aggregated report printed by `admin trace --stats`.
+type traceStatsMessage struct {
+	Status   string           `json:"status"`
+	Duration time.Duration    `json:"duration"`
+	Total    int              `json:"total"`
+	Errors   int              `json:"errors"`
+	APIs     []*traceAPIStats `json:"apis"`
+	Slowest  []traceSlowCall  `json:"slowest"`
+}
+
+// traceStats collects trace entries for a stats report.
+type traceStats struct {
+	apis    map[string]*traceAPIStats
+	slowest []traceSlowCall
+	total   int
+	errors  int
+}
+
+func newTraceStats() *traceStats {
+	return &traceStats{apis: make(map[string]*traceAPIStats)}
+}
+
+// add records a single trace entry.
+func (s *traceStats) add(t madmin.TraceInfo) {
+	call := traceSlowCall{
+		Time: t.Time,
+		Host: t.NodeName,
+		API:  t.FuncName,
+	}
+	var failed bool
+	var rx, tx int64
+	switch t.TraceType {
+	case madmin.TraceStorage:
+		call.Path = t.StorageStats.Path
+		call.Duration = t.StorageStats.Duration
+	case madmin.TraceOS:
+		call.Path = t.OSStats.Path
+		call.Duration = t.OSStats.Duration
+	default:
+		call.Time = t.ReqInfo.Time
+		call.Path = t.ReqInfo.Path
+		call.StatusCode = t.RespInfo.StatusCode
+		call.Duration = t.CallStats.Latency
+		failed = t.RespInfo.StatusCode >= http.StatusBadRequest
+		rx, tx = int64(t.CallStats.InputBytes), int64(t.CallStats.OutputBytes)
+	}
+
+	api, ok := s.apis[t.FuncName]
+	if !ok {
+		api = &traceAPIStats{API: t.FuncName, Type: traceTypeName(t.TraceType)}
+		s.apis[t.FuncName] = api
+	}
+	api.Count++
+	api.Rx += rx
+	api.Tx += tx
+	api.durations = append(api.durations, call.Duration)
+	s.total++
+	if failed {
+		api.Errors++
+		s.errors++
+	}
+
+	// Keep the slowest calls sorted, longest first.
+	i := sort.Search(len(s.slowest), func(i int) bool {
+		return s.slowest[i].Duration < call.Duration
+	})
+	if i < traceStatsSlowest {
+		s.slowest = append(s.slowest, traceSlowCall{})
+		copy(s.slowest[i+1:], s.slowest[i:])
+		s.slowest[i] = call
+		if len(s.slowest) > traceStatsSlowest {
+			s.slowest = s.slowest[:traceStatsSlowest]
+		}
+	}
+}
+
+// report computes the latency percentiles of every API, the busiest first.
+func (s *traceStats) report(window time.Duration) traceStatsMessage {
+	msg := traceStatsMessage{
+		Duration: window,
+		Total:    s.total,
+		Errors:   s.errors,
+		Slowest:  s.slowest,
+	}
+	for _, api := range s.apis {
+		sort.Slice(api.durations, func(i, j int) bool { return api.durations[i] < api.durations[j] })
+		var sum time.Duration
+		for _, d := range api.durations {
+			sum += d
+		}
+		api.Avg = sum / time.Duration(len(api.durations))
+		api.P50 = durationPercentile(api.durations, 50)
+		api.P90 = durationPercentile(api.durations, 90)
+		api.P99 = durationPercentile(api.durations, 99)
+		api.Max = api.durations[len(api.durations)-1]
+		msg.APIs = append(msg.APIs, api)
+	}
+	sort.Slice(msg.APIs, func(i, j int) bool {
+		if msg.APIs[i].Count == msg.APIs[j].Count {
+			return msg.APIs[i].API < msg.APIs[j].API
+		}
+		return msg.APIs[i].Count > msg.APIs[j].Count
+	})
+	return msg
+}
+
+// durationPercentile returns the nearest-rank percentile of sorted durations.
+func durationPercentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func traceTypeName(t madmin.TraceType) string {
+	switch t {
+	case madmin.TraceStorage:
+		return "storage"
+	case madmin.TraceOS:
+		return "os"
+	}
+	return "http"
+}
+
+func (t traceStatsMessage) JSON() string {
+	t.Status = "success"
+	statsBytes, e := json.MarshalIndent(t, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(statsBytes)
+}
+
+func (t traceStatsMessage) String() string {
+	var b = &strings.Builder{}
+	fmt.Fprintf(b, "%s %d calls, %d errors in %s\n", console.Colorize("Stat", "Summary:"), t.Total, t.Errors, t.Duration)
+	if t.Total == 0 {
+		return strings.TrimSuffix(b.String(), "\n")
+	}
+
+	fmt.Fprintln(b)
+	fmt.Fprintln(b, console.Colorize("Stat", fmt.Sprintf("%-40s %8s %7s %10s %10s %10s %10s %10s", "API", "COUNT", "ERRORS", "AVG", "P50", "P90", "P99", "MAX")))
+	for _, api := range t.APIs {
+		errStr := fmt.Sprintf("%7d", api.Errors)
+		if api.Errors > 0 {
+			errStr = console.Colorize("ErrStatus", errStr)
+		}
+		fmt.Fprintf(b, "%s %8d %s %10s %10s %10s %10s %10s\n", console.Colorize("FuncName", fmt.Sprintf("%-40s", api.API)), api.Count, errStr,
+			api.Avg.Round(time.Microsecond), api.P50.Round(time.Microsecond), api.P90.Round(time.Microsecond),
+			api.P99.Round(time.Microsecond), api.Max.Round(time.Microsecond))
+	}
+
+	fmt.Fprintln(b)
+	fmt.Fprintln(b, console.Colorize("Stat", "Slowest calls:"))
+	for _, call := range t.Slowest {
+		fmt.Fprintf(b, "%s %10s %s %s%s", call.Time.Format(timeFormat), call.Duration.Round(time.Microsecond),
+			console.Colorize("FuncName", call.API), colorizedNodeName(call.Host), call.Path)
+		if call.StatusCode != 0 {
+			fmt.Fprintf(b, " [%d]", call.StatusCode)
+		}
+		fmt.Fprintln(b)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
@@ -0,0 +1,85 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/minio/madmin-go"
+)
+
+func TestDurationPercentile(t *testing.T) {
+	var sorted []time.Duration
+	for i := 1; i <= 100; i++ {
+		sorted = append(sorted, time.Duration(i)*time.Millisecond)
+	}
+	testCases := []struct {
+		durations []time.Duration
+		p         int
+		expected  time.Duration
+	}{
+		{nil, 50, 0},
+		{sorted[:1], 99, time.Millisecond},
+		{sorted, 50, 50 * time.Millisecond},
+		{sorted, 90, 90 * time.Millisecond},
+		{sorted, 99, 99 * time.Millisecond},
+		{sorted[:10], 99, 10 * time.Millisecond},
+	}
+	for i, testCase := range testCases {
+		if got := durationPercentile(testCase.durations, testCase.p); got != testCase.expected {
+			t.Fatalf("Test %d: expected %s, got %s", i+1, testCase.expected, got)
+		}
+	}
+}
+
+func TestTraceStats(t *testing.T) {
+	stats := newTraceStats()
+	for i := 1; i <= 20; i++ {
+		trace := madmin.TraceInfo{FuncName: "s3.GetObject"}
+		trace.CallStats.Latency = time.Duration(i) * time.Millisecond
+		trace.RespInfo.StatusCode = 200
+		if i%5 == 0 {
+			trace.RespInfo.StatusCode = 503
+		}
+		stats.add(trace)
+	}
+	stats.add(madmin.TraceInfo{
+		TraceType:    madmin.TraceStorage,
+		FuncName:     "storage.ReadAll",
+		StorageStats: madmin.TraceStorageStats{Duration: time.Second},
+	})
+
+	report := stats.report(time.Minute)
+	if report.Total != 21 || report.Errors != 4 {
+		t.Fatalf("expected 21 calls and 4 errors, got %d and %d", report.Total, report.Errors)
+	}
+	if len(report.APIs) != 2 || report.APIs[0].API != "s3.GetObject" {
+		t.Fatalf("unexpected APIs order: %v", report.APIs)
+	}
+	if api := report.APIs[0]; api.P50 != 10*time.Millisecond || api.Max != 20*time.Millisecond || api.Errors != 4 {
+		t.Fatalf("unexpected s3.GetObject stats: %+v", api)
+	}
+	if len(report.Slowest) != traceStatsSlowest {
+		t.Fatalf("expected %d slowest calls, got %d", traceStatsSlowest, len(report.Slowest))
+	}
+	if report.Slowest[0].API != "storage.ReadAll" || report.Slowest[1].Duration != 20*time.Millisecond ||
+		report.Slowest[traceStatsSlowest-1].Duration != 12*time.Millisecond {
+		t.Fatalf("unexpected slowest calls: %v", report.Slowest)
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"context"
 	"fmt"
 	"hash/fnv"
+	"net"
 	"net/http"
 	"path"
 	"strings"
@@ -64,16 +65,28 @@ var adminTraceFlags = []cli.Flag{
 	},
 	cli.StringSliceFlag{
 		Name:  "funcname",
-		Usage: "trace only matching func name",
+		Usage: "trace only matching func name, wildcards are accepted (e.g. `*Heal*`)",
 	},
 	cli.StringSliceFlag{
 		Name:  "path",
 		Usage: "trace only matching path",
 	},
+	cli.StringSliceFlag{
+		Name:  "node",
+		Usage: "trace only matching servers",
+	},
 	cli.BoolFlag{
 		Name:  "errors, e",
 		Usage: "trace only failed requests",
 	},
+	cli.DurationFlag{
+		Name:  "stats",
+		Usage: "collect traces for this duration (e.g. `1m`) and print aggregated call counts, latencies and errors per API",
+	},
+	cli.BoolFlag{
+		Name:  "anonymize",
+		Usage: "hash bucket, object and host names, redact credentials, user metadata, tags and bodies",
+	},
 }
 
 var adminTraceCmd = cli.Command{
@@ -93,6 +106,11 @@ USAGE:
 FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}
+ANONYMIZATION:
+  --anonymize replaces bucket, object, server and client host names by keyed hashes,
+  ports are kept. The key is generated randomly for each run: a name gets the same
+  hash all along one trace, but hashes of two runs cannot be compared.
+
 EXAMPLES:
   1. Show verbose console trace for MinIO server
      {{.Prompt}} {{.HelpName}} -v -a myminio
@@ -108,6 +126,18 @@ EXAMPLES:
 
   5. Show console trace for requests with '404' and '503' status code
     {{.Prompt}} {{.HelpName}} --status-code 404 --status-code 503 myminio
+
+  6. Show console trace for requests served by two specific servers
+    {{.Prompt}} {{.HelpName}} --node minio1:9000 --node minio2:9000 myminio
+
+  7. Show console trace only for internal healing operations
+    {{.Prompt}} {{.HelpName}} --call internal --funcname "*Heal*" myminio
+
+  8. Show per API call counts, latency percentiles and the slowest requests seen during one minute
+    {{.Prompt}} {{.HelpName}} --stats 1m myminio
+
+  9. Show verbose console trace safe to share in a public bug report
+    {{.Prompt}} {{.HelpName}} -v --anonymize myminio
 `,
 }
 
@@ -136,12 +166,27 @@ func matchTrace(ctx *cli.Context, traceInfo madmin.ServiceTraceInfo) bool {
 	methods := ctx.StringSlice("method")
 	funcNames := ctx.StringSlice("funcname")
 	apiPaths := ctx.StringSlice("path")
+	nodes := ctx.StringSlice("node")
 
-	if len(statusCodes) == 0 && len(methods) == 0 && len(funcNames) == 0 && len(apiPaths) == 0 {
+	if len(statusCodes) == 0 && len(methods) == 0 && len(funcNames) == 0 && len(apiPaths) == 0 && len(nodes) == 0 {
 		// no specific filtering found trace all the requests
 		return true
 	}
 
+	// Filter servers if passed by the user
+	if len(nodes) > 0 {
+		matched := false
+		for _, node := range nodes {
+			if nodeMatch(node, traceInfo.Trace.NodeName) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+
 	// Filter request path if passed by the user
 	if len(apiPaths) > 0 {
 		matched := false
@@ -203,6 +248,16 @@ func matchTrace(ctx *cli.Context, traceInfo madmin.ServiceTraceInfo) bool {
 	return true
 }
 
+// nodeMatch - matches a server passed by the user against the node name
+// of a trace entry, the port is optional in the user input.
+func nodeMatch(node, nodeName string) bool {
+	if node == nodeName {
+		return true
+	}
+	host, _, e := net.SplitHostPort(nodeName)
+	return e == nil && host == node
+}
+
 // Calculate tracing options for command line flags
 func tracingOpts(ctx *cli.Context) (opts madmin.ServiceTraceOpts, e error) {
 
@@ -289,15 +344,45 @@ func mainAdminTrace(ctx *cli.Context) error {
 	opts, e := tracingOpts(ctx)
 	fatalIf(probe.NewError(e), "Unable to start tracing")
 
+	var anonymizer *traceAnonymizer
+	if ctx.Bool("anonymize") {
+		anonymizer, e = newTraceAnonymizer()
+		fatalIf(probe.NewError(e), "Unable to initialize trace anonymization")
+	}
+
+	window := ctx.Duration("stats")
+	var stats *traceStats
+	start := time.Now()
+	if window > 0 {
+		stats = newTraceStats()
+		ctxt, cancel = context.WithTimeout(ctxt, window)
+		defer cancel()
+	}
+
 	// Start listening on all trace activity.
 	traceCh := client.ServiceTrace(ctxt, opts)
 	for traceInfo := range traceCh {
 		if traceInfo.Err != nil {
+			if stats != nil && ctxt.Err() != nil {
+				// Stats window is over.
+				break
+			}
 			fatalIf(probe.NewError(traceInfo.Err), "Unable to listen to http trace")
 		}
-		if matchTrace(ctx, traceInfo) {
-			printTrace(verbose, traceInfo)
+		if !matchTrace(ctx, traceInfo) {
+			continue
+		}
+		if anonymizer != nil {
+			traceInfo.Trace = anonymizer.trace(traceInfo.Trace)
 		}
+		if stats != nil {
+			stats.add(traceInfo.Trace)
+			continue
+		}
+		printTrace(verbose, traceInfo)
+	}
+	if stats != nil {
+		printMsg(stats.report(time.Since(start).Round(time.Second)))
 	}
 	return nil
 }
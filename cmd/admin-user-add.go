@@ -87,6 +87,7 @@ type userMessage struct {
 	PolicyName string   `json:"policyName,omitempty"`
 	UserStatus string   `json:"userStatus,omitempty"`
 	MemberOf   []string `json:"memberOf,omitempty"`
+	Policies   []string `json:"policies,omitempty"`
 }
 
 func (u userMessage) String() string {
@@ -97,6 +98,14 @@ func (u userMessage) String() string {
 		policyFieldMaxLen := 20
 
 		// Create a new pretty table with cols configuration
+		if u.Policies != nil {
+			return newPrettyTable("  ",
+				Field{"UserStatus", userFieldMaxLen},
+				Field{"AccessKey", accessFieldMaxLen},
+				Field{"PolicyName", policyFieldMaxLen},
+				Field{"PolicyName", -1},
+			).buildRow(u.UserStatus, u.AccessKey, u.PolicyName, strings.Join(u.Policies, ","))
+		}
 		return newPrettyTable("  ",
 			Field{"UserStatus", userFieldMaxLen},
 			Field{"AccessKey", accessFieldMaxLen},
@@ -124,7 +133,15 @@ func (u userMessage) String() string {
 
 func (u userMessage) JSON() string {
 	u.Status = "success"
-	jsonMessageBytes, e := json.MarshalIndent(u, "", " ")
+	var msg interface{} = u
+	if u.Policies != nil {
+		// Users listed without any policy get an empty list.
+		msg = struct {
+			userMessage
+			Policies []string `json:"policies"`
+		}{u, u.Policies}
+	}
+	jsonMessageBytes, e := json.MarshalIndent(msg, "", " ")
 	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
 
 	return string(jsonMessageBytes)
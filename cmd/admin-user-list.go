@@ -18,19 +18,34 @@
 package cmd
 
 import (
+	"sort"
+	"strings"
+	"sync"
+
 	"github.com/fatih/color"
 	"github.com/minio/cli"
+	"github.com/minio/madmin-go"
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/pkg/console"
 )
 
+// maxGroupLookups bounds the concurrent group lookups of --with-policy.
+const maxGroupLookups = 8
+
+var adminUserListFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "with-policy",
+		Usage: "list the policies of each user, including the ones inherited from its groups",
+	},
+}
+
 var adminUserListCmd = cli.Command{
 	Name:         "list",
 	Usage:        "list all users",
 	Action:       mainAdminUserList,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        globalFlags,
+	Flags:        append(adminUserListFlags, globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -43,6 +58,9 @@ FLAGS:
 EXAMPLES:
   1. List all users on MinIO server.
      {{.Prompt}} {{.HelpName}} myminio
+
+  2. List all users with their groups and effective policies, for an access audit.
+     {{.Prompt}} {{.HelpName}} --with-policy --json myminio
 `,
 }
 
@@ -74,13 +92,109 @@ func mainAdminUserList(ctx *cli.Context) error {
 	users, e := client.ListUsers(globalContext)
 	fatalIf(probe.NewError(e).Trace(args...), "Unable to list user")
 
+	var memberOf map[string][]string
+	var groupPolicies map[string]string
+	withPolicy := ctx.Bool("with-policy")
+	if withPolicy {
+		groups, e := client.ListGroups(globalContext)
+		fatalIf(probe.NewError(e).Trace(args...), "Unable to list groups")
+		descs, err := describeGroups(client, groups)
+		fatalIf(err.Trace(args...), "Unable to get group info")
+		memberOf, groupPolicies = groupMemberships(descs)
+	}
+
 	for k, v := range users {
-		printMsg(userMessage{
+		msg := userMessage{
 			op:         "list",
 			AccessKey:  k,
 			PolicyName: v.PolicyName,
 			UserStatus: string(v.Status),
-		})
+		}
+		if withPolicy {
+			msg.MemberOf = memberOf[k]
+			msg.Policies = effectivePolicies(v.PolicyName, msg.MemberOf, groupPolicies)
+		}
+		printMsg(msg)
 	}
 	return nil
 }
+
+// describeGroups - fetches the description of groups, a few at a time.
+func describeGroups(client *madmin.AdminClient, groups []string) ([]madmin.GroupDesc, *probe.Error) {
+	descs, errs := describeEachGroup(client, groups)
+	for i, e := range errs {
+		if e != nil {
+			return nil, probe.NewError(e).Trace(groups[i])
+		}
+	}
+	return descs, nil
+}
+
+// describeEachGroup - fetches the description of groups, a few at a time,
+// returning the error of each group which failed.
+func describeEachGroup(client *madmin.AdminClient, groups []string) ([]madmin.GroupDesc, []error) {
+	descs := make([]madmin.GroupDesc, len(groups))
+	errs := make([]error, len(groups))
+	limitCh := make(chan struct{}, maxGroupLookups)
+	var wg sync.WaitGroup
+	for i, group := range groups {
+		wg.Add(1)
+		limitCh <- struct{}{}
+		go func(i int, group string) {
+			defer func() {
+				<-limitCh
+				wg.Done()
+			}()
+			desc, e := client.GetGroupDescription(globalContext, group)
+			if e != nil {
+				errs[i] = e
+				return
+			}
+			descs[i] = *desc
+		}(i, group)
+	}
+	wg.Wait()
+	return descs, errs
+}
+
+// groupMemberships - returns the groups of each user and the policy of
+// each group, saving a user info lookup per user. Disabled groups grant
+// no policy.
+func groupMemberships(groups []madmin.GroupDesc) (memberOf map[string][]string, policies map[string]string) {
+	memberOf = make(map[string][]string)
+	policies = make(map[string]string)
+	for _, group := range groups {
+		if group.Status != "disabled" {
+			policies[group.Name] = group.Policy
+		}
+		for _, member := range group.Members {
+			memberOf[member] = append(memberOf[member], group.Name)
+		}
+	}
+	for _, groups := range memberOf {
+		sort.Strings(groups)
+	}
+	return memberOf, policies
+}
+
+// effectivePolicies - returns the sorted policy names applying to a user,
+// its own ones and the ones of its groups. An empty non nil slice is
+// returned when there are none.
+func effectivePolicies(userPolicy string, groups []string, groupPolicies map[string]string) []string {
+	seen := make(map[string]bool)
+	policies := []string{}
+	add := func(policy string) {
+		for _, name := range strings.Split(policy, ",") {
+			if name = strings.TrimSpace(name); name != "" && !seen[name] {
+				seen[name] = true
+				policies = append(policies, name)
+			}
+		}
+	}
+	add(userPolicy)
+	for _, group := range groups {
+		add(groupPolicies[group])
+	}
+	sort.Strings(policies)
+	return policies
+}
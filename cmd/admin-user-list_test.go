@@ -0,0 +1,62 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/minio/madmin-go"
+)
+
+func TestEffectivePolicies(t *testing.T) {
+	memberOf, groupPolicies := groupMemberships([]madmin.GroupDesc{
+		{Name: "devs", Members: []string{"alice", "bob"}, Policy: "readwrite"},
+		{Name: "auditors", Members: []string{"alice"}, Policy: "readonly,diagnostics"},
+		{Name: "empty", Members: []string{"bob"}},
+		{Name: "former", Members: []string{"erin"}, Policy: "consoleAdmin", Status: "disabled"},
+	})
+
+	testCases := []struct {
+		user, userPolicy string
+		groups           []string
+		policies         []string
+	}{
+		{"alice", "", []string{"auditors", "devs"}, []string{"diagnostics", "readonly", "readwrite"}},
+		{"bob", "readwrite", []string{"devs", "empty"}, []string{"readwrite"}},
+		{"carol", "consoleAdmin, readonly", nil, []string{"consoleAdmin", "readonly"}},
+		{"dave", "", nil, []string{}},
+		{"erin", "readonly", []string{"former"}, []string{"readonly"}},
+	}
+
+	for i, testCase := range testCases {
+		if groups := memberOf[testCase.user]; !reflect.DeepEqual(groups, testCase.groups) {
+			t.Fatalf("Test %d: expected groups %v, got %v", i+1, testCase.groups, groups)
+		}
+		policies := effectivePolicies(testCase.userPolicy, memberOf[testCase.user], groupPolicies)
+		if !reflect.DeepEqual(policies, testCase.policies) {
+			t.Fatalf("Test %d: expected policies %v, got %v", i+1, testCase.policies, policies)
+		}
+	}
+
+	msg := userMessage{op: "list", AccessKey: "dave", Policies: []string{}}
+	if !strings.Contains(strings.Replace(msg.JSON(), " ", "", -1), `"policies":[]`) {
+		t.Fatalf("expected an empty policies list in %s", msg.JSON())
+	}
+}
@@ -0,0 +1,224 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+	iampolicy "github.com/minio/pkg/iam/policy"
+)
+
+var adminUserPolicySimulateFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "requests",
+		Usage: "file listing the requests to simulate, one 'ACTION [RESOURCE]' per line, '-' reads from stdin",
+	},
+}
+
+var adminUserPolicySimulateCmd = cli.Command{
+	Name:         "policy-simulate",
+	Usage:        "evaluate a set of requests against the effective policy of a user",
+	Action:       mainAdminUserPolicySimulate,
+	OnUsageError: onUsageError,
+	Before:       setGlobalsFromContext,
+	Flags:        append(adminUserPolicySimulateFlags, globalFlags...),
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} TARGET USERNAME --requests FILE
+
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}
+REQUESTS:
+  Each line of the requests file holds an action and an optional resource, either as a
+  'bucket/object' path or as an 'arn:aws:s3:::bucket/object' ARN. Empty lines and lines
+  starting with '#' are ignored, e.g.:
+
+    s3:GetObject mybucket/photos/2021/january.jpg
+    s3:ListBucket arn:aws:s3:::mybucket
+    admin:ServerInfo
+
+  The requests are evaluated against the policies of the user merged with the ones of
+  its groups, without any condition values other than the user name.
+
+EXAMPLES:
+  1. Check which of the requests listed in "review.txt" user "foobar" is allowed to make.
+     {{.Prompt}} {{.HelpName}} myminio foobar --requests review.txt
+
+  2. Run the same check in a compliance job, reading the requests from stdin.
+     {{.Prompt}} cat review.txt | {{.HelpName}} --json myminio foobar --requests -
+`,
+}
+
+// checkAdminUserPolicySimulateSyntax - validate all the passed arguments
+func checkAdminUserPolicySimulateSyntax(ctx *cli.Context) {
+	if len(ctx.Args()) != 2 {
+		cli.ShowCommandHelpAndExit(ctx, "policy-simulate", 1) // last argument is exit code
+	}
+	if ctx.String("requests") == "" {
+		fatalIf(errInvalidArgument().Trace(ctx.Args()...), "--requests is required.")
+	}
+}
+
+// simulateRequest - a request to evaluate against a policy.
+type simulateRequest struct {
+	Action   string
+	Resource string
+}
+
+// parseSimulateRequests - parses the 'ACTION [RESOURCE]' lines of r.
+func parseSimulateRequests(r io.Reader) ([]simulateRequest, *probe.Error) {
+	var requests []simulateRequest
+	scanner := bufio.NewScanner(r)
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) > 2 {
+			return nil, probe.NewError(fmt.Errorf("line %d: expected 'ACTION [RESOURCE]', got `%s`", lineNum, line))
+		}
+		if !iampolicy.Action(fields[0]).IsValid() && !iampolicy.AdminAction(fields[0]).IsValid() {
+			return nil, probe.NewError(fmt.Errorf("line %d: invalid action `%s`", lineNum, fields[0]))
+		}
+		request := simulateRequest{Action: fields[0]}
+		if len(fields) == 2 {
+			request.Resource = strings.TrimPrefix(fields[1], iampolicy.ResourceARNPrefix)
+		}
+		requests = append(requests, request)
+	}
+	if e := scanner.Err(); e != nil {
+		return nil, probe.NewError(e)
+	}
+	return requests, nil
+}
+
+// isSimulatedRequestAllowed - evaluates request as made by user, member of groups.
+func isSimulatedRequestAllowed(policy iampolicy.Policy, user string, groups []string, request simulateRequest) bool {
+	bucket, object := request.Resource, ""
+	if i := strings.Index(request.Resource, "/"); i >= 0 {
+		bucket, object = request.Resource[:i], request.Resource[i+1:]
+	}
+	return policy.IsAllowed(iampolicy.Args{
+		AccountName: user,
+		Groups:      groups,
+		Action:      iampolicy.Action(request.Action),
+		BucketName:  bucket,
+		ObjectName:  object,
+		ConditionValues: map[string][]string{
+			"username": {user},
+			"userid":   {user},
+		},
+	})
+}
+
+// policySimulateMessage container for the result of a simulated request
+type policySimulateMessage struct {
+	Status   string `json:"status"`
+	User     string `json:"user"`
+	Action   string `json:"action"`
+	Resource string `json:"resource,omitempty"`
+	Result   string `json:"result"`
+}
+
+func (m policySimulateMessage) String() string {
+	result := console.Colorize("Allow", "allow")
+	if m.Result != "allow" {
+		result = console.Colorize("Deny", "deny ")
+	}
+	return fmt.Sprintf("%s  %s  %s", result, console.Colorize("Action", m.Action), m.Resource)
+}
+
+func (m policySimulateMessage) JSON() string {
+	m.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(m, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// mainAdminUserPolicySimulate is the handler for "mc admin user policy-simulate" command.
+func mainAdminUserPolicySimulate(ctx *cli.Context) error {
+	checkAdminUserPolicySimulateSyntax(ctx)
+
+	console.SetColor("Allow", color.New(color.FgGreen, color.Bold))
+	console.SetColor("Deny", color.New(color.FgRed, color.Bold))
+	console.SetColor("Action", color.New(color.FgYellow))
+
+	// Get the alias parameter from cli
+	args := ctx.Args()
+	aliasedURL, user := args.Get(0), args.Get(1)
+
+	requestsFile := ctx.String("requests")
+	var reader io.Reader = os.Stdin
+	if requestsFile != "-" {
+		f, e := os.Open(requestsFile)
+		fatalIf(probe.NewError(e).Trace(requestsFile), "Unable to open the requests file.")
+		defer f.Close()
+		reader = f
+	}
+	requests, err := parseSimulateRequests(reader)
+	fatalIf(err.Trace(requestsFile), "Unable to parse the requests file.")
+
+	// Create a new MinIO Admin Client
+	client, err := newAdminClient(aliasedURL)
+	fatalIf(err, "Unable to initialize admin connection.")
+
+	userInfo, e := client.GetUserInfo(globalContext, user)
+	fatalIf(probe.NewError(e).Trace(args...), "Unable to get user info")
+
+	descs, err := describeGroups(client, userInfo.MemberOf)
+	fatalIf(err.Trace(args...), "Unable to get group info")
+	_, groupPolicies := groupMemberships(descs)
+
+	var policy iampolicy.Policy
+	for _, name := range effectivePolicies(userInfo.PolicyName, userInfo.MemberOf, groupPolicies) {
+		buf, e := client.InfoCannedPolicy(globalContext, name)
+		fatalIf(probe.NewError(e).Trace(name), "Unable to fetch policy document")
+		p, e := iampolicy.ParseConfig(bytes.NewReader(buf))
+		fatalIf(probe.NewError(e).Trace(name), "Unable to parse policy document")
+		policy = policy.Merge(*p)
+	}
+
+	for _, request := range requests {
+		result := "deny"
+		if isSimulatedRequestAllowed(policy, user, userInfo.MemberOf, request) {
+			result = "allow"
+		}
+		printMsg(policySimulateMessage{
+			User:     user,
+			Action:   request.Action,
+			Resource: request.Resource,
+			Result:   result,
+		})
+	}
+	return nil
+}
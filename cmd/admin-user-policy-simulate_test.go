@@ -0,0 +1,98 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	iampolicy "github.com/minio/pkg/iam/policy"
+)
+
+func TestParseSimulateRequests(t *testing.T) {
+	requests, err := parseSimulateRequests(strings.NewReader(`
+# photos
+s3:GetObject mybucket/photos/a.jpg
+  s3:ListBucket   arn:aws:s3:::mybucket
+
+admin:ServerInfo
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []simulateRequest{
+		{"s3:GetObject", "mybucket/photos/a.jpg"},
+		{"s3:ListBucket", "mybucket"},
+		{"admin:ServerInfo", ""},
+	}
+	if len(requests) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, requests)
+	}
+	for i := range expected {
+		if requests[i] != expected[i] {
+			t.Fatalf("Request %d: expected %v, got %v", i+1, expected[i], requests[i])
+		}
+	}
+
+	for _, input := range []string{"s3:NoSuchAction mybucket", "s3:GetObject mybucket/a extra"} {
+		if _, err := parseSimulateRequests(strings.NewReader(input)); err == nil {
+			t.Fatalf("expected %q to fail", input)
+		}
+	}
+}
+
+func TestIsSimulatedRequestAllowed(t *testing.T) {
+	userPolicy, e := iampolicy.ParseConfig(strings.NewReader(`{
+  "Version": "2012-10-17",
+  "Statement": [
+    {"Effect": "Allow", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::mybucket/*"]},
+    {"Effect": "Allow", "Action": ["s3:PutObject"], "Resource": ["arn:aws:s3:::home/${aws:username}/*"]}
+  ]
+}`))
+	if e != nil {
+		t.Fatal(e)
+	}
+	groupPolicy, e := iampolicy.ParseConfig(strings.NewReader(`{
+  "Version": "2012-10-17",
+  "Statement": [
+    {"Effect": "Deny", "Action": ["s3:GetObject"], "Resource": ["arn:aws:s3:::mybucket/private/*"]}
+  ]
+}`))
+	if e != nil {
+		t.Fatal(e)
+	}
+	policy := userPolicy.Merge(*groupPolicy)
+
+	testCases := []struct {
+		request simulateRequest
+		allowed bool
+	}{
+		{simulateRequest{"s3:GetObject", "mybucket/photos/a.jpg"}, true},
+		{simulateRequest{"s3:GetObject", "mybucket/private/a.jpg"}, false},
+		{simulateRequest{"s3:GetObject", "otherbucket/a.jpg"}, false},
+		{simulateRequest{"s3:PutObject", "home/foobar/a.jpg"}, true},
+		{simulateRequest{"s3:PutObject", "home/other/a.jpg"}, false},
+		{simulateRequest{"admin:ServerInfo", ""}, false},
+	}
+
+	for i, testCase := range testCases {
+		if allowed := isSimulatedRequestAllowed(policy, "foobar", []string{"auditors"}, testCase.request); allowed != testCase.allowed {
+			t.Fatalf("Test %d: expected allowed %t for %v, got %t", i+1, testCase.allowed, testCase.request, allowed)
+		}
+	}
+}
@@ -42,6 +42,10 @@ var adminUserSvcAcctAddFlags = []cli.Flag{
 		Name:  "policy",
 		Usage: "path to a JSON policy file",
 	},
+	cli.StringFlag{
+		Name:  "target-account",
+		Usage: "create the service account on behalf of this account instead of the positional ACCOUNT",
+	},
 }
 
 var adminUserSvcAcctAddCmd = cli.Command{
@@ -55,10 +59,13 @@ var adminUserSvcAcctAddCmd = cli.Command{
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}} ALIAS ACCOUNT
+  {{.HelpName}} ALIAS [ACCOUNT]
 
 ACCOUNT:
-  An account could be a regular MinIO user, STS ou LDAP user.
+  An account could be a regular MinIO user, STS ou LDAP user. It becomes the parent
+  of the service account, whose permissions are the ones of the parent, restricted
+  by the policy passed with --policy if any. ACCOUNT may be omitted when
+  --target-account is set.
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -66,15 +73,24 @@ FLAGS:
 EXAMPLES:
   1. Add a new service account for user 'foobar' to MinIO server.
      {{.Prompt}} {{.HelpName}} myminio foobar
+
+  2. Add a new service account on behalf of user 'foobar', restricted to read-only access.
+     {{.Prompt}} {{.HelpName}} --target-account foobar --policy readonly.json myminio
 `,
 }
 
 // checkAdminUserSvcAcctAddSyntax - validate all the passed arguments
 func checkAdminUserSvcAcctAddSyntax(ctx *cli.Context) {
-	if len(ctx.Args()) != 2 {
+	targetAccount := ctx.String("target-account")
+	argsNr := len(ctx.Args())
+	if argsNr != 2 && (targetAccount == "" || argsNr != 1) {
 		fatalIf(errInvalidArgument().Trace(ctx.Args().Tail()...),
 			"Incorrect number of arguments for user svcacct add command.")
 	}
+	if targetAccount != "" && argsNr == 2 && ctx.Args().Get(1) != targetAccount {
+		fatalIf(errInvalidArgument().Trace(ctx.Args().Get(1), targetAccount),
+			"Account `"+ctx.Args().Get(1)+"` conflicts with --target-account `"+targetAccount+"`.")
+	}
 }
 
 // svcAcctMessage container for content message structure
@@ -122,8 +138,11 @@ func (u svcAcctMessage) String() string {
 	case "enable":
 		return console.Colorize("UserMessage", "Enabled service account `"+u.AccessKey+"` successfully.")
 	case "add":
-		return console.Colorize("UserMessage",
-			fmt.Sprintf("Access Key: %s\nSecret Key: %s", u.AccessKey, u.SecretKey))
+		msg := fmt.Sprintf("Access Key: %s\nSecret Key: %s", u.AccessKey, u.SecretKey)
+		if u.ParentUser != "" {
+			msg += fmt.Sprintf("\nParent User: %s", u.ParentUser)
+		}
+		return console.Colorize("UserMessage", msg)
 	case "set":
 		return console.Colorize("UserMessage", "Edited service account `"+u.AccessKey+"` successfully.")
 	}
@@ -146,6 +165,9 @@ func mainAdminUserSvcAcctAdd(ctx *cli.Context) error {
 	args := ctx.Args()
 	aliasedURL := args.Get(0)
 	user := args.Get(1)
+	if targetAccount := ctx.String("target-account"); targetAccount != "" {
+		user = targetAccount
+	}
 
 	accessKey := ctx.String("access-key")
 	secretKey := ctx.String("secret-key")
@@ -176,6 +198,7 @@ func mainAdminUserSvcAcctAdd(ctx *cli.Context) error {
 		op:            "add",
 		AccessKey:     creds.AccessKey,
 		SecretKey:     creds.SecretKey,
+		ParentUser:    user,
 		AccountStatus: "enabled",
 	})
 
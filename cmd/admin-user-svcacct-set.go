@@ -34,10 +34,19 @@ var adminUserSvcAcctSetFlags = []cli.Flag{
 		Name:  "policy",
 		Usage: "path to a JSON policy file",
 	},
+	cli.BoolFlag{
+		Name:  "enable",
+		Usage: "enable the service account",
+	},
+	cli.BoolFlag{
+		Name:  "disable",
+		Usage: "disable the service account",
+	},
 }
 
 var adminUserSvcAcctSetCmd = cli.Command{
 	Name:         "set",
+	Aliases:      []string{"edit"},
 	Usage:        "edit an existing service account",
 	Action:       mainAdminUserSvcAcctSet,
 	OnUsageError: onUsageError,
@@ -55,6 +64,9 @@ FLAGS:
 EXAMPLES:
   1. Change the secret key of the service account 'J123C4ZXEQN8RK6ND35I' in MinIO server.
      {{.Prompt}} {{.HelpName}} myminio/ 'J123C4ZXEQN8RK6ND35I' --secret-key 'xxxxxxx'
+
+  2. Replace the policy of the service account 'J123C4ZXEQN8RK6ND35I' and disable it, keeping its secret key.
+     {{.Prompt}} {{.HelpName}} myminio/ 'J123C4ZXEQN8RK6ND35I' --policy /tmp/policy.json --disable
 `,
 }
 
@@ -64,6 +76,14 @@ func checkAdminUserSvcAcctSetSyntax(ctx *cli.Context) {
 		fatalIf(errInvalidArgument().Trace(ctx.Args().Tail()...),
 			"Incorrect number of arguments for user svcacct set command.")
 	}
+	if ctx.Bool("enable") && ctx.Bool("disable") {
+		fatalIf(errInvalidArgument().Trace(ctx.Args().Tail()...),
+			"--enable and --disable cannot be used together.")
+	}
+	if ctx.String("secret-key") == "" && ctx.String("policy") == "" && !ctx.Bool("enable") && !ctx.Bool("disable") {
+		fatalIf(errInvalidArgument().Trace(ctx.Args().Tail()...),
+			"Nothing to update, please pass --secret-key, --policy, --enable or --disable.")
+	}
 }
 
 // mainAdminUserSvcAcctSet is the handle for "mc admin user svcacct set" command.
@@ -93,9 +113,15 @@ func mainAdminUserSvcAcctSet(ctx *cli.Context) error {
 		NewPolicy:    buf,
 		NewSecretKey: secretKey,
 	}
+	switch {
+	case ctx.Bool("enable"):
+		opts.NewStatus = "on"
+	case ctx.Bool("disable"):
+		opts.NewStatus = "off"
+	}
 
 	e := client.UpdateServiceAccount(globalContext, svcAccount, opts)
-	fatalIf(probe.NewError(e).Trace(args...), "Unable to add a new service account")
+	fatalIf(probe.NewError(e).Trace(args...), "Unable to edit the service account")
 
 	printMsg(svcAcctMessage{
 		op:        "set",
@@ -27,6 +27,7 @@ var adminUserSubcommands = []cli.Command{
 	adminUserListCmd,
 	adminUserInfoCmd,
 	adminUserPolicyCmd,
+	adminUserPolicySimulateCmd,
 	adminUserSvcAcctCmd,
 }
 
@@ -75,6 +75,7 @@ func mainAliasList(ctx *cli.Context, deprecated bool) error {
 	console.SetColor("SecretKey", color.New(color.FgCyan))
 	console.SetColor("API", color.New(color.FgBlue))
 	console.SetColor("Path", color.New(color.FgCyan))
+	console.SetColor("Endpoints", color.New(color.FgYellow))
 
 	alias := cleanAlias(ctx.Args().Get(0))
 
@@ -130,6 +131,8 @@ func listAliases(alias string, deprecated bool) (aliases []aliasMessage) {
 				AccessKey:   v.AccessKey,
 				SecretKey:   v.SecretKey,
 				API:         v.API,
+				Region:      v.Region,
+				Endpoints:   v.Endpoints,
 			}
 
 			if deprecated {
@@ -151,6 +154,8 @@ func listAliases(alias string, deprecated bool) (aliases []aliasMessage) {
 			AccessKey:   v.AccessKey,
 			SecretKey:   v.SecretKey,
 			API:         v.API,
+			Region:      v.Region,
+			Endpoints:   v.Endpoints,
 		}
 
 		if deprecated {
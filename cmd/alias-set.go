@@ -46,6 +46,14 @@ var aliasSetFlags = []cli.Flag{
 		Name:  "api",
 		Usage: "API signature. Valid options are '[S3v4, S3v2]'",
 	},
+	cli.StringFlag{
+		Name:  "region",
+		Usage: "region of the service, auto-detected per bucket when not set",
+	},
+	cli.StringFlag{
+		Name:  "endpoints",
+		Usage: "comma separated list of additional URLs serving the same deployment, used for failover and balancing",
+	},
 }
 
 var aliasSetCmd = cli.Command{
@@ -96,6 +104,18 @@ EXAMPLES:
      {{.Prompt}} echo -e "BKIKJAA5BMMU2RHO6IBB\nV8f1CwQqAcwo80UEIJEjc5gVQUSSx5ohQ9GSrr12" | \
                  {{.HelpName}} mys3 https://s3.amazonaws.com --api "s3v4" --path "off"
      {{.EnableHistory}}
+
+  6. Add Amazon S3 storage service under "mys3eu" alias, with buckets located in the 'eu-west-1' region.
+     {{.DisableHistory}}
+     {{.Prompt}} {{.HelpName}} mys3eu https://s3.amazonaws.com \
+                 BKIKJAA5BMMU2RHO6IBB V8f1CwQqAcwo80UEIJEjc5gVQUSSx5ohQ9GSrr12 --region eu-west-1
+     {{.EnableHistory}}
+
+  7. Add MinIO service under "myminio" alias, spreading requests over three nodes and moving on to the next
+     node when one cannot be reached. For security reasons turn off bash history momentarily.
+     {{.DisableHistory}}
+     {{.Prompt}} {{.HelpName}} --endpoints http://node2:9000,http://node3:9000 myminio http://node1:9000 minio minio123
+     {{.EnableHistory}}
 `,
 }
 
@@ -122,6 +142,16 @@ func checkAliasSetSyntax(ctx *cli.Context, accessKey string, secretKey string, d
 		fatalIf(errInvalidURL(url), "Invalid URL.")
 	}
 
+	for _, endpoint := range parseAliasEndpoints(ctx.String("endpoints")) {
+		if !isValidHostURL(endpoint) {
+			fatalIf(errInvalidURL(endpoint), "Invalid endpoint URL.")
+		}
+		if newClientURL(endpoint).Scheme != newClientURL(url).Scheme {
+			fatalIf(errInvalidArgument().Trace(endpoint),
+				"Endpoint `"+endpoint+"` must use the same scheme as `"+url+"`.")
+		}
+	}
+
 	if !isValidAccessKey(accessKey) {
 		fatalIf(errInvalidArgument().Trace(accessKey),
 			"Invalid access key `"+accessKey+"`.")
@@ -150,6 +180,18 @@ func checkAliasSetSyntax(ctx *cli.Context, accessKey string, secretKey string, d
 	}
 }
 
+// parseAliasEndpoints - splits the value of --endpoints into URLs.
+func parseAliasEndpoints(value string) []string {
+	var endpoints []string
+	for _, endpoint := range strings.Split(value, ",") {
+		endpoint = trimTrailingSeparator(strings.TrimSpace(endpoint))
+		if endpoint != "" {
+			endpoints = append(endpoints, endpoint)
+		}
+	}
+	return endpoints
+}
+
 // setAlias - set an alias config.
 func setAlias(alias string, aliasCfgV10 aliasConfigV10) aliasMessage {
 	mcCfgV10, err := loadMcConfig()
@@ -168,12 +210,14 @@ func setAlias(alias string, aliasCfgV10 aliasConfigV10) aliasMessage {
 		SecretKey: aliasCfgV10.SecretKey,
 		API:       aliasCfgV10.API,
 		Path:      aliasCfgV10.Path,
+		Region:    aliasCfgV10.Region,
+		Endpoints: aliasCfgV10.Endpoints,
 	}
 }
 
 // probeS3Signature - auto probe S3 server signature: issue a Stat call
 // using v4 signature then v2 in case of failure.
-func probeS3Signature(ctx context.Context, accessKey, secretKey, url string) (string, *probe.Error) {
+func probeS3Signature(ctx context.Context, accessKey, secretKey, url, region string) (string, *probe.Error) {
 	probeBucketName := randString(60, rand.NewSource(time.Now().UnixNano()), "probe-bucket-sign-")
 	// Test s3 connection for API auto probe
 	s3Config := &Config{
@@ -183,6 +227,7 @@ func probeS3Signature(ctx context.Context, accessKey, secretKey, url string) (st
 		SecretKey: secretKey,
 		HostURL:   urlJoinPath(url, probeBucketName),
 		Debug:     globalDebug,
+		Region:    region,
 	}
 
 	probeSignatureType := func(stype string) (string, *probe.Error) {
@@ -223,12 +268,13 @@ func probeS3Signature(ctx context.Context, accessKey, secretKey, url string) (st
 
 // BuildS3Config constructs an S3 Config and does
 // signature auto-probe when needed.
-func BuildS3Config(ctx context.Context, url, accessKey, secretKey, api, path string) (*Config, *probe.Error) {
+func BuildS3Config(ctx context.Context, url, accessKey, secretKey, api, path, region string) (*Config, *probe.Error) {
 	s3Config := NewS3Config(url, &aliasConfigV10{
 		AccessKey: accessKey,
 		SecretKey: secretKey,
 		URL:       url,
 		Path:      path,
+		Region:    region,
 	})
 
 	// If api is provided we do not auto probe signature, this is
@@ -238,9 +284,9 @@ func BuildS3Config(ctx context.Context, url, accessKey, secretKey, api, path str
 		return s3Config, nil
 	}
 	// Probe S3 signature version
-	api, err := probeS3Signature(ctx, accessKey, secretKey, url)
+	api, err := probeS3Signature(ctx, accessKey, secretKey, url, region)
 	if err != nil {
-		return nil, err.Trace(url, accessKey, secretKey, api, path)
+		return nil, err.Trace(url, accessKey, secretKey, api, path, region)
 	}
 
 	s3Config.Signature = api
@@ -288,11 +334,12 @@ func fetchAliasKeys(args cli.Args) (string, string) {
 func mainAliasSet(cli *cli.Context, deprecated bool) error {
 	console.SetColor("AliasMessage", color.New(color.FgGreen))
 	var (
-		args  = cli.Args()
-		alias = cleanAlias(args.Get(0))
-		url   = trimTrailingSeparator(args.Get(1))
-		api   = cli.String("api")
-		path  = cli.String("path")
+		args   = cli.Args()
+		alias  = cleanAlias(args.Get(0))
+		url    = trimTrailingSeparator(args.Get(1))
+		api    = cli.String("api")
+		path   = cli.String("path")
+		region = cli.String("region")
 	)
 
 	// Support deprecated lookup flag
@@ -315,7 +362,7 @@ func mainAliasSet(cli *cli.Context, deprecated bool) error {
 	ctx, cancelAliasAdd := context.WithCancel(globalContext)
 	defer cancelAliasAdd()
 
-	s3Config, err := BuildS3Config(ctx, url, accessKey, secretKey, api, path)
+	s3Config, err := BuildS3Config(ctx, url, accessKey, secretKey, api, path, region)
 	fatalIf(err.Trace(cli.Args()...), "Unable to initialize new alias from the provided credentials.")
 
 	msg := setAlias(alias, aliasConfigV10{
@@ -324,6 +371,8 @@ func mainAliasSet(cli *cli.Context, deprecated bool) error {
 		SecretKey: s3Config.SecretKey,
 		API:       s3Config.Signature,
 		Path:      path,
+		Region:    s3Config.Region,
+		Endpoints: parseAliasEndpoints(cli.String("endpoints")),
 	}) // Add an alias with specified credentials.
 
 	msg.op = "set"
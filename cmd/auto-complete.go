@@ -316,6 +316,7 @@ var completeCmds = map[string]complete.Predictor{
 	"/admin/console":   aliasCompleter,
 	"/admin/update":    aliasCompleter,
 	"/admin/top/locks": aliasCompleter,
+	"/admin/top/drive": aliasCompleter,
 
 	"/admin/service/stop":    aliasCompleter,
 	"/admin/service/restart": aliasCompleter,
@@ -333,13 +334,14 @@ var completeCmds = map[string]complete.Predictor{
 	"/admin/policy/list":   aliasCompleter,
 	"/admin/policy/remove": aliasCompleter,
 
-	"/admin/user/add":     aliasCompleter,
-	"/admin/user/disable": aliasCompleter,
-	"/admin/user/enable":  aliasCompleter,
-	"/admin/user/list":    aliasCompleter,
-	"/admin/user/remove":  aliasCompleter,
-	"/admin/user/info":    aliasCompleter,
-	"/admin/user/policy":  aliasCompleter,
+	"/admin/user/add":             aliasCompleter,
+	"/admin/user/disable":         aliasCompleter,
+	"/admin/user/enable":          aliasCompleter,
+	"/admin/user/list":            aliasCompleter,
+	"/admin/user/remove":          aliasCompleter,
+	"/admin/user/info":            aliasCompleter,
+	"/admin/user/policy":          aliasCompleter,
+	"/admin/user/policy-simulate": aliasCompleter,
 
 	"/admin/user/svcacct/add":     aliasCompleter,
 	"/admin/user/svcacct/ls":      aliasCompleter,
@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strconv"
 	"strings"
 	"syscall"
 	"time"
@@ -44,6 +45,14 @@ var (
 			Name:  "version-id, vid",
 			Usage: "display a specific version of an object",
 		},
+		cli.StringFlag{
+			Name:  "ranges",
+			Usage: "display only the comma separated byte ranges of an object (e.g. `0-1023,4096-8191`)",
+		},
+		cli.BoolFlag{
+			Name:  "range-header",
+			Usage: "prefix each byte range with a header, requires --ranges",
+		},
 	}
 )
 
@@ -89,6 +98,9 @@ EXAMPLES:
 
   7. Display the content of a particular object version
      {{.Prompt}} {{.HelpName}} --vid "3ddac055-89a7-40fa-8cd3-530a5581b6b8" play/my-bucket/my-object
+
+  8. Display the first kilobyte and the third 4 kilobytes block of an object, each prefixed with a header
+     {{.Prompt}} {{.HelpName}} --ranges "0-1023,8192-12287" --range-header play/my-bucket/my-object
 `,
 }
 
@@ -141,8 +153,46 @@ func (s prettyStdout) Write(input []byte) (int, error) {
 	return inputLen, nil
 }
 
+// catRange - inclusive byte range of an object, an end of -1 means till the end of the object.
+type catRange struct {
+	start, end int64
+}
+
+// String - returns the range in `start-end` form.
+func (r catRange) String() string {
+	if r.end < 0 {
+		return fmt.Sprintf("%d-", r.start)
+	}
+	return fmt.Sprintf("%d-%d", r.start, r.end)
+}
+
+// parseCatRanges - parses comma separated byte ranges such as `0-1023,4096-`.
+func parseCatRanges(rangesStr string) ([]catRange, *probe.Error) {
+	var ranges []catRange
+	for _, rangeStr := range strings.Split(rangesStr, ",") {
+		rangeStr = strings.TrimSpace(rangeStr)
+		tokens := strings.SplitN(rangeStr, "-", 2)
+		if len(tokens) != 2 || tokens[0] == "" {
+			return nil, errInvalidArgument().Trace(rangeStr)
+		}
+		start, e := strconv.ParseInt(tokens[0], 10, 64)
+		if e != nil || start < 0 {
+			return nil, errInvalidArgument().Trace(rangeStr)
+		}
+		end := int64(-1)
+		if tokens[1] != "" {
+			end, e = strconv.ParseInt(tokens[1], 10, 64)
+			if e != nil || end < start {
+				return nil, errInvalidArgument().Trace(rangeStr)
+			}
+		}
+		ranges = append(ranges, catRange{start: start, end: end})
+	}
+	return ranges, nil
+}
+
 // parseCatSyntax performs command-line input validation for cat command.
-func parseCatSyntax(ctx *cli.Context) (args []string, versionID string, timeRef time.Time) {
+func parseCatSyntax(ctx *cli.Context) (args []string, versionID string, timeRef time.Time, ranges []catRange) {
 	args = ctx.Args()
 
 	versionID = ctx.String("version-id")
@@ -162,14 +212,27 @@ func parseCatSyntax(ctx *cli.Context) (args []string, versionID string, timeRef
 		}
 	}
 
+	if ctx.IsSet("ranges") {
+		if len(args) != 1 || args[0] == "-" {
+			fatalIf(errInvalidArgument().Trace(args...), "You need to pass exactly one object if --ranges is specified")
+		}
+		var err *probe.Error
+		ranges, err = parseCatRanges(ctx.String("ranges"))
+		fatalIf(err, "Unable to parse --ranges `"+ctx.String("ranges")+"`.")
+	} else if ctx.Bool("range-header") {
+		fatalIf(errInvalidArgument().Trace(), "You cannot specify --range-header without --ranges")
+	}
+
 	timeRef = parseRewindFlag(rewind)
 	return
 }
 
-// catURL displays contents of a URL to stdout.
-func catURL(ctx context.Context, sourceURL, sourceVersion string, timeRef time.Time, encKeyDB map[string][]prefixSSEPair) *probe.Error {
+// catURL displays contents of a URL to stdout, restricted to the
+// given byte ranges when any are passed.
+func catURL(ctx context.Context, sourceURL, sourceVersion string, timeRef time.Time, encKeyDB map[string][]prefixSSEPair, ranges []catRange, rangeHeader bool) *probe.Error {
 	var reader io.ReadCloser
 	size := int64(-1)
+	objectSize := int64(-1)
 	switch sourceURL {
 	case "-":
 		reader = os.Stdin
@@ -189,6 +252,7 @@ func catURL(ctx context.Context, sourceURL, sourceVersion string, timeRef time.T
 			if client.GetURL().Type == objectStorage {
 				size = content.Size
 			}
+			objectSize = content.Size
 		} else {
 			return err.Trace(sourceURL)
 		}
@@ -197,9 +261,44 @@ func catURL(ctx context.Context, sourceURL, sourceVersion string, timeRef time.T
 		}
 		defer reader.Close()
 	}
+	if len(ranges) > 0 {
+		return catRanges(reader, objectSize, ranges, rangeHeader).Trace(sourceURL)
+	}
 	return catOut(reader, size).Trace(sourceURL)
 }
 
+// catRanges writes the requested byte ranges of reader one after the other
+// to stdout, all ranges are validated against size before anything is written.
+func catRanges(r io.Reader, size int64, ranges []catRange, rangeHeader bool) *probe.Error {
+	seeker, ok := r.(io.ReadSeeker)
+	if !ok {
+		return probe.NewError(errors.New("byte ranges are not supported for this source"))
+	}
+	for i, rng := range ranges {
+		if rng.start >= size || rng.end >= size {
+			return errInvalidArgument().Trace(rng.String(), strconv.FormatInt(size, 10))
+		}
+		if rng.end < 0 {
+			ranges[i].end = size - 1
+		}
+	}
+	for _, rng := range ranges {
+		if rangeHeader {
+			if _, e := fmt.Fprintf(os.Stdout, "==> bytes=%s <==\n", rng); e != nil {
+				return probe.NewError(e)
+			}
+		}
+		if _, e := seeker.Seek(rng.start, io.SeekStart); e != nil {
+			return probe.NewError(e)
+		}
+		length := rng.end - rng.start + 1
+		if err := catOut(io.LimitReader(seeker, length), length); err != nil {
+			return err.Trace(rng.String())
+		}
+	}
+	return nil
+}
+
 // catOut reads from reader stream and writes to stdout. Also check the length of the
 // read bytes against size parameter (if not -1) and return the appropriate error
 func catOut(r io.Reader, size int64) *probe.Error {
@@ -255,7 +354,7 @@ func mainCat(cliCtx *cli.Context) error {
 	fatalIf(err, "Unable to parse encryption keys.")
 
 	// check 'cat' cli arguments.
-	args, versionID, rewind := parseCatSyntax(cliCtx)
+	args, versionID, rewind, ranges := parseCatSyntax(cliCtx)
 
 	// Set command flags from context.
 	stdinMode := false
@@ -282,7 +381,7 @@ func mainCat(cliCtx *cli.Context) error {
 
 	// Convert arguments to URLs: expand alias, fix format.
 	for _, url := range args {
-		fatalIf(catURL(ctx, url, versionID, rewind, encKeyDB).Trace(url), "Unable to read from `"+url+"`.")
+		fatalIf(catURL(ctx, url, versionID, rewind, encKeyDB, ranges, cliCtx.Bool("range-header")).Trace(url), "Unable to read from `"+url+"`.")
 	}
 
 	return nil
@@ -21,6 +21,7 @@ import (
 	"bytes"
 	"io"
 	"io/ioutil"
+	"reflect"
 	"testing"
 )
 
@@ -63,3 +64,37 @@ func TestPrettyStdout(t *testing.T) {
 		}
 	}
 }
+
+func TestParseCatRanges(t *testing.T) {
+	testCases := []struct {
+		ranges   string
+		expected []catRange
+		success  bool
+	}{
+		{"0-1023", []catRange{{0, 1023}}, true},
+		{"0-1023,4096-8191", []catRange{{0, 1023}, {4096, 8191}}, true},
+		{"10-10, 100-", []catRange{{10, 10}, {100, -1}}, true},
+		{"", nil, false},
+		{"-100", nil, false},
+		{"100", nil, false},
+		{"100-10", nil, false},
+		{"a-b", nil, false},
+		{"0-10,", nil, false},
+	}
+
+	for i, testCase := range testCases {
+		ranges, err := parseCatRanges(testCase.ranges)
+		if testCase.success && err != nil {
+			t.Fatalf("Test %d: unexpected error: %v", i+1, err)
+		}
+		if !testCase.success {
+			if err == nil {
+				t.Fatalf("Test %d: expected an error, found none", i+1)
+			}
+			continue
+		}
+		if !reflect.DeepEqual(ranges, testCase.expected) {
+			t.Fatalf("Test %d: expected %v, found %v", i+1, testCase.expected, ranges)
+		}
+	}
+}
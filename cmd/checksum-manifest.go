@@ -0,0 +1,100 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// checksumManifestFlags - flags for recording transferred objects, shared by cp and mirror.
+var checksumManifestFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "checksum-manifest",
+		Usage: "record key, size, SHA256 checksum and ETag of each transferred object to a file (see MANIFEST)",
+	},
+}
+
+// checksumManifestEntry - manifest record of a transferred object,
+// the manifest is a file of JSON lines, one line per object.
+type checksumManifestEntry struct {
+	Key    string `json:"key"`
+	Source string `json:"source"`
+	Size   int64  `json:"size"`
+	SHA256 string `json:"sha256,omitempty"`
+	ETag   string `json:"etag,omitempty"`
+}
+
+// checksumManifest appends an entry for each successful transfer.
+type checksumManifest struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// newChecksumManifest opens the manifest file at path, entries are
+// appended to an existing file.
+func newChecksumManifest(path string) (*checksumManifest, *probe.Error) {
+	file, e := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	return &checksumManifest{file: file}, nil
+}
+
+// add records a successfully transferred object, skipped and faked
+// objects are not recorded.
+func (m *checksumManifest) add(urls URLs) *probe.Error {
+	if m == nil || urls.Error != nil || urls.notTransferred || urls.SourceContent == nil || urls.TargetContent == nil {
+		return nil
+	}
+	entry := checksumManifestEntry{
+		Key:    filepath.ToSlash(filepath.Join(urls.TargetAlias, urls.TargetContent.URL.Path)),
+		Source: filepath.ToSlash(filepath.Join(urls.SourceAlias, urls.SourceContent.URL.Path)),
+		Size:   urls.SourceContent.Size,
+		SHA256: urls.checksum,
+		ETag:   urls.TargetContent.ETag,
+	}
+	entryBytes, e := json.Marshal(entry)
+	if e != nil {
+		return probe.NewError(e)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, e = m.file.Write(append(entryBytes, '\n')); e != nil {
+		return probe.NewError(e)
+	}
+	return nil
+}
+
+// close flushes and closes the manifest file.
+func (m *checksumManifest) close() *probe.Error {
+	if m == nil {
+		return nil
+	}
+	if e := m.file.Sync(); e != nil {
+		m.file.Close()
+		return probe.NewError(e)
+	}
+	return probe.NewError(m.file.Close())
+}
@@ -34,6 +34,28 @@ func (e APINotImplemented) Error() string {
 	return "`" + e.API + "` is not supported for `" + e.APIType + "`."
 }
 
+// VersionIDNotPreserved - target stored an object under a new version ID
+// instead of the requested source version ID.
+type VersionIDNotPreserved struct {
+	VersionID       string
+	TargetVersionID string
+}
+
+func (e VersionIDNotPreserved) Error() string {
+	return "Target stored version `" + e.TargetVersionID + "` instead of preserving version `" + e.VersionID + "`."
+}
+
+// IncompleteUploadNotRemoved - an incomplete upload holding Size bytes
+// could not be removed.
+type IncompleteUploadNotRemoved struct {
+	Size int64
+	Err  error
+}
+
+func (e IncompleteUploadNotRemoved) Error() string {
+	return e.Err.Error()
+}
+
 // GenericBucketError - generic bucket operations error
 type GenericBucketError struct {
 	Bucket string
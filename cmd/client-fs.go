@@ -375,8 +375,9 @@ func (f *fsClient) put(ctx context.Context, reader io.Reader, size int64, progre
 }
 
 // Put - create a new file with metadata.
-func (f *fsClient) Put(ctx context.Context, reader io.Reader, size int64, progress io.Reader, opts PutOptions) (int64, *probe.Error) {
-	return f.put(ctx, reader, size, progress, opts)
+func (f *fsClient) Put(ctx context.Context, reader io.Reader, size int64, progress io.Reader, opts PutOptions) (int64, string, *probe.Error) {
+	n, err := f.put(ctx, reader, size, progress, opts)
+	return n, "", err
 }
 
 // ShareDownload - share download not implemented for filesystem.
@@ -388,7 +389,7 @@ func (f *fsClient) ShareDownload(ctx context.Context, versionID string, expires
 }
 
 // ShareUpload - share upload not implemented for filesystem.
-func (f *fsClient) ShareUpload(ctx context.Context, startsWith bool, expires time.Duration, contentType string) (string, map[string]string, *probe.Error) {
+func (f *fsClient) ShareUpload(ctx context.Context, startsWith bool, expires time.Duration, contentType string, maxSize int64) (string, map[string]string, *probe.Error) {
 	return "", nil, probe.NewError(APINotImplemented{
 		API:     "ShareUpload",
 		APIType: "filesystem",
@@ -396,11 +397,11 @@ func (f *fsClient) ShareUpload(ctx context.Context, startsWith bool, expires tim
 }
 
 // Copy - copy data from source to destination
-func (f *fsClient) Copy(ctx context.Context, source string, opts CopyOptions, progress io.Reader) *probe.Error {
+func (f *fsClient) Copy(ctx context.Context, source string, opts CopyOptions, progress io.Reader) (string, *probe.Error) {
 	rc, e := os.Open(source)
 	if e != nil {
 		err := f.toClientError(e, source)
-		return err.Trace(source)
+		return "", err.Trace(source)
 	}
 	defer rc.Close()
 
@@ -411,9 +412,9 @@ func (f *fsClient) Copy(ctx context.Context, source string, opts CopyOptions, pr
 
 	destination := f.PathURL.Path
 	if _, err := f.put(ctx, rc, opts.size, progress, putOpts); err != nil {
-		return err.Trace(destination, source)
+		return "", err.Trace(destination, source)
 	}
-	return nil
+	return "", nil
 }
 
 // Get returns reader and any additional metadata.
@@ -602,7 +603,7 @@ func (f *fsClient) listPrefixes(prefix string, contentCh chan<- *ClientContent)
 		}
 
 		file := filepath.Join(dirName, fi.Name())
-		if fi.Mode()&os.ModeSymlink == os.ModeSymlink {
+		if globalFollowSymlink && fi.Mode()&os.ModeSymlink == os.ModeSymlink {
 			st, e := os.Stat(file)
 			if e != nil {
 				// Ignore any errors on symlink
@@ -669,7 +670,7 @@ func (f *fsClient) listInRoutine(contentCh chan<- *ClientContent, isMetadata boo
 		}
 		for _, file := range files {
 			fi := file
-			if fi.Mode()&os.ModeSymlink == os.ModeSymlink {
+			if globalFollowSymlink && fi.Mode()&os.ModeSymlink == os.ModeSymlink {
 				fp := filepath.Join(fpath, fi.Name())
 				fi, e = os.Stat(fp)
 				if e != nil {
@@ -677,7 +678,7 @@ func (f *fsClient) listInRoutine(contentCh chan<- *ClientContent, isMetadata boo
 					continue
 				}
 			}
-			if fi.Mode().IsRegular() || fi.Mode().IsDir() {
+			if fi.Mode().IsRegular() || fi.Mode().IsDir() || fi.Mode()&os.ModeSymlink == os.ModeSymlink {
 				pathURL = *f.PathURL
 				pathURL.Path = filepath.Join(pathURL.Path, fi.Name())
 
@@ -838,14 +839,14 @@ func (f *fsClient) listRecursiveInRoutine(contentCh chan *ClientContent, isMetad
 			}
 			return e
 		}
-		if fi.Mode()&os.ModeSymlink == os.ModeSymlink {
+		if globalFollowSymlink && fi.Mode()&os.ModeSymlink == os.ModeSymlink {
 			fi, e = os.Stat(fp)
 			if e != nil {
 				// Ignore any errors for symlink
 				return nil
 			}
 		}
-		if fi.Mode().IsRegular() {
+		if fi.Mode().IsRegular() || fi.Mode()&os.ModeSymlink == os.ModeSymlink {
 			contentCh <- &ClientContent{
 				URL:  *newClientURL(fp),
 				Time: fi.ModTime(),
@@ -1061,9 +1062,14 @@ func (f *fsClient) toClientError(e error, fpath string) *probe.Error {
 func (f *fsClient) fsStat(isIncomplete bool) (os.FileInfo, *probe.Error) {
 	fpath := f.PathURL.Path
 
+	stat := os.Stat
+	if !globalFollowSymlink {
+		stat = os.Lstat
+	}
+
 	// Check if the path corresponds to a directory and returns
 	// the successful result whether isIncomplete is specified or not.
-	st, e := os.Stat(fpath)
+	st, e := stat(fpath)
 	if e == nil && st.IsDir() {
 		return st, nil
 	}
@@ -1072,7 +1078,7 @@ func (f *fsClient) fsStat(isIncomplete bool) (os.FileInfo, *probe.Error) {
 		fpath += partSuffix
 	}
 
-	st, e = os.Stat(fpath)
+	st, e = stat(fpath)
 	if e != nil {
 		return nil, f.toClientError(e, fpath)
 	}
@@ -44,7 +44,7 @@ func (s *TestSuite) TestList(c *C) {
 
 	reader := bytes.NewReader([]byte(data))
 	var n int64
-	n, err = fsClient.Put(context.Background(), reader, int64(len(data)), nil, PutOptions{
+	n, _, err = fsClient.Put(context.Background(), reader, int64(len(data)), nil, PutOptions{
 		metadata: map[string]string{
 			"Content-Type": "application/octet-stream",
 		},
@@ -58,7 +58,7 @@ func (s *TestSuite) TestList(c *C) {
 	c.Assert(err, IsNil)
 
 	reader = bytes.NewReader([]byte(data))
-	n, err = fsClient.Put(context.Background(), reader, int64(len(data)), nil, PutOptions{
+	n, _, err = fsClient.Put(context.Background(), reader, int64(len(data)), nil, PutOptions{
 		metadata: map[string]string{
 			"Content-Type": "application/octet-stream",
 		},
@@ -88,7 +88,7 @@ func (s *TestSuite) TestList(c *C) {
 	c.Assert(err, IsNil)
 
 	reader = bytes.NewReader([]byte(data))
-	n, err = fsClient.Put(context.Background(), reader, int64(len(data)), nil, PutOptions{
+	n, _, err = fsClient.Put(context.Background(), reader, int64(len(data)), nil, PutOptions{
 		metadata: map[string]string{
 			"Content-Type": "application/octet-stream",
 		},
@@ -150,7 +150,7 @@ func (s *TestSuite) TestList(c *C) {
 	c.Assert(err, IsNil)
 
 	reader = bytes.NewReader([]byte(data))
-	n, err = fsClient.Put(context.Background(), reader, int64(len(data)), nil, PutOptions{
+	n, _, err = fsClient.Put(context.Background(), reader, int64(len(data)), nil, PutOptions{
 		metadata: map[string]string{
 			"Content-Type": "application/octet-stream",
 		},
@@ -259,7 +259,7 @@ func (s *TestSuite) TestPut(c *C) {
 	data := "hello"
 	reader := bytes.NewReader([]byte(data))
 	var n int64
-	n, err = fsClient.Put(context.Background(), reader, int64(len(data)), nil, PutOptions{
+	n, _, err = fsClient.Put(context.Background(), reader, int64(len(data)), nil, PutOptions{
 		metadata: map[string]string{
 			"Content-Type": "application/octet-stream",
 		},
@@ -283,7 +283,7 @@ func (s *TestSuite) TestGet(c *C) {
 	data := "hello"
 	var reader io.Reader
 	reader = bytes.NewReader([]byte(data))
-	n, err := fsClient.Put(context.Background(), reader, int64(len(data)), nil, PutOptions{
+	n, _, err := fsClient.Put(context.Background(), reader, int64(len(data)), nil, PutOptions{
 		metadata: map[string]string{
 			"Content-Type": "application/octet-stream",
 		}})
@@ -312,7 +312,7 @@ func (s *TestSuite) TestGetRange(c *C) {
 	data := "hello world"
 	var reader io.Reader
 	reader = bytes.NewReader([]byte(data))
-	n, err := fsClient.Put(context.Background(), reader, int64(len(data)), nil, PutOptions{
+	n, _, err := fsClient.Put(context.Background(), reader, int64(len(data)), nil, PutOptions{
 		metadata: map[string]string{
 			"Content-Type": "application/octet-stream",
 		},
@@ -345,7 +345,7 @@ func (s *TestSuite) TestStatObject(c *C) {
 	data := "hello"
 	dataLen := len(data)
 	reader := bytes.NewReader([]byte(data))
-	n, err := fsClient.Put(context.Background(), reader, int64(dataLen), nil, PutOptions{
+	n, _, err := fsClient.Put(context.Background(), reader, int64(dataLen), nil, PutOptions{
 		metadata: map[string]string{
 			"Content-Type": "application/octet-stream",
 		},
@@ -373,13 +373,13 @@ func (s *TestSuite) TestCopy(c *C) {
 
 	data := "hello world"
 	reader := bytes.NewReader([]byte(data))
-	n, err := fsClientSource.Put(context.Background(), reader, int64(len(data)), nil, PutOptions{
+	n, _, err := fsClientSource.Put(context.Background(), reader, int64(len(data)), nil, PutOptions{
 		metadata: map[string]string{
 			"Content-Type": "application/octet-stream",
 		},
 	})
 	c.Assert(err, IsNil)
 	c.Assert(n, Equals, int64(len(data)))
-	err = fsClientTarget.Copy(context.Background(), sourcePath, CopyOptions{size: int64(len(data))}, nil)
+	_, err = fsClientTarget.Copy(context.Background(), sourcePath, CopyOptions{size: int64(len(data))}, nil)
 	c.Assert(err, IsNil)
 }
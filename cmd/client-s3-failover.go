@@ -0,0 +1,176 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/minio/pkg/console"
+)
+
+// failoverBackoff is how long an endpoint that refused a connection
+// is skipped before being tried again.
+const failoverBackoff = 30 * time.Second
+
+// failoverTransport spreads requests across several endpoints of the
+// same deployment and moves on to the next endpoint when one cannot be
+// reached. Only the connection target changes: the Host header is left
+// untouched so that request signatures stay valid.
+type failoverTransport struct {
+	base      http.RoundTripper
+	endpoints []string
+	debug     bool
+
+	next      uint32
+	mu        sync.Mutex
+	downUntil map[string]time.Time
+}
+
+// newFailoverTransport returns a transport balancing over the given
+// endpoints, each being a host[:port] reachable with the same scheme.
+func newFailoverTransport(base http.RoundTripper, endpoints []string, debug bool) *failoverTransport {
+	return &failoverTransport{
+		base:      base,
+		endpoints: endpoints,
+		debug:     debug,
+		downUntil: make(map[string]time.Time),
+	}
+}
+
+// order returns the endpoints to try for the next request, rotating the
+// starting point for each call. Endpoints which recently failed are
+// moved to the end of the list.
+func (t *failoverTransport) order(now time.Time) []string {
+	n := len(t.endpoints)
+	start := int(atomic.AddUint32(&t.next, 1)-1) % n
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	healthy := make([]string, 0, n)
+	var down []string
+	for i := 0; i < n; i++ {
+		endpoint := t.endpoints[(start+i)%n]
+		if until, ok := t.downUntil[endpoint]; ok && now.Before(until) {
+			down = append(down, endpoint)
+			continue
+		}
+		healthy = append(healthy, endpoint)
+	}
+	return append(healthy, down...)
+}
+
+func (t *failoverTransport) markDown(endpoint string, now time.Time) {
+	t.mu.Lock()
+	t.downUntil[endpoint] = now.Add(failoverBackoff)
+	t.mu.Unlock()
+}
+
+func (t *failoverTransport) markUp(endpoint string) {
+	t.mu.Lock()
+	delete(t.downUntil, endpoint)
+	t.mu.Unlock()
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *failoverTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.Host
+	if host == "" {
+		host = req.URL.Host
+	}
+
+	endpoints := t.order(time.Now())
+	var body *failoverBody
+	if req.Body != nil && req.Body != http.NoBody {
+		body = &failoverBody{ReadCloser: req.Body}
+	}
+
+	var lastErr error
+	for i, endpoint := range endpoints {
+		r := req.Clone(req.Context())
+		r.Host = host
+		r.URL.Host = endpoint
+		if body != nil {
+			r.Body = body
+		}
+
+		resp, err := t.base.RoundTrip(r)
+		if err == nil {
+			t.markUp(endpoint)
+			if t.debug {
+				console.Debugln("Request served by endpoint " + endpoint)
+			}
+			return resp, nil
+		}
+		lastErr = err
+
+		// Only retry when nothing was sent, otherwise the request
+		// body cannot be replayed.
+		if !isConnectionFailure(err) || (body != nil && body.read) {
+			break
+		}
+		t.markDown(endpoint, time.Now())
+		if t.debug && i < len(endpoints)-1 {
+			console.Debugln("Endpoint " + endpoint + " unreachable, trying next endpoint: " + err.Error())
+		}
+	}
+	if body != nil && !body.read {
+		body.ReadCloser.Close()
+	}
+	return nil, lastErr
+}
+
+// failoverBody wraps a request body so that the underlying transport
+// closing it after a failed connection does not prevent replaying it
+// against another endpoint.
+type failoverBody struct {
+	io.ReadCloser
+	read bool
+}
+
+func (b *failoverBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if n > 0 {
+		b.read = true
+	}
+	return n, err
+}
+
+func (b *failoverBody) Close() error {
+	if b.read {
+		return b.ReadCloser.Close()
+	}
+	return nil
+}
+
+// isConnectionFailure returns true when err shows that the endpoint
+// could not be reached at all.
+func isConnectionFailure(err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) && opErr.Op == "dial" {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNREFUSED)
+}
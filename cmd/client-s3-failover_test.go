@@ -0,0 +1,91 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"errors"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type failoverTestTransport struct {
+	down  map[string]bool
+	hosts []string
+}
+
+func (t *failoverTestTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	t.hosts = append(t.hosts, req.URL.Host+"|"+req.Host)
+	if req.Body != nil {
+		defer req.Body.Close()
+	}
+	if t.down[req.URL.Host] {
+		return nil, &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	}
+	if req.Body != nil {
+		if _, err := ioutil.ReadAll(req.Body); err != nil {
+			return nil, err
+		}
+	}
+	return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody}, nil
+}
+
+func TestFailoverTransport(t *testing.T) {
+	base := &failoverTestTransport{down: map[string]bool{"node2:9000": true}}
+	tr := newFailoverTransport(base, []string{"node1:9000", "node2:9000", "node3:9000"}, false)
+
+	for i := 0; i < 4; i++ {
+		req, err := http.NewRequest(http.MethodPut, "http://node1:9000/bucket/object", ioutil.NopCloser(strings.NewReader("data")))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = tr.RoundTrip(req); err != nil {
+			t.Fatalf("Test %d: unexpected error: %v", i+1, err)
+		}
+	}
+
+	expected := []string{
+		"node1:9000|node1:9000",
+		// node2 fails and is skipped until its backoff expires.
+		"node2:9000|node1:9000",
+		"node3:9000|node1:9000",
+		"node3:9000|node1:9000",
+		"node1:9000|node1:9000",
+	}
+	if strings.Join(base.hosts, " ") != strings.Join(expected, " ") {
+		t.Fatalf("expected %v, got %v", expected, base.hosts)
+	}
+}
+
+func TestFailoverTransportAllDown(t *testing.T) {
+	base := &failoverTestTransport{down: map[string]bool{"node1:9000": true, "node2:9000": true}}
+	tr := newFailoverTransport(base, []string{"node1:9000", "node2:9000"}, false)
+
+	req, err := http.NewRequest(http.MethodGet, "http://node1:9000/bucket/object", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = tr.RoundTrip(req); !isConnectionFailure(err) {
+		t.Fatalf("expected connection failure, got %v", err)
+	}
+	if len(base.hosts) != 2 {
+		t.Fatalf("expected both endpoints to be tried, got %v", base.hosts)
+	}
+}
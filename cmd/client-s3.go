@@ -122,7 +122,7 @@ func newFactory() func(config *Config) (Client, *probe.Error) {
 		}
 		// Generate a hash out of s3Conf.
 		confHash := fnv.New32a()
-		confHash.Write([]byte(hostName + config.AccessKey + config.SecretKey + config.SessionToken))
+		confHash.Write([]byte(hostName + config.AccessKey + config.SecretKey + config.SessionToken + config.Region + strings.Join(config.Endpoints, ",")))
 		confSum := confHash.Sum32()
 
 		// Lookup previous cache by hash.
@@ -187,6 +187,14 @@ func newFactory() func(config *Config) (Client, *probe.Error) {
 				transport = tr
 			}
 
+			if len(config.Endpoints) > 0 {
+				endpoints := []string{targetURL.Host}
+				for _, endpoint := range config.Endpoints {
+					endpoints = append(endpoints, newClientURL(endpoint).Host)
+				}
+				transport = newFailoverTransport(transport, endpoints, config.Debug)
+			}
+
 			if config.Debug {
 				if strings.EqualFold(config.Signature, "S3v4") {
 					transport = httptracer.GetNewTraceTransport(newTraceV4(), transport)
@@ -198,10 +206,16 @@ func newFactory() func(config *Config) (Client, *probe.Error) {
 			// Not found. Instantiate a new MinIO
 			var e error
 
+			// When no region is configured, minio-go detects the
+			// region of each bucket on first use and caches it.
+			region := config.Region
+			if region == "" {
+				region = os.Getenv("MC_REGION")
+			}
 			options := minio.Options{
 				Creds:        creds,
 				Secure:       useTLS,
-				Region:       os.Getenv("MC_REGION"),
+				Region:       region,
 				BucketLookup: config.Lookup,
 				Transport:    transport,
 			}
@@ -828,10 +842,10 @@ func (c *S3Client) Get(ctx context.Context, opts GetOptions) (io.ReadCloser, *pr
 // Copy - copy object, uses server side copy API. Also uses an abstracted API
 // such that large file sizes will be copied in multipart manner on server
 // side.
-func (c *S3Client) Copy(ctx context.Context, source string, opts CopyOptions, progress io.Reader) *probe.Error {
+func (c *S3Client) Copy(ctx context.Context, source string, opts CopyOptions, progress io.Reader) (string, *probe.Error) {
 	dstBucket, dstObject := c.url2BucketAndObject()
 	if dstBucket == "" {
-		return probe.NewError(BucketNameEmpty{})
+		return "", probe.NewError(BucketNameEmpty{})
 	}
 
 	metadata := make(map[string]string, len(opts.metadata))
@@ -881,45 +895,46 @@ func (c *S3Client) Copy(ctx context.Context, source string, opts CopyOptions, pr
 
 	// Assign metadata after irrelevant parts are delete above
 	destOpts.UserMetadata = metadata
-	destOpts.ReplaceMetadata = len(metadata) > 0
+	destOpts.ReplaceMetadata = len(metadata) > 0 || opts.replaceMetadata
 
+	var ui minio.UploadInfo
 	var e error
 	if opts.disableMultipart || opts.size < 64*1024*1024 {
-		_, e = c.api.CopyObject(ctx, destOpts, srcOpts)
+		ui, e = c.api.CopyObject(ctx, destOpts, srcOpts)
 	} else {
-		_, e = c.api.ComposeObject(ctx, destOpts, srcOpts)
+		ui, e = c.api.ComposeObject(ctx, destOpts, srcOpts)
 	}
 
 	if e != nil {
 		errResponse := minio.ToErrorResponse(e)
 		if errResponse.Code == "AccessDenied" {
-			return probe.NewError(PathInsufficientPermission{
+			return "", probe.NewError(PathInsufficientPermission{
 				Path: c.targetURL.String(),
 			})
 		}
 		if errResponse.Code == "NoSuchBucket" {
-			return probe.NewError(BucketDoesNotExist{
+			return "", probe.NewError(BucketDoesNotExist{
 				Bucket: dstBucket,
 			})
 		}
 		if errResponse.Code == "InvalidBucketName" {
-			return probe.NewError(BucketInvalid{
+			return "", probe.NewError(BucketInvalid{
 				Bucket: dstBucket,
 			})
 		}
 		if errResponse.Code == "NoSuchKey" {
-			return probe.NewError(ObjectMissing{})
+			return "", probe.NewError(ObjectMissing{})
 		}
-		return probe.NewError(e)
+		return "", probe.NewError(e)
 	}
-	return nil
+	return ui.VersionID, nil
 }
 
 // Put - upload an object with custom metadata.
-func (c *S3Client) Put(ctx context.Context, reader io.Reader, size int64, progress io.Reader, putOpts PutOptions) (int64, *probe.Error) {
+func (c *S3Client) Put(ctx context.Context, reader io.Reader, size int64, progress io.Reader, putOpts PutOptions) (int64, string, *probe.Error) {
 	bucket, object := c.url2BucketAndObject()
 	if bucket == "" {
-		return 0, probe.NewError(BucketNameEmpty{})
+		return 0, "", probe.NewError(BucketNameEmpty{})
 	}
 
 	metadata := make(map[string]string, len(putOpts.metadata))
@@ -963,7 +978,7 @@ func (c *S3Client) Put(ctx context.Context, reader io.Reader, size int64, progre
 	if ok {
 		tagsSet, e := tags.Parse(tagsHdr, true)
 		if e != nil {
-			return 0, probe.NewError(e)
+			return 0, "", probe.NewError(e)
 		}
 		tagsMap = tagsSet.ToMap()
 		delete(metadata, "X-Amz-Tagging")
@@ -999,6 +1014,13 @@ func (c *S3Client) Put(ctx context.Context, reader io.Reader, size int64, progre
 		ServerSideEncryption: putOpts.sse,
 		SendContentMd5:       putOpts.md5,
 		DisableMultipart:     putOpts.disableMultipart,
+		PartSize:             putOpts.partSize,
+	}
+
+	if putOpts.sourceVersionID != "" {
+		// Only single part uploads can specify their version ID.
+		opts.Internal.SourceVersionID = putOpts.sourceVersionID
+		opts.DisableMultipart = true
 	}
 
 	if !retainUntilDate.IsZero() && !retainUntilDate.Equal(timeSentinel) {
@@ -1020,42 +1042,48 @@ func (c *S3Client) Put(ctx context.Context, reader io.Reader, size int64, progre
 	if e != nil {
 		errResponse := minio.ToErrorResponse(e)
 		if errResponse.Code == "UnexpectedEOF" || e == io.EOF {
-			return ui.Size, probe.NewError(UnexpectedEOF{
+			return ui.Size, ui.VersionID, probe.NewError(UnexpectedEOF{
 				TotalSize:    size,
 				TotalWritten: ui.Size,
 			})
 		}
 		if errResponse.Code == "AccessDenied" {
-			return ui.Size, probe.NewError(PathInsufficientPermission{
+			return ui.Size, ui.VersionID, probe.NewError(PathInsufficientPermission{
 				Path: c.targetURL.String(),
 			})
 		}
 		if errResponse.Code == "MethodNotAllowed" {
-			return ui.Size, probe.NewError(ObjectAlreadyExists{
+			return ui.Size, ui.VersionID, probe.NewError(ObjectAlreadyExists{
 				Object: object,
 			})
 		}
 		if errResponse.Code == "XMinioObjectExistsAsDirectory" {
-			return ui.Size, probe.NewError(ObjectAlreadyExistsAsDirectory{
+			return ui.Size, ui.VersionID, probe.NewError(ObjectAlreadyExistsAsDirectory{
 				Object: object,
 			})
 		}
 		if errResponse.Code == "NoSuchBucket" {
-			return ui.Size, probe.NewError(BucketDoesNotExist{
+			return ui.Size, ui.VersionID, probe.NewError(BucketDoesNotExist{
 				Bucket: bucket,
 			})
 		}
 		if errResponse.Code == "InvalidBucketName" {
-			return ui.Size, probe.NewError(BucketInvalid{
+			return ui.Size, ui.VersionID, probe.NewError(BucketInvalid{
 				Bucket: bucket,
 			})
 		}
 		if errResponse.Code == "NoSuchKey" {
-			return ui.Size, probe.NewError(ObjectMissing{})
+			return ui.Size, ui.VersionID, probe.NewError(ObjectMissing{})
 		}
-		return ui.Size, probe.NewError(e)
+		return ui.Size, ui.VersionID, probe.NewError(e)
 	}
-	return ui.Size, nil
+	if putOpts.sourceVersionID != "" && ui.VersionID != putOpts.sourceVersionID {
+		return ui.Size, ui.VersionID, probe.NewError(VersionIDNotPreserved{
+			VersionID:       putOpts.sourceVersionID,
+			TargetVersionID: ui.VersionID,
+		})
+	}
+	return ui.Size, ui.VersionID, nil
 }
 
 // Remove incomplete uploads.
@@ -1068,7 +1096,7 @@ func (c *S3Client) removeIncompleteObjects(ctx context.Context, bucket string, o
 
 		for info := range objectsCh {
 			if err := c.api.RemoveIncompleteUpload(ctx, bucket, info.Key); err != nil {
-				removeObjectErrorCh <- minio.RemoveObjectError{ObjectName: info.Key, Err: err}
+				removeObjectErrorCh <- minio.RemoveObjectError{ObjectName: info.Key, Err: IncompleteUploadNotRemoved{Size: info.Size, Err: err}}
 			}
 		}
 	}()
@@ -1164,7 +1192,7 @@ func (c *S3Client) Remove(ctx context.Context, isIncomplete, isRemoveBucket, isB
 					sent := false
 					for !sent {
 						select {
-						case objectsCh <- minio.ObjectInfo{Key: objectName, VersionID: objectVersionID}:
+						case objectsCh <- minio.ObjectInfo{Key: objectName, VersionID: objectVersionID, Size: content.Size}:
 							sent = true
 						case removeStatus := <-statusCh:
 							errorCh <- probe.NewError(removeStatus.Err)
@@ -1192,10 +1220,12 @@ func (c *S3Client) Remove(ctx context.Context, isIncomplete, isRemoveBucket, isB
 				// "Object is WORM protected and cannot be overwritten",
 				// it is too generic. We have the object's name and vid.
 				// Adding the object's name and version id into the error msg
-				removeStatus.Err = errors.New(strings.Replace(
-					removeStatus.Err.Error(), "Object is WORM protected",
-					"Object, '"+removeStatus.ObjectName+" (Version ID="+
-						removeStatus.VersionID+")' is WORM protected", 1))
+				if !isIncomplete {
+					removeStatus.Err = errors.New(strings.Replace(
+						removeStatus.Err.Error(), "Object is WORM protected",
+						"Object, '"+removeStatus.ObjectName+" (Version ID="+
+							removeStatus.VersionID+")' is WORM protected", 1))
+				}
 				errorCh <- probe.NewError(removeStatus.Err)
 			}
 		}
@@ -1366,6 +1396,27 @@ func (c *S3Client) listObjectWrapper(ctx context.Context, bucket, object string,
 	return c.api.ListObjects(ctx, bucket, minio.ListObjectsOptions{Prefix: object, Recursive: isRecursive, WithMetadata: metadata, MaxKeys: maxKeys})
 }
 
+// incompleteUploadSize - returns the size of the parts uploaded so far,
+// the listing of incomplete uploads does not report it.
+func (c *S3Client) incompleteUploadSize(ctx context.Context, bucket, object, uploadID string) int64 {
+	core := minio.Core{Client: c.api}
+	var size int64
+	var partNumberMarker int
+	for {
+		result, e := core.ListObjectParts(ctx, bucket, object, uploadID, partNumberMarker, 1000)
+		if e != nil {
+			return size
+		}
+		for _, part := range result.ObjectParts {
+			size += part.Size
+		}
+		if !result.IsTruncated {
+			return size
+		}
+		partNumberMarker = result.NextPartNumberMarker
+	}
+}
+
 func (c *S3Client) statIncompleteUpload(ctx context.Context, bucket, object string) (*ClientContent, *probe.Error) {
 	nonRecursive := false
 	objectMetadata := &ClientContent{}
@@ -1380,7 +1431,7 @@ func (c *S3Client) statIncompleteUpload(ctx context.Context, bucket, object stri
 		if objectMultipartInfo.Key == object {
 			objectMetadata.URL = c.targetURL.Clone()
 			objectMetadata.Time = objectMultipartInfo.Initiated
-			objectMetadata.Size = objectMultipartInfo.Size
+			objectMetadata.Size = c.incompleteUploadSize(ctx, bucket, object, objectMultipartInfo.UploadID)
 			objectMetadata.Type = os.FileMode(0664)
 			objectMetadata.Metadata = map[string]string{}
 			return objectMetadata, nil
@@ -1640,7 +1691,7 @@ func (c *S3Client) listVersionsRoutine(ctx context.Context, b, o string, isRecur
 				continue
 			}
 
-			if objectVersion.LastModified.Before(timeRef) {
+			if !objectVersion.LastModified.After(timeRef) {
 				skipKey = objectVersion.Key
 
 				// Skip if this is a delete marker and we are not asked to list it
@@ -1783,7 +1834,7 @@ func (c *S3Client) listIncompleteInRoutine(ctx context.Context, contentCh chan *
 					content.Type = os.ModeDir
 				default:
 					content.URL = url
-					content.Size = object.Size
+					content.Size = c.incompleteUploadSize(ctx, bucket.Name, object.Key, object.UploadID)
 					content.Time = object.Initiated
 					content.Type = os.ModeTemporary
 				}
@@ -1811,7 +1862,7 @@ func (c *S3Client) listIncompleteInRoutine(ctx context.Context, contentCh chan *
 				content.Type = os.ModeDir
 			default:
 				content.URL = url
-				content.Size = object.Size
+				content.Size = c.incompleteUploadSize(ctx, b, object.Key, object.UploadID)
 				content.Time = object.Initiated
 				content.Type = os.ModeTemporary
 			}
@@ -1849,7 +1900,7 @@ func (c *S3Client) listIncompleteRecursiveInRoutine(ctx context.Context, content
 				url.Path = c.joinPath(bucket.Name, object.Key)
 				content := &ClientContent{}
 				content.URL = url
-				content.Size = object.Size
+				content.Size = c.incompleteUploadSize(ctx, bucket.Name, object.Key, object.UploadID)
 				content.Time = object.Initiated
 				content.Type = os.ModeTemporary
 				contentCh <- content
@@ -1873,7 +1924,7 @@ func (c *S3Client) listIncompleteRecursiveInRoutine(ctx context.Context, content
 			url.Path = c.joinPath(b, object.Key)
 			content := &ClientContent{}
 			content.URL = url
-			content.Size = object.Size
+			content.Size = c.incompleteUploadSize(ctx, b, object.Key, object.UploadID)
 			content.Time = object.Initiated
 			content.Type = os.ModeTemporary
 			contentCh <- content
@@ -2107,7 +2158,7 @@ func (c *S3Client) ShareDownload(ctx context.Context, versionID string, expires
 }
 
 // ShareUpload - get data for presigned post http form upload.
-func (c *S3Client) ShareUpload(ctx context.Context, isRecursive bool, expires time.Duration, contentType string) (string, map[string]string, *probe.Error) {
+func (c *S3Client) ShareUpload(ctx context.Context, isRecursive bool, expires time.Duration, contentType string, maxSize int64) (string, map[string]string, *probe.Error) {
 	bucket, object := c.url2BucketAndObject()
 	p := minio.NewPostPolicy()
 	if e := p.SetExpires(UTCNow().Add(expires)); e != nil {
@@ -2115,7 +2166,17 @@ func (c *S3Client) ShareUpload(ctx context.Context, isRecursive bool, expires ti
 	}
 	if strings.TrimSpace(contentType) != "" || contentType != "" {
 		// No need to verify for error here, since we have stripped out spaces.
-		p.SetContentType(contentType)
+		if strings.HasSuffix(contentType, "/*") {
+			// Wildcard subtypes such as "image/*" match on the type prefix.
+			p.SetContentTypeStartsWith(strings.TrimSuffix(contentType, "*"))
+		} else {
+			p.SetContentType(contentType)
+		}
+	}
+	if maxSize > 0 {
+		if e := p.SetContentLengthRange(0, maxSize); e != nil {
+			return "", nil, probe.NewError(e)
+		}
 	}
 	if e := p.SetBucket(bucket); e != nil {
 		return "", nil, probe.NewError(e)
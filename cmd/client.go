@@ -59,6 +59,8 @@ type PutOptions struct {
 	md5, disableMultipart bool
 	isPreserve            bool
 	storageClass          string
+	partSize              uint64
+	sourceVersionID       string
 }
 
 // StatOptions holds options of the HEAD operation
@@ -91,6 +93,9 @@ type CopyOptions struct {
 	disableMultipart bool
 	isPreserve       bool
 	storageClass     string
+	// replaceMetadata replaces the metadata of the source even when
+	// metadata is empty, e.g. once all of it is excluded.
+	replaceMetadata bool
 }
 
 // Client - client interface
@@ -112,7 +117,7 @@ type Client interface {
 	SetAccess(ctx context.Context, access string, isJSON bool) *probe.Error
 
 	// I/O operations
-	Copy(ctx context.Context, source string, opts CopyOptions, progress io.Reader) *probe.Error
+	Copy(ctx context.Context, source string, opts CopyOptions, progress io.Reader) (versionID string, err *probe.Error)
 
 	// Runs select expression on object storage on specific files.
 	Select(ctx context.Context, expression string, sse encrypt.ServerSide, opts SelectObjectOpts) (io.ReadCloser, *probe.Error)
@@ -120,7 +125,7 @@ type Client interface {
 	// I/O operations with metadata.
 	Get(ctx context.Context, opts GetOptions) (reader io.ReadCloser, err *probe.Error)
 
-	Put(ctx context.Context, reader io.Reader, size int64, progress io.Reader, opts PutOptions) (n int64, err *probe.Error)
+	Put(ctx context.Context, reader io.Reader, size int64, progress io.Reader, opts PutOptions) (n int64, versionID string, err *probe.Error)
 
 	// Object Locking related API
 	PutObjectRetention(ctx context.Context, versionID string, mode minio.RetentionMode, retainUntilDate time.Time, bypassGovernance bool) *probe.Error
@@ -130,7 +135,7 @@ type Client interface {
 
 	// I/O operations with expiration
 	ShareDownload(ctx context.Context, versionID string, expires time.Duration) (string, *probe.Error)
-	ShareUpload(context.Context, bool, time.Duration, string) (string, map[string]string, *probe.Error)
+	ShareUpload(context.Context, bool, time.Duration, string, int64) (string, map[string]string, *probe.Error)
 
 	// Watch events
 	Watch(ctx context.Context, options WatchOptions) (*WatchObject, *probe.Error)
@@ -207,6 +212,8 @@ type Config struct {
 	Debug        bool
 	Insecure     bool
 	Lookup       minio.BucketLookupType
+	Region       string
+	Endpoints    []string
 	Transport    *http.Transport
 }
 
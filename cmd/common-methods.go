@@ -19,8 +19,11 @@ package cmd
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"errors"
+	"hash"
 	"io"
 	"net/http"
 	"os"
@@ -29,6 +32,7 @@ import (
 	"strings"
 	"time"
 
+	humanize "github.com/dustin/go-humanize"
 	"golang.org/x/net/http/httpguts"
 	"gopkg.in/h2non/filetype.v1"
 
@@ -36,6 +40,16 @@ import (
 	"github.com/minio/mc/pkg/probe"
 	minio "github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/encrypt"
+	"github.com/minio/pkg/wildcard"
+)
+
+const (
+	// nullVersionID is the version ID of objects written while
+	// versioning was not enabled.
+	nullVersionID = "null"
+
+	// maxSinglePutSize is the largest object a single PUT can upload.
+	maxSinglePutSize = 5 * humanize.GiByte
 )
 
 // decode if the key is encoded key and returns the key
@@ -304,11 +318,12 @@ func putTargetRetention(ctx context.Context, alias string, urlStr string, metada
 	return nil
 }
 
-// putTargetStream writes to URL from Reader.
-func putTargetStream(ctx context.Context, alias, urlStr, mode, until, legalHold string, reader io.Reader, size int64, progress io.Reader, opts PutOptions) (int64, *probe.Error) {
+// putTargetStream writes to URL from Reader, it returns the version ID
+// of the uploaded object on versioned targets.
+func putTargetStream(ctx context.Context, alias, urlStr, mode, until, legalHold string, reader io.Reader, size int64, progress io.Reader, opts PutOptions) (int64, string, *probe.Error) {
 	targetClnt, err := newClientFromAlias(alias, urlStr)
 	if err != nil {
-		return 0, err.Trace(alias, urlStr)
+		return 0, "", err.Trace(alias, urlStr)
 	}
 
 	if mode != "" {
@@ -321,11 +336,11 @@ func putTargetStream(ctx context.Context, alias, urlStr, mode, until, legalHold
 		opts.metadata[AmzObjectLockLegalHold] = legalHold
 	}
 
-	n, err := targetClnt.Put(ctx, reader, size, progress, opts)
+	n, versionID, err := targetClnt.Put(ctx, reader, size, progress, opts)
 	if err != nil {
-		return n, err.Trace(alias, urlStr)
+		return n, versionID, err.Trace(alias, urlStr)
 	}
-	return n, nil
+	return n, versionID, nil
 }
 
 // putTargetStreamWithURL writes to URL from reader. If length=-1, read until EOF.
@@ -334,20 +349,31 @@ func putTargetStreamWithURL(urlStr string, reader io.Reader, size int64, opts Pu
 	if err != nil {
 		return 0, err.Trace(alias, urlStr)
 	}
-	contentType := guessURLContentType(urlStr)
 	if opts.metadata == nil {
 		opts.metadata = map[string]string{}
 	}
-	opts.metadata["Content-Type"] = contentType
-	return putTargetStream(context.Background(), alias, urlStrFull, "", "", "", reader, size, nil, opts)
+	// Keep the content-type passed by the caller, under the key the
+	// client expects.
+	for k, v := range opts.metadata {
+		if k != "Content-Type" && strings.EqualFold(k, "Content-Type") {
+			delete(opts.metadata, k)
+			opts.metadata["Content-Type"] = v
+		}
+	}
+	if !hasContentType(opts.metadata) {
+		opts.metadata["Content-Type"] = guessURLContentType(urlStr)
+	}
+	n, _, err := putTargetStream(context.Background(), alias, urlStrFull, "", "", "", reader, size, nil, opts)
+	return n, err
 }
 
-// copySourceToTargetURL copies to targetURL from source.
-func copySourceToTargetURL(ctx context.Context, alias, urlStr, source, sourceVersionID, mode, until, legalHold string, size int64, progress io.Reader, opts CopyOptions) *probe.Error {
+// copySourceToTargetURL copies to targetURL from source, it returns the
+// version ID of the copy on versioned targets.
+func copySourceToTargetURL(ctx context.Context, alias, urlStr, source, sourceVersionID, mode, until, legalHold string, size int64, progress io.Reader, opts CopyOptions) (string, *probe.Error) {
 
 	targetClnt, err := newClientFromAlias(alias, urlStr)
 	if err != nil {
-		return err.Trace(alias, urlStr)
+		return "", err.Trace(alias, urlStr)
 	}
 
 	opts.versionID = sourceVersionID
@@ -356,11 +382,11 @@ func copySourceToTargetURL(ctx context.Context, alias, urlStr, source, sourceVer
 	opts.metadata[AmzObjectLockRetainUntilDate] = until
 	opts.metadata[AmzObjectLockLegalHold] = legalHold
 
-	err = targetClnt.Copy(ctx, source, opts, progress)
+	versionID, err := targetClnt.Copy(ctx, source, opts, progress)
 	if err != nil {
-		return err.Trace(alias, urlStr)
+		return "", err.Trace(alias, urlStr)
 	}
-	return nil
+	return versionID, nil
 }
 
 func filterMetadata(metadata map[string]string) map[string]string {
@@ -378,6 +404,25 @@ func filterMetadata(metadata map[string]string) map[string]string {
 	return newMetadata
 }
 
+// excludeMetadata - removes the metadata matching any of the patterns when
+// the metadata of the source is preserved, the header names are matched case
+// insensitively, e.g. 'x-amz-meta-internal-*'. It reports whether metadata
+// was excluded, a server side copy then replaces the metadata of the source.
+func excludeMetadata(metadata map[string]string, patterns []string, preserve bool) bool {
+	if !preserve || len(patterns) == 0 {
+		return false
+	}
+	for k := range metadata {
+		for _, pattern := range patterns {
+			if wildcard.Match(strings.ToLower(pattern), strings.ToLower(k)) {
+				delete(metadata, k)
+				break
+			}
+		}
+	}
+	return true
+}
+
 // getAllMetadata - returns a map of user defined function
 // by combining the usermetadata of object and values passed by attr keyword
 func getAllMetadata(ctx context.Context, sourceAlias, sourceURLStr string, srcSSE encrypt.ServerSide, urls URLs) (map[string]string, *probe.Error) {
@@ -420,6 +465,7 @@ func uploadSourceToTargetURL(ctx context.Context, urls URLs, progress io.Reader,
 	tgtSSE := getSSE(targetPath, encKeyDB[targetAlias])
 
 	var err *probe.Error
+	var targetVersionID string
 	var metadata = map[string]string{}
 	var mode, until, legalHold string
 
@@ -467,8 +513,8 @@ func uploadSourceToTargetURL(ctx context.Context, urls URLs, progress io.Reader,
 		metadata[http.CanonicalHeaderKey(k)] = v
 	}
 
-	// Optimize for server side copy if the host is same.
-	if sourceAlias == targetAlias {
+	// Optimize for server side copy if the target can reach the source.
+	if canServerSideCopy(sourceAlias, targetAlias) {
 		// preserve new metadata and save existing ones.
 		if preserve {
 			currentMetadata, err := getAllMetadata(ctx, sourceAlias, sourceURL.String(), srcSSE, urls)
@@ -479,6 +525,7 @@ func uploadSourceToTargetURL(ctx context.Context, urls URLs, progress io.Reader,
 				metadata[k] = v
 			}
 		}
+		replaceMetadata := excludeMetadata(metadata, urls.metadataExclude, preserve)
 
 		// Get metadata from target content as well
 		for k, v := range urls.TargetContent.Metadata {
@@ -500,12 +547,13 @@ func uploadSourceToTargetURL(ctx context.Context, urls URLs, progress io.Reader,
 			srcSSE:           srcSSE,
 			tgtSSE:           tgtSSE,
 			metadata:         filterMetadata(metadata),
+			replaceMetadata:  replaceMetadata,
 			disableMultipart: urls.DisableMultipart,
 			isPreserve:       preserve,
 			storageClass:     urls.TargetContent.StorageClass,
 		}
 
-		err = copySourceToTargetURL(ctx, targetAlias, targetURL.String(), sourcePath, sourceVersion, mode, until,
+		targetVersionID, err = copySourceToTargetURL(ctx, targetAlias, targetURL.String(), sourcePath, sourceVersion, mode, until,
 			legalHold, length, progress, opts)
 	} else {
 		if urls.SourceContent.RetentionEnabled {
@@ -541,6 +589,7 @@ func uploadSourceToTargetURL(ctx context.Context, urls URLs, progress io.Reader,
 			return urls.WithError(err.Trace(sourceURL.String()))
 		}
 		defer reader.Close()
+		excludeMetadata(metadata, urls.metadataExclude, preserve)
 
 		// Get metadata from target content as well
 		for k, v := range urls.TargetContent.Metadata {
@@ -560,18 +609,53 @@ func uploadSourceToTargetURL(ctx context.Context, urls URLs, progress io.Reader,
 			disableMultipart: urls.DisableMultipart,
 			isPreserve:       preserve,
 		}
+		if urls.AdaptivePartSize {
+			putOpts.partSize = adaptivePartSize(length)
+		}
+		if versionID := urls.SourceContent.VersionID; urls.PreserveVersionID && versionID != "" && versionID != nullVersionID {
+			if length > maxSinglePutSize {
+				errorIf(errDummy().Trace(sourceURL.String()), "Unable to preserve version `%s` of `%s`, objects larger than %s cannot specify their version ID.",
+					versionID, sourceURL.String(), humanize.IBytes(maxSinglePutSize))
+			} else {
+				putOpts.sourceVersionID = versionID
+			}
+		}
 
-		if isReadAt(reader) {
-			_, err = putTargetStream(ctx, targetAlias, targetURL.String(), mode, until,
+		var hasher hash.Hash
+		if urls.withChecksum {
+			// Hashing requires the data to be read sequentially.
+			hasher = sha256.New()
+			_, targetVersionID, err = putTargetStream(ctx, targetAlias, targetURL.String(), mode, until,
+				legalHold, io.TeeReader(io.LimitReader(reader, length), hasher), length, progress, putOpts)
+		} else if isReadAt(reader) {
+			_, targetVersionID, err = putTargetStream(ctx, targetAlias, targetURL.String(), mode, until,
 				legalHold, reader, length, progress, putOpts)
 		} else {
-			_, err = putTargetStream(ctx, targetAlias, targetURL.String(), mode, until,
+			_, targetVersionID, err = putTargetStream(ctx, targetAlias, targetURL.String(), mode, until,
 				legalHold, io.LimitReader(reader, length), length, progress, putOpts)
 		}
+		if notPreserved, ok := err.ToGoError().(VersionIDNotPreserved); ok {
+			// The target does not support it, keep its new version.
+			errorIf(err.Trace(sourceURL.String()), "Unable to preserve version `%s` of `%s`.", notPreserved.VersionID, sourceURL.String())
+			err = nil
+		}
+		if err == nil && hasher != nil {
+			urls.checksum = hex.EncodeToString(hasher.Sum(nil))
+		}
 	}
 	if err != nil {
 		return urls.WithError(err.Trace(sourceURL.String()))
 	}
+	urls.targetVersionID = targetVersionID
+
+	if urls.withChecksum {
+		// Record the ETag of the target as stored.
+		if targetClnt, err := newClientFromAlias(targetAlias, targetURL.String()); err == nil {
+			if st, err := targetClnt.Stat(ctx, StatOptions{sse: tgtSSE}); err == nil {
+				urls.TargetContent.ETag = st.ETag
+			}
+		}
+	}
 
 	return urls.WithError(nil)
 }
@@ -595,6 +679,12 @@ func newClientFromAlias(alias, urlStr string) (Client, *probe.Error) {
 		return fsClient, nil
 	}
 
+	if region, ok := aliasRegions[alias]; ok {
+		aliasCfg := *hostCfg
+		aliasCfg.Region = region
+		hostCfg = &aliasCfg
+	}
+
 	s3Config := NewS3Config(urlStr, hostCfg)
 
 	s3Client, err := S3New(s3Config)
@@ -20,6 +20,7 @@ package cmd
 import (
 	"errors"
 	"reflect"
+	"sort"
 	"testing"
 )
 
@@ -69,3 +70,46 @@ func TestGetDecodedKey(t *testing.T) {
 		}
 	}
 }
+
+func TestExcludeMetadata(t *testing.T) {
+	metadata := func() map[string]string {
+		return map[string]string{
+			"Content-Type":             "image/png",
+			"Cache-Control":            "max-age=3600",
+			"X-Amz-Meta-Internal-Id":   "42",
+			"X-Amz-Meta-Internal-Path": "/srv/photos/a.png",
+			"X-Amz-Meta-Owner":         "alice",
+		}
+	}
+	all := []string{"Cache-Control", "Content-Type", "X-Amz-Meta-Internal-Id", "X-Amz-Meta-Internal-Path", "X-Amz-Meta-Owner"}
+	testCases := []struct {
+		patterns []string
+		preserve bool
+		expected []string
+		replace  bool
+	}{
+		{nil, true, all, false},
+		// Without --preserve a server side copy keeps the metadata of the
+		// source, nothing is excluded nor replaced.
+		{[]string{"cache-control"}, false, all, false},
+		{[]string{"x-amz-meta-internal-*"}, true, []string{"Cache-Control", "Content-Type", "X-Amz-Meta-Owner"}, true},
+		{[]string{"x-amz-meta-internal-*", "cache-control"}, true, []string{"Content-Type", "X-Amz-Meta-Owner"}, true},
+		{[]string{"X-Amz-Meta-*", "Content-*"}, true, []string{"Cache-Control"}, true},
+		{[]string{"*"}, true, []string{}, true},
+	}
+	for i, testCase := range testCases {
+		m := metadata()
+		replace := excludeMetadata(m, testCase.patterns, testCase.preserve)
+		if replace != testCase.replace {
+			t.Fatalf("Test %d: expected replace %v, got %v", i+1, testCase.replace, replace)
+		}
+		var keys []string
+		for k := range m {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		if len(keys) != len(testCase.expected) || len(keys) > 0 && !reflect.DeepEqual(keys, testCase.expected) {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.expected, keys)
+		}
+	}
+}
@@ -44,6 +44,10 @@ type aliasConfigV10 struct {
 	SessionToken string `json:"sessionToken,omitempty"`
 	API          string `json:"api"`
 	Path         string `json:"path"`
+	Region       string `json:"region,omitempty"`
+	// Endpoints lists further URLs of the same deployment, used
+	// in turn with URL and tried next when one is unreachable.
+	Endpoints []string `json:"endpoints,omitempty"`
 }
 
 // configV10 config version.
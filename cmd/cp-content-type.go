@@ -0,0 +1,87 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/mimedb"
+)
+
+// contentTypeMap - derives the content-type of objects from the extension
+// of their key, custom mappings take precedence over the builtin ones.
+type contentTypeMap struct {
+	custom map[string]string
+}
+
+// parseContentTypeMap - parses extension mappings in the mime.types format,
+// each line is a content-type followed by its extensions, e.g.
+// `text/markdown md markdown`. Empty lines and `#` comments are ignored.
+func parseContentTypeMap(r io.Reader) (*contentTypeMap, *probe.Error) {
+	m := &contentTypeMap{custom: map[string]string{}}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.Index(line, "#"); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) < 2 || !strings.Contains(fields[0], "/") {
+			return nil, errInvalidArgument().Trace(scanner.Text())
+		}
+		for _, ext := range fields[1:] {
+			m.custom[strings.ToLower(strings.TrimPrefix(ext, "."))] = fields[0]
+		}
+	}
+	if e := scanner.Err(); e != nil {
+		return nil, probe.NewError(e)
+	}
+	return m, nil
+}
+
+// loadContentTypeMapFile - loads extension mappings from a local file, an
+// empty filename only uses the builtin mappings.
+func loadContentTypeMapFile(filename string) (*contentTypeMap, *probe.Error) {
+	if filename == "" {
+		return &contentTypeMap{}, nil
+	}
+	f, e := os.Open(filename)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	defer f.Close()
+	return parseContentTypeMap(f)
+}
+
+// typeOf - returns the content-type of key, `application/octet-stream`
+// when its extension is unknown.
+func (m *contentTypeMap) typeOf(key string) string {
+	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(key), "."))
+	if contentType, ok := m.custom[ext]; ok && ext != "" {
+		return contentType
+	}
+	return mimedb.TypeByExtension(ext)
+}
@@ -26,6 +26,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync/atomic"
 
 	"github.com/fatih/color"
 	jsoniter "github.com/json-iterator/go"
@@ -50,6 +51,14 @@ var (
 			Name:  "recursive, r",
 			Usage: "copy recursively",
 		},
+		cli.BoolFlag{
+			Name:  "versions",
+			Usage: "copy all versions of each object, oldest first, with --recursive",
+		},
+		cli.StringFlag{
+			Name:  "exclude-versions-older-than",
+			Usage: "with --versions, skip noncurrent versions older than L days, M hours and N minutes",
+		},
 		cli.StringFlag{
 			Name:  "older-than",
 			Usage: "copy objects older than L days, M hours and N minutes",
@@ -82,6 +91,54 @@ var (
 			Name:  "disable-multipart",
 			Usage: "disable multipart upload feature",
 		},
+		cli.BoolFlag{
+			Name:  "preserve-version-id",
+			Usage: "write each copied version with its source version ID, on targets supporting it",
+		},
+		cli.BoolFlag{
+			Name:  "adaptive-part-size",
+			Usage: "pick the multipart part size of each object from its size, aiming at about 1000 parts of at most 256MiB, each buffered in memory",
+		},
+		cli.BoolFlag{
+			Name:  "tee",
+			Usage: "copy the single SOURCE to all the following TARGETs at once, reading it only once",
+		},
+		cli.BoolFlag{
+			Name:  "throttle-on-error",
+			Usage: "reduce concurrency and delay requests while the target returns throttling errors",
+		},
+		cli.BoolFlag{
+			Name:  "list-only",
+			Usage: "list source objects and their computed target without copying",
+		},
+		cli.BoolFlag{
+			Name:  "skip-existing-with-same-etag",
+			Usage: "skip objects whose target already exists with the same ETag, or the same size and MD5 checksum",
+		},
+		cli.BoolFlag{
+			Name:  "if-size-differs",
+			Usage: "copy only objects whose target is missing or has a different size",
+		},
+		cli.BoolFlag{
+			Name:  "newest",
+			Usage: "copy only the most recently modified object under the source prefix",
+		},
+		cli.BoolFlag{
+			Name:  "oldest",
+			Usage: "copy only the least recently modified object under the source prefix",
+		},
+		cli.BoolFlag{
+			Name:  "verify-after",
+			Usage: "download each object after upload and compare it byte for byte with its source",
+		},
+		cli.BoolFlag{
+			Name:  "remove-on-mismatch",
+			Usage: "remove uploaded objects failing --verify-after",
+		},
+		cli.IntFlag{
+			Name:  "limit-objects",
+			Usage: "stop after copying N objects, useful to sample a large recursive copy",
+		},
 		cli.BoolFlag{
 			Name:  "md5",
 			Usage: "force all upload(s) to calculate md5sum checksum",
@@ -90,6 +147,22 @@ var (
 			Name:  "tags",
 			Usage: "apply tags to the uploaded objects",
 		},
+		cli.StringSliceFlag{
+			Name:  "metadata-exclude",
+			Usage: "with --preserve, do not copy the source metadata matching this header name pattern, e.g. 'x-amz-meta-internal-*'",
+		},
+		cli.StringFlag{
+			Name:  "metadata-map-file",
+			Usage: "apply metadata and tags per object from a CSV file of `PATTERN,METADATA,TAGS` records, matching PATTERN with the source object key",
+		},
+		cli.BoolFlag{
+			Name:  "metadata-replace-content-type-by-extension",
+			Usage: "set the content-type of copied objects from their extension, copy a bucket onto itself to fix it in place",
+		},
+		cli.StringFlag{
+			Name:  "content-type-map",
+			Usage: "with --metadata-replace-content-type-by-extension, read extra mappings from a mime.types formatted file",
+		},
 		cli.StringFlag{
 			Name:  rmFlag,
 			Usage: "retention mode to be applied on the object (governance, compliance)",
@@ -102,6 +175,22 @@ var (
 			Name:  lhFlag,
 			Usage: "apply legal hold to the copied object (on, off)",
 		},
+		cli.BoolFlag{
+			Name:  "fair",
+			Usage: "copy large and small objects in separate worker pools so neither starves the other",
+		},
+		cli.IntFlag{
+			Name:  "max-concurrent-uploads",
+			Usage: "maximum number of objects uploaded concurrently from the local filesystem",
+		},
+		cli.IntFlag{
+			Name:  "max-concurrent-downloads",
+			Usage: "maximum number of objects downloaded concurrently to the local filesystem",
+		},
+		cli.StringFlag{
+			Name:  "source-region",
+			Usage: "region of the source bucket, overrides the region configured for the source alias",
+		},
 	}
 )
 
@@ -119,12 +208,13 @@ var cpCmd = cli.Command{
 	Action:       mainCopy,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        append(append(cpFlags, ioFlags...), globalFlags...),
+	Flags:        append(append(append(append(append(cpFlags, transferHookFlags...), checksumManifestFlags...), symlinkFlags...), ioFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
   {{.HelpName}} [FLAGS] SOURCE [SOURCE...] TARGET
+  {{.HelpName}} [FLAGS] --tee SOURCE TARGET [TARGET...]
 
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -133,6 +223,33 @@ ENVIRONMENT VARIABLES:
   MC_ENCRYPT:      list of comma delimited prefixes
   MC_ENCRYPT_KEY:  list of comma delimited prefix=secret values
 
+HOOKS:
+  --on-success, --on-failure commands are run after each transferred object,
+  without a shell. The command is split into arguments on spaces, then each
+  argument gets the following substitutions:
+
+     {key}  --> Substitutes to the target path of the object.
+     {size} --> Substitutes to the size of the object.
+     {etag} --> Substitutes to the ETag of the source object.
+
+  Objects skipped when resuming a session, or by --skip-existing-with-same-etag
+  and --if-size-differs, run no command.
+
+MANIFEST:
+  --checksum-manifest appends one JSON line per transferred object to the file:
+
+     {"key":"<target>","source":"<source>","size":<size>,"sha256":"<checksum>","etag":"<target etag>"}
+
+  The SHA256 checksum is computed over the transferred data, it is omitted
+  for server side copies where data does not go through this client.
+
+VERSIONS:
+  --versions copies every version of each object, oldest first, so that the
+  versions keep their order on a versioned target. Combined with --rewind only
+  the versions created at or before the rewind time are copied. Delete markers
+  are never copied: an object whose latest version at the rewind time is a
+  delete marker did not exist then and none of its versions are copied.
+
 EXAMPLES:
   01. Copy a list of objects from local file system to Amazon S3 cloud storage.
       {{.Prompt}} {{.HelpName}} Music/*.ogg s3/jukebox/
@@ -195,6 +312,63 @@ EXAMPLES:
   20. Set tags to the uploaded objects
       {{.Prompt}} {{.HelpName}} -r --tags "category=prod" ./data/ play/another-bucket/
 
+  21. Copy a folder recursively and apply metadata and tags per object as listed in 'metadata.csv'.
+      {{.Prompt}} {{.HelpName}} -r --metadata-map-file metadata.csv ./data/ play/mybucket/
+
+  22. Copy a folder recursively uploading at most 8 objects at a time, large objects do not starve small ones.
+      {{.Prompt}} {{.HelpName}} -r --fair --max-concurrent-uploads 8 ./data/ play/mybucket/
+
+  23. Copy a folder recursively and notify a local script about each uploaded object.
+      {{.Prompt}} {{.HelpName}} -r --on-success "./notify.sh {key} {size} {etag}" ./data/ play/mybucket/
+
+  24. List the objects a recursive copy would act on and their target, without copying.
+      {{.Prompt}} {{.HelpName}} -r --list-only --json play/mybucket/ s3/backup/
+
+  25. Migrate a folder recursively and record an auditable manifest of the transferred objects.
+      {{.Prompt}} {{.HelpName}} -r --md5 --checksum-manifest migration.jsonl ./data/ play/mybucket/
+
+  26. Sample a large bucket by copying only its first 100 matching objects.
+      {{.Prompt}} {{.HelpName}} -r --limit-objects 100 s3/logs/ ./sample/
+
+  27. Copy a versioned bucket with all its versions, dropping noncurrent versions older than 90 days.
+      {{.Prompt}} {{.HelpName}} -r --versions --exclude-versions-older-than 90d play/mybucket/ s3/archive/
+
+  28. Re-run a recursive copy, skipping the objects already copied with the same content.
+      {{.Prompt}} {{.HelpName}} -r --skip-existing-with-same-etag ./data/ play/mybucket/
+
+  29. Fix in place the content-type of migrated static files, keeping their other metadata.
+      {{.Prompt}} {{.HelpName}} -r --metadata-replace-content-type-by-extension play/website/ play/website/
+
+  30. Fetch the latest database backup under a prefix.
+      {{.Prompt}} {{.HelpName}} --newest s3/backups/db/ ./latest-db.tar.gz
+
+  31. Export a versioned bucket with all the versions that existed at the end of 2020.
+      {{.Prompt}} {{.HelpName}} -r --versions --rewind 2020.12.31T23:59:59 play/mybucket/ s3/archive/
+
+  32. Copy irreplaceable data, reading each object back after upload and removing the ones that do not match.
+      {{.Prompt}} {{.HelpName}} -r --verify-after --remove-on-mismatch ./archive/ s3/vault/archive/
+
+  33. Cheaply sync a prefix, copying only the objects missing on the target or with a different size.
+      {{.Prompt}} {{.HelpName}} -r --if-size-differs s3/logs/ play/logs/
+
+  34. Copy a mix of small and very large backups, sizing the upload parts of each object after its size.
+      {{.Prompt}} {{.HelpName}} -r --adaptive-part-size ./backups/ s3/backups/
+
+  35. Migrate a versioned bucket to another MinIO cluster, keeping the version IDs of all its versions.
+      {{.Prompt}} {{.HelpName}} -r --versions --preserve-version-id old/mybucket/ new/mybucket/
+
+  36. Copy a large dataset to an overloaded target, backing off while it asks to slow down.
+      {{.Prompt}} {{.HelpName}} -r --throttle-on-error --debug ./dataset/ s3/dataset/
+
+  37. Upload a large backup to a primary and a backup bucket at once, reading it only once.
+      {{.Prompt}} {{.HelpName}} --tee ./db.tar.gz s3/primary/ backup/db/db.tar.gz
+
+  38. Copy a bucket to another AWS region server side, the 'us-east' source alias pointing at 'us-east-1'.
+      {{.Prompt}} {{.HelpName}} -r --json --source-region us-east-1 us-east/mybucket/ eu-west/mybucket/
+
+  39. Migrate a bucket with its metadata, except the internal metadata and the cache-control of the source.
+      {{.Prompt}} {{.HelpName}} -r -a --metadata-exclude "x-amz-meta-internal-*" --metadata-exclude cache-control old/mybucket/ new/mybucket/
+
 `,
 }
 
@@ -206,11 +380,16 @@ type copyMessage struct {
 	Size       int64  `json:"size"`
 	TotalCount int64  `json:"totalCount"`
 	TotalSize  int64  `json:"totalSize"`
+	CopyMethod string `json:"copyMethod,omitempty"`
 }
 
 // String colorized copy message
 func (c copyMessage) String() string {
-	return console.Colorize("Copy", fmt.Sprintf("`%s` -> `%s`", c.Source, c.Target))
+	msg := fmt.Sprintf("`%s` -> `%s`", c.Source, c.Target)
+	if c.CopyMethod == copyMethodServerSide {
+		msg += " (server-side)"
+	}
+	return console.Colorize("Copy", msg)
 }
 
 // JSON jsonified copy message
@@ -222,6 +401,47 @@ func (c copyMessage) JSON() string {
 	return string(copyMessageBytes)
 }
 
+// skipSameETagMessage is printed at the end of a copy with --skip-existing-with-same-etag
+// or --if-size-differs.
+type skipSameETagMessage struct {
+	Status  string `json:"status"`
+	Skipped int64  `json:"skippedUnchanged"`
+}
+
+// String colorized skipped objects message
+func (s skipSameETagMessage) String() string {
+	return console.Colorize("Skipped", fmt.Sprintf("Skipped %d unchanged object(s) already present on the target.", s.Skipped))
+}
+
+// JSON jsonified skipped objects message
+func (s skipSameETagMessage) JSON() string {
+	s.Status = "success"
+	skipMessageBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(skipMessageBytes)
+}
+
+// limitReachedMessage is printed when --limit-objects stopped a transfer early.
+type limitReachedMessage struct {
+	Status string `json:"status"`
+	Limit  int64  `json:"limitObjects"`
+}
+
+// String colorized limit reached message
+func (l limitReachedMessage) String() string {
+	return console.Colorize("LimitReached", fmt.Sprintf("Object limit of %d reached, remaining objects were not transferred.", l.Limit))
+}
+
+// JSON jsonified limit reached message
+func (l limitReachedMessage) JSON() string {
+	l.Status = "success"
+	limitMessageBytes, e := json.MarshalIndent(l, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(limitMessageBytes)
+}
+
 // Progress - an interface which describes current amount
 // of data written.
 type Progress interface {
@@ -260,10 +480,14 @@ func doCopy(ctx context.Context, cpURLs URLs, pg ProgressReader, encKeyDB map[st
 			Size:       length,
 			TotalCount: cpURLs.TotalCount,
 			TotalSize:  cpURLs.TotalSize,
+			CopyMethod: remoteCopyMethod(sourceAlias, targetAlias),
 		})
 	}
 
 	urls := uploadSourceToTargetURL(ctx, cpURLs, pg, encKeyDB, preserve)
+	if urls.verifyAfter && urls.Error == nil {
+		urls.Error = verifyCopy(ctx, urls, encKeyDB, urls.removeOnMismatch)
+	}
 	if isMvCmd && urls.Error == nil {
 		rmManager.add(ctx, sourceAlias, sourceURL.String())
 	}
@@ -277,6 +501,7 @@ func doCopyFake(ctx context.Context, cpURLs URLs, pg Progress) URLs {
 		progressReader.ProgressBar.Add64(cpURLs.SourceContent.Size)
 	}
 
+	cpURLs.notTransferred = true
 	return cpURLs
 }
 
@@ -293,8 +518,11 @@ func doPrepareCopyURLs(ctx context.Context, session *sessionV8, cancelCopy conte
 	versionID := session.Header.CommandStringFlags["version-id"]
 	olderThan := session.Header.CommandStringFlags["older-than"]
 	newerThan := session.Header.CommandStringFlags["newer-than"]
+	withVersions := session.Header.CommandBoolFlags["versions"]
+	excludeVersionsOlderThan := session.Header.CommandStringFlags["exclude-versions-older-than"]
 	encryptKeys := session.Header.CommandStringFlags["encrypt-key"]
 	encrypt := session.Header.CommandStringFlags["encrypt"]
+	limit := int64(session.Header.CommandIntFlags["limit-objects"])
 	encKeyDB, err := parseAndValidateEncryptionKeys(encryptKeys, encrypt)
 	fatalIf(err, "Unable to parse encryption keys.")
 
@@ -306,7 +534,10 @@ func doPrepareCopyURLs(ctx context.Context, session *sessionV8, cancelCopy conte
 		scanBar = scanBarFactory()
 	}
 
-	URLsCh := prepareCopyURLs(ctx, sourceURLs, targetURL, isRecursive, encKeyDB, olderThan, newerThan, parseRewindFlag(rewind), versionID)
+	prepCtx, cancelPrep := context.WithCancel(ctx)
+	defer cancelPrep()
+
+	URLsCh := prepareCopyURLs(prepCtx, sourceURLs, targetURL, isRecursive, encKeyDB, olderThan, newerThan, parseRewindFlag(rewind), versionID, withVersions, excludeVersionsOlderThan)
 	done := false
 	for !done {
 		select {
@@ -315,6 +546,13 @@ func doPrepareCopyURLs(ctx context.Context, session *sessionV8, cancelCopy conte
 				done = true
 				break
 			}
+			if limit > 0 && totalObjects >= limit {
+				// Limit reached with objects left, stop listing and
+				// drain what is left.
+				session.Header.LimitReached = session.Header.LimitReached || cpURLs.Error == nil
+				cancelPrep()
+				break
+			}
 			if cpURLs.Error != nil {
 				// Print in new line and adjust to top so that we don't print over the ongoing scan bar
 				if !globalQuiet && !globalJSON {
@@ -359,9 +597,11 @@ func doPrepareCopyURLs(ctx context.Context, session *sessionV8, cancelCopy conte
 	return
 }
 
-func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.Context, session *sessionV8, encKeyDB map[string][]prefixSSEPair, isMvCmd bool) error {
+func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.Context, args []string, session *sessionV8, encKeyDB map[string][]prefixSSEPair, isMvCmd bool) error {
 	var isCopied func(string) bool
 	var totalObjects, totalBytes int64
+	// Set when --limit-objects left objects out of the transfer.
+	var limitReached bool
 
 	var cpURLsCh = make(chan URLs, 10000)
 
@@ -375,12 +615,30 @@ func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.
 		pg = newAccounter(totalBytes)
 	}
 
-	sourceURLs := cli.Args()[:len(cli.Args())-1]
-	targetURL := cli.Args()[len(cli.Args())-1] // Last one is target
+	sourceURLs := args[:len(args)-1]
+	targetURL := args[len(args)-1] // Last one is target
 
 	tgtClnt, err := newClient(targetURL)
 	fatalIf(err, "Unable to initialize `"+targetURL+"`.")
 
+	var metaMap *metadataMap
+	if metaMapFile := cli.String("metadata-map-file"); metaMapFile != "" {
+		metaMap, err = loadMetadataMapFile(metaMapFile)
+		fatalIf(err.Trace(metaMapFile), "Unable to load metadata map file.")
+	}
+
+	var contentTypes *contentTypeMap
+	if cli.Bool("metadata-replace-content-type-by-extension") {
+		contentTypes, err = loadContentTypeMapFile(cli.String("content-type-map"))
+		fatalIf(err.Trace(cli.String("content-type-map")), "Unable to load content-type map file.")
+	}
+
+	var manifest *checksumManifest
+	if manifestFile := cli.String("checksum-manifest"); manifestFile != "" {
+		manifest, err = newChecksumManifest(manifestFile)
+		fatalIf(err.Trace(manifestFile), "Unable to open checksum manifest file.")
+	}
+
 	// Check if the target bucket has object locking enabled
 	var withLock bool
 	if _, _, _, _, err = tgtClnt.GetObjectLockConfig(ctx); err == nil {
@@ -397,6 +655,7 @@ func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.
 		} else {
 			totalBytes, totalObjects = session.Header.TotalBytes, session.Header.TotalObjects
 		}
+		limitReached = session.Header.LimitReached
 
 		pg.SetTotal(totalBytes)
 
@@ -426,11 +685,23 @@ func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.
 		newerThan := cli.String("newer-than")
 		rewind := cli.String("rewind")
 		versionID := cli.String("version-id")
+		limit := int64(cli.Int("limit-objects"))
 
 		go func() {
+			prepCtx, cancelPrep := context.WithCancel(ctx)
+			defer cancelPrep()
+
 			totalBytes := int64(0)
-			for cpURLs := range prepareCopyURLs(ctx, sourceURLs, targetURL, isRecursive,
-				encKeyDB, olderThan, newerThan, parseRewindFlag(rewind), versionID) {
+			for cpURLs := range prepareCopyURLs(prepCtx, sourceURLs, targetURL, isRecursive,
+				encKeyDB, olderThan, newerThan, parseRewindFlag(rewind), versionID,
+				cli.Bool("versions"), cli.String("exclude-versions-older-than")) {
+				if limit > 0 && totalObjects >= limit {
+					// Limit reached with objects left, stop listing and
+					// drain what is left.
+					limitReached = limitReached || cpURLs.Error == nil
+					cancelPrep()
+					continue
+				}
 				if cpURLs.Error != nil {
 					// Print in new line and adjust to top so that we
 					// don't print over the ongoing scan bar
@@ -460,9 +731,42 @@ func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.
 	var quitCh = make(chan struct{})
 	var statusCh = make(chan URLs)
 
-	parallel := newParallelManager(statusCh)
+	var throttle *errorThrottle
+	if cli.Bool("throttle-on-error") {
+		throttle = newErrorThrottle(maxParallelWorkers)
+	}
+	parallel := newCopyPools(statusCh, cli.Bool("fair"), cli.Int("max-concurrent-uploads"), cli.Int("max-concurrent-downloads"), throttle)
+
+	skipSameETag := cli.Bool("skip-existing-with-same-etag")
+	ifSizeDiffers := cli.Bool("if-size-differs")
+	isSameTarget := isSameETag
+	if ifSizeDiffers {
+		isSameTarget = isSameSize
+	}
+	var skippedObjects int64
 
 	go func() {
+		preserve := cli.Bool("preserve")
+
+		// With --versions, the versions of an object are listed newest
+		// first and queued as a single task copying them oldest first,
+		// so the latest version is also the latest one on the target.
+		var versions []URLs
+		var versionsKeepMetadata bool
+		queueVersions := func() {
+			group, keepMetadata := versions, versionsKeepMetadata
+			versions = nil
+			if len(group) == 0 {
+				return
+			}
+			parallel.queueTask(group[0], func() URLs {
+				for i := len(group) - 1; i > 0; i-- {
+					statusCh <- doCopy(ctx, group[i], pg, encKeyDB, isMvCmd, keepMetadata)
+				}
+				return doCopy(ctx, group[0], pg, encKeyDB, isMvCmd, keepMetadata)
+			})
+		}
+
 		gracefulStop := func() {
 			parallel.stopAndWait()
 			close(statusCh)
@@ -475,6 +779,7 @@ func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.
 				return
 			case cpURLs, ok := <-cpURLsCh:
 				if !ok {
+					queueVersions()
 					gracefulStop()
 					return
 				}
@@ -512,7 +817,6 @@ func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.
 					cpURLs.TargetContent.Metadata["X-Amz-Tagging"] = tags
 				}
 
-				preserve := cli.Bool("preserve")
 				if cli.String("attr") != "" {
 					userMetaMap, _ := getMetaDataEntry(cli.String("attr"))
 					for metadataKey, metaDataVal := range userMetaMap {
@@ -520,23 +824,59 @@ func doCopySession(ctx context.Context, cancelCopy context.CancelFunc, cli *cli.
 					}
 				}
 
+				if metaMap != nil {
+					metaMap.apply(objectKey(cpURLs.SourceContent.URL), cpURLs.TargetContent)
+				}
+
+				// A server side copy replaces all metadata, keep the existing
+				// ones when only the content-type is meant to change.
+				keepMetadata := preserve
+				if contentTypes != nil {
+					cpURLs.TargetContent.Metadata["Content-Type"] = contentTypes.typeOf(objectKey(cpURLs.TargetContent.URL))
+					keepMetadata = keepMetadata || canServerSideCopy(cpURLs.SourceAlias, cpURLs.TargetAlias)
+				}
+
 				cpURLs.MD5 = cli.Bool("md5") || withLock
 				cpURLs.DisableMultipart = cli.Bool("disable-multipart")
+				cpURLs.AdaptivePartSize = cli.Bool("adaptive-part-size")
+				cpURLs.PreserveVersionID = cli.Bool("preserve-version-id")
+				cpURLs.withChecksum = manifest != nil
+				cpURLs.verifyAfter = cli.Bool("verify-after")
+				cpURLs.removeOnMismatch = cli.Bool("remove-on-mismatch")
+				cpURLs.metadataExclude = cli.StringSlice("metadata-exclude")
 
 				// Verify if previously copied, notify progress bar.
 				if isCopied != nil && isCopied(cpURLs.SourceContent.URL.String()) {
-					parallel.queueTask(func() URLs {
+					parallel.queueTask(cpURLs, func() URLs {
 						return doCopyFake(ctx, cpURLs, pg)
 					})
+				} else if cli.Bool("versions") {
+					if len(versions) > 0 && versions[0].SourceContent.URL.String() != cpURLs.SourceContent.URL.String() {
+						queueVersions()
+					}
+					versions = append(versions, cpURLs)
+					versionsKeepMetadata = keepMetadata
+				} else if skipSameETag || ifSizeDiffers {
+					parallel.queueTask(cpURLs, func() URLs {
+						same, err := isSameTarget(ctx, cpURLs)
+						errorIf(err, "Unable to compare `%s` with its target, copying it.", cpURLs.SourceContent.URL.String())
+						if same {
+							atomic.AddInt64(&skippedObjects, 1)
+							return doCopyFake(ctx, cpURLs, pg)
+						}
+						return doCopy(ctx, cpURLs, pg, encKeyDB, isMvCmd, keepMetadata)
+					})
 				} else {
-					parallel.queueTask(func() URLs {
-						return doCopy(ctx, cpURLs, pg, encKeyDB, isMvCmd, preserve)
+					parallel.queueTask(cpURLs, func() URLs {
+						return doCopy(ctx, cpURLs, pg, encKeyDB, isMvCmd, keepMetadata)
 					})
 				}
 			}
 		}
 	}()
 
+	hook := newTransferHook(cli)
+
 	var retErr error
 	errSeen := false
 	cpAllFilesErr := true
@@ -560,7 +900,9 @@ loop:
 			if !ok {
 				break loop
 			}
+			hook.run(cpURLs)
 			if cpURLs.Error == nil {
+				errorIf(manifest.add(cpURLs), "Unable to record `%s` in checksum manifest.", cpURLs.SourceContent.URL.String())
 				if session != nil {
 					session.Header.LastCopied = cpURLs.SourceContent.URL.String()
 					session.Save()
@@ -618,6 +960,29 @@ loop:
 		}
 	}
 
+	if skipSameETag || ifSizeDiffers {
+		printMsg(skipSameETagMessage{Skipped: atomic.LoadInt64(&skippedObjects)})
+	}
+
+	if limitReached {
+		printMsg(limitReachedMessage{Limit: int64(cli.Int("limit-objects"))})
+	}
+
+	if hook.wait() {
+		retErr = exitStatus(globalErrorExitStatus)
+	}
+
+	if err := manifest.close(); err != nil {
+		errorIf(err, "Unable to save checksum manifest.")
+		retErr = exitStatus(globalErrorExitStatus)
+	}
+
+	if metaMap != nil {
+		for _, pattern := range metaMap.unmatched() {
+			errorIf(errDummy().Trace(pattern), "Metadata map pattern `"+pattern+"` did not match any object.")
+		}
+	}
+
 	return retErr
 }
 
@@ -637,11 +1002,72 @@ func mainCopy(cliCtx *cli.Context) error {
 		fatalIf(err, "Unable to parse attribute %v", cliCtx.String("attr"))
 	}
 
+	// The source prefix is replaced by the object picked by --newest or --oldest.
+	args := append([]string{}, cliCtx.Args()...)
+	if cliCtx.Bool("newest") || cliCtx.Bool("oldest") {
+		if len(args) != 2 || cliCtx.Bool("newest") && cliCtx.Bool("oldest") {
+			fatalIf(errInvalidArgument().Trace(args...), "--newest and --oldest select a single object out of one source prefix.")
+		}
+		if cliCtx.Bool("recursive") || cliCtx.Bool("versions") || cliCtx.String("version-id") != "" {
+			fatalIf(errInvalidArgument().Trace(args...), "--newest and --oldest cannot be used with --recursive, --versions or --version-id.")
+		}
+		sourceURL, err := selectObjectByTime(ctx, args[0], cliCtx.Bool("newest"), parseRewindFlag(cliCtx.String("rewind")),
+			cliCtx.String("older-than"), cliCtx.String("newer-than"))
+		fatalIf(err.Trace(args[0]), "Unable to find any object under `"+args[0]+"`.")
+		args[0] = sourceURL
+	}
+
+	// With --tee all arguments but the first one are targets.
+	if cliCtx.Bool("tee") {
+		console.SetColor("Copy", color.New(color.FgGreen, color.Bold))
+		return doCopyTee(ctx, cliCtx, args, encKeyDB, userMetaMap)
+	}
+
 	// check 'copy' cli arguments.
-	checkCopySyntax(ctx, cliCtx, encKeyDB, false)
+	checkCopySyntax(ctx, cliCtx, args, encKeyDB, false)
+
+	if region := cliCtx.String("source-region"); region != "" {
+		targetAlias, _ := url2Alias(args[len(args)-1])
+		for _, arg := range args[:len(args)-1] {
+			sourceAlias, _ := url2Alias(arg)
+			if sourceAlias == targetAlias {
+				fatalIf(errInvalidArgument().Trace(args...), "--source-region requires the source and the target to use different aliases.")
+			}
+			aliasRegions[sourceAlias] = region
+		}
+	}
+
+	if cliCtx.Bool("if-size-differs") && cliCtx.Bool("skip-existing-with-same-etag") {
+		fatalIf(errInvalidArgument().Trace(args...), "--if-size-differs and --skip-existing-with-same-etag cannot be used together.")
+	}
+
+	// Targets are compared with their latest version, not with each
+	// copied version.
+	if cliCtx.Bool("versions") && (cliCtx.Bool("if-size-differs") || cliCtx.Bool("skip-existing-with-same-etag")) {
+		fatalIf(errInvalidArgument().Trace(args...), "--if-size-differs and --skip-existing-with-same-etag cannot be used with --versions.")
+	}
+
+	if cliCtx.Bool("preserve-version-id") && !cliCtx.Bool("versions") && cliCtx.String("version-id") == "" {
+		fatalIf(errInvalidArgument().Trace(args...), "--preserve-version-id requires --versions or --version-id.")
+	}
+
+	if len(cliCtx.StringSlice("metadata-exclude")) > 0 && !cliCtx.Bool("preserve") {
+		fatalIf(errInvalidArgument().Trace(args...), "--metadata-exclude requires --preserve.")
+	}
+
+	if cliCtx.Bool("remove-on-mismatch") && !cliCtx.Bool("verify-after") {
+		fatalIf(errInvalidArgument().Trace(args...), "--remove-on-mismatch requires --verify-after.")
+	}
 
 	// Additional command specific theme customization.
 	console.SetColor("Copy", color.New(color.FgGreen, color.Bold))
+	console.SetColor("LimitReached", color.New(color.FgYellow))
+	console.SetColor("Skipped", color.New(color.FgYellow))
+
+	if cliCtx.Bool("list-only") {
+		console.SetColor("ListOnly", color.New(color.FgGreen))
+		return doCopyListOnly(ctx, cliCtx, args, encKeyDB)
+	}
 
 	recursive := cliCtx.Bool("recursive")
 	rewind := cliCtx.String("rewind")
@@ -675,6 +1101,8 @@ func mainCopy(cliCtx *cli.Context) error {
 			session = newSessionV8(sessionID)
 			session.Header.CommandType = "cp"
 			session.Header.CommandBoolFlags["recursive"] = recursive
+			session.Header.CommandBoolFlags["versions"] = cliCtx.Bool("versions")
+			session.Header.CommandStringFlags["exclude-versions-older-than"] = cliCtx.String("exclude-versions-older-than")
 			session.Header.CommandStringFlags["rewind"] = rewind
 			session.Header.CommandStringFlags["version-id"] = versionID
 			session.Header.CommandStringFlags["older-than"] = olderThan
@@ -694,6 +1122,9 @@ func mainCopy(cliCtx *cli.Context) error {
 			session.Header.UserMetaData = userMetaMap
 			session.Header.CommandBoolFlags["md5"] = cliCtx.Bool("md5")
 			session.Header.CommandBoolFlags["disable-multipart"] = cliCtx.Bool("disable-multipart")
+			session.Header.CommandBoolFlags["adaptive-part-size"] = cliCtx.Bool("adaptive-part-size")
+			session.Header.CommandBoolFlags["preserve-version-id"] = cliCtx.Bool("preserve-version-id")
+			session.Header.CommandIntFlags["limit-objects"] = cliCtx.Int("limit-objects")
 
 			var e error
 			if session.Header.RootPath, e = os.Getwd(); e != nil {
@@ -702,11 +1133,11 @@ func mainCopy(cliCtx *cli.Context) error {
 			}
 
 			// extract URLs.
-			session.Header.CommandArgs = cliCtx.Args()
+			session.Header.CommandArgs = args
 		}
 	}
 
-	e := doCopySession(ctx, cancelCopy, cliCtx, session, encKeyDB, false)
+	e := doCopySession(ctx, cancelCopy, cliCtx, args, session, encKeyDB, false)
 	if session != nil {
 		session.Delete()
 	}
@@ -18,7 +18,11 @@
 package cmd
 
 import (
+	"context"
+	"io/ioutil"
+	"os"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -74,3 +78,178 @@ func TestParseMetaData(t *testing.T) {
 		}
 	}
 }
+
+func TestParseMetadataMap(t *testing.T) {
+	input := `# pattern,metadata,tags
+logs/*,"X-Amz-Meta-Source=legacy;Cache-Control=max-age=90000,min-fresh=9000",
+logs/2021/*,X-Amz-Meta-Year=2021,year=2021&kind=log
+`
+	m, err := parseMetadataMap(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(m.entries) != 2 {
+		t.Fatalf("expected 2 entries, found %d", len(m.entries))
+	}
+
+	content := &ClientContent{Metadata: map[string]string{}, UserMetadata: map[string]string{}}
+	m.apply("logs/2021/jan.log", content)
+	expectedMetadata := map[string]string{
+		"X-Amz-Meta-Source": "legacy",
+		"Cache-Control":     "max-age=90000,min-fresh=9000",
+		"X-Amz-Meta-Year":   "2021",
+	}
+	if !reflect.DeepEqual(content.UserMetadata, expectedMetadata) {
+		t.Fatalf("expected %v, found %v", expectedMetadata, content.UserMetadata)
+	}
+	if tags := content.Metadata["X-Amz-Tagging"]; tags != "year=2021&kind=log" {
+		t.Fatalf("unexpected tags %s", tags)
+	}
+	if unmatched := m.unmatched(); len(unmatched) != 0 {
+		t.Fatalf("unexpected unmatched patterns %v", unmatched)
+	}
+
+	m, err = parseMetadataMap(strings.NewReader("images/*,X-Amz-Meta-Kind=image\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	m.apply("logs/2021/jan.log", content)
+	if unmatched := m.unmatched(); !reflect.DeepEqual(unmatched, []string{"images/*"}) {
+		t.Fatalf("unexpected unmatched patterns %v", unmatched)
+	}
+
+	if _, err = parseMetadataMap(strings.NewReader("images/*\n")); err == nil {
+		t.Fatalf("expected an error for a record without metadata")
+	}
+}
+
+func TestTransferHookSkipsNotTransferred(t *testing.T) {
+	hook := &transferHook{onSuccess: "false", hookFail: true, limitCh: make(chan struct{}, 1)}
+	urls := doCopyFake(context.Background(), URLs{
+		SourceContent: &ClientContent{Size: 1024},
+		TargetContent: &ClientContent{},
+	}, nil)
+	if !urls.notTransferred {
+		t.Fatal("Expected a faked copy to be marked as not transferred")
+	}
+	hook.run(urls)
+	if hook.wait() {
+		t.Fatal("Expected no hook command to run for an object not transferred")
+	}
+}
+
+func TestChecksumManifestSkipsNotTransferred(t *testing.T) {
+	f, e := ioutil.TempFile("", "manifest")
+	if e != nil {
+		t.Fatal(e)
+	}
+	f.Close()
+	defer os.Remove(f.Name())
+
+	manifest, err := newChecksumManifest(f.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	urls := URLs{
+		SourceContent: &ClientContent{Size: 1024},
+		TargetContent: &ClientContent{},
+	}
+	if err = manifest.add(doCopyFake(context.Background(), urls, nil)); err != nil {
+		t.Fatal(err)
+	}
+	urls.checksum = "5891b5b522d5df086d0ff0b110fbd9d21bb4fc7163af34d08286a2e846f6be03"
+	if err = manifest.add(urls); err != nil {
+		t.Fatal(err)
+	}
+	if err = manifest.close(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, e := ioutil.ReadFile(f.Name())
+	if e != nil {
+		t.Fatal(e)
+	}
+	if lines := strings.Count(string(data), "\n"); lines != 1 || !strings.Contains(string(data), urls.checksum) {
+		t.Fatalf("Expected only the transferred object in the manifest, got %q", data)
+	}
+}
+
+func TestExpandHookCommand(t *testing.T) {
+	urls := URLs{
+		SourceContent: &ClientContent{Size: 1024, ETag: "abcd"},
+		TargetAlias:   "play",
+		TargetContent: &ClientContent{},
+	}
+	testCases := []struct {
+		command  string
+		path     string
+		expected []string
+	}{
+		{"notify {key}", "/mybucket/dir/object", []string{"notify", "play/mybucket/dir/object"}},
+		{"notify {key} {size} {etag}", "/mybucket/dir/object", []string{"notify", "play/mybucket/dir/object", "1024", "abcd"}},
+		{"notify", "/mybucket/dir/object", []string{"notify"}},
+		{"notify --key={key}", "/mybucket/my dir/my object", []string{"notify", "--key=play/mybucket/my dir/my object"}},
+	}
+	for i, testCase := range testCases {
+		urls.TargetContent.URL.Path = testCase.path
+		if got := expandHookCommand(testCase.command, urls); !reflect.DeepEqual(got, testCase.expected) {
+			t.Fatalf("Test %d: expected %q, got %q", i+1, testCase.expected, got)
+		}
+	}
+}
+
+func TestContentTypeMap(t *testing.T) {
+	m, err := parseContentTypeMap(strings.NewReader(`# custom types
+text/markdown md markdown
+
+application/x-custom .CUS
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	testCases := []struct {
+		key      string
+		expected string
+	}{
+		{"docs/README.md", "text/markdown"},
+		{"docs/guide.MARKDOWN", "text/markdown"},
+		{"data/file.cus", "application/x-custom"},
+		{"site/index.html", "text/html"},
+		{"site/no-extension", "application/octet-stream"},
+	}
+	for i, testCase := range testCases {
+		if got := m.typeOf(testCase.key); got != testCase.expected {
+			t.Fatalf("Test %d: expected %s, got %s", i+1, testCase.expected, got)
+		}
+	}
+
+	for i, input := range []string{"md markdown", "text/markdown"} {
+		if _, err := parseContentTypeMap(strings.NewReader(input)); err == nil {
+			t.Fatalf("Test %d: expected an error for %q", i+1, input)
+		}
+	}
+}
+
+func TestDeletedAtRewind(t *testing.T) {
+	testCases := []struct {
+		key     string
+		marker  bool
+		deleted bool
+	}{
+		{"a", false, false},
+		{"a", true, true},
+		{"a", false, false},
+		{"b", true, true},
+		{"b", false, true},
+		{"b", false, true},
+		{"c", false, false},
+	}
+	isDeleted := deletedAtRewind()
+	for i, testCase := range testCases {
+		content := &ClientContent{URL: ClientURL{Path: testCase.key}, IsDeleteMarker: testCase.marker}
+		if deleted := isDeleted(content); deleted != testCase.deleted {
+			t.Fatalf("Test %d: expected deleted %v, got %v", i+1, testCase.deleted, deleted)
+		}
+	}
+}
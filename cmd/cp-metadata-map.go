@@ -0,0 +1,133 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/csv"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/wildcard"
+)
+
+// metadataMapEntry - metadata and tags to apply on objects matching a key pattern.
+type metadataMapEntry struct {
+	pattern  string
+	metadata map[string]string
+	tags     string
+	matched  bool
+}
+
+// metadataMap - ordered list of entries loaded from a metadata map file,
+// later entries take precedence over earlier ones.
+type metadataMap struct {
+	entries []*metadataMapEntry
+}
+
+// parseMetadataMap - parses a metadata map in CSV format, each record is of
+// the form `PATTERN,METADATA[,TAGS]` where METADATA follows the `--attr`
+// syntax and TAGS the `--tags` syntax.
+func parseMetadataMap(r io.Reader) (*metadataMap, *probe.Error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	m := &metadataMap{}
+	for {
+		record, e := reader.Read()
+		if e == io.EOF {
+			break
+		}
+		if e != nil {
+			return nil, probe.NewError(e)
+		}
+		if len(record) < 2 || len(record) > 3 || record[0] == "" {
+			return nil, errInvalidArgument().Trace(strings.Join(record, ","))
+		}
+		entry := &metadataMapEntry{
+			pattern:  record[0],
+			metadata: map[string]string{},
+		}
+		if record[1] != "" {
+			metadata, err := getMetaDataEntry(record[1])
+			if err != nil {
+				return nil, err.Trace(record[1])
+			}
+			entry.metadata = metadata
+		}
+		if len(record) == 3 {
+			entry.tags = record[2]
+		}
+		m.entries = append(m.entries, entry)
+	}
+	return m, nil
+}
+
+// loadMetadataMapFile - loads a metadata map from a local file.
+func loadMetadataMapFile(filename string) (*metadataMap, *probe.Error) {
+	f, e := os.Open(filename)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	defer f.Close()
+	return parseMetadataMap(f)
+}
+
+// apply - sets the metadata and tags of all entries matching the source
+// object key on the target content.
+func (m *metadataMap) apply(key string, content *ClientContent) {
+	for _, entry := range m.entries {
+		if !wildcard.Match(entry.pattern, key) {
+			continue
+		}
+		entry.matched = true
+		for k, v := range entry.metadata {
+			content.UserMetadata[k] = v
+		}
+		if entry.tags == "" {
+			continue
+		}
+		if tags := content.Metadata["X-Amz-Tagging"]; tags != "" {
+			content.Metadata["X-Amz-Tagging"] = tags + "&" + entry.tags
+		} else {
+			content.Metadata["X-Amz-Tagging"] = entry.tags
+		}
+	}
+}
+
+// unmatched - returns the patterns which did not match any object.
+func (m *metadataMap) unmatched() (patterns []string) {
+	for _, entry := range m.entries {
+		if !entry.matched {
+			patterns = append(patterns, entry.pattern)
+		}
+	}
+	return patterns
+}
+
+// objectKey - returns the object key of a copy source or target, without
+// the bucket name for object storage URLs.
+func objectKey(u ClientURL) string {
+	if u.Type != objectStorage {
+		return u.Path
+	}
+	return splitStr(u.Path, string(u.Separator), 3)[2]
+}
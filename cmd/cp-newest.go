@@ -0,0 +1,68 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// selectObjectByTime - lists the prefix recursively and returns the
+// aliased URL of the object with the latest, or the earliest, last
+// modified time. Objects filtered out by --older-than and --newer-than
+// are not considered.
+func selectObjectByTime(ctx context.Context, prefixURL string, newest bool, timeRef time.Time, olderThan, newerThan string) (string, *probe.Error) {
+	alias, _, _, err := expandAlias(prefixURL)
+	if err != nil {
+		return "", err.Trace(prefixURL)
+	}
+	clnt, err := newClient(prefixURL)
+	if err != nil {
+		return "", err.Trace(prefixURL)
+	}
+
+	var selected *ClientContent
+	for content := range clnt.List(ctx, ListOptions{Recursive: true, TimeRef: timeRef, ShowDir: DirNone}) {
+		if content.Err != nil {
+			return "", content.Err.Trace(prefixURL)
+		}
+		if !content.Type.IsRegular() || content.IsDeleteMarker {
+			continue
+		}
+		if olderThan != "" && isOlder(content.Time, olderThan) {
+			continue
+		}
+		if newerThan != "" && isNewer(content.Time, newerThan) {
+			continue
+		}
+		if selected == nil ||
+			newest && content.Time.After(selected.Time) ||
+			!newest && content.Time.Before(selected.Time) {
+			selected = content
+		}
+	}
+	if selected == nil {
+		return "", errDummy().Trace(prefixURL)
+	}
+	if alias == "" {
+		return selected.URL.Path, nil
+	}
+	return alias + selected.URL.Path, nil
+}
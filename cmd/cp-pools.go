@@ -0,0 +1,140 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import "runtime"
+
+// Objects of at least this size are copied in their own
+// worker pool when fair scheduling is requested.
+const fairLargeObjectSize = 128 * 1024 * 1024
+
+// Maximum number of tasks waiting for a worker in each pool.
+const copyPoolQueueSize = 10000
+
+// copyPool - worker pool fed through its own queue, so that a busy
+// pool does not hold back tasks queued for the other pools.
+type copyPool struct {
+	parallel *ParallelManager
+	queueCh  chan func() URLs
+	doneCh   chan struct{}
+}
+
+// newCopyPool - starts a worker pool of at most maxWorkers workers.
+func newCopyPool(resultCh chan URLs, maxWorkers int, throttle *errorThrottle) *copyPool {
+	p := &copyPool{
+		parallel: newParallelManagerWithMaxWorkers(resultCh, maxWorkers, throttle),
+		queueCh:  make(chan func() URLs, copyPoolQueueSize),
+		doneCh:   make(chan struct{}),
+	}
+	go func() {
+		defer close(p.doneCh)
+		for fn := range p.queueCh {
+			p.parallel.queueTask(fn)
+		}
+	}()
+	return p
+}
+
+// queueTask - queues fn without waiting for a worker to be available.
+func (p *copyPool) queueTask(fn func() URLs) {
+	p.queueCh <- fn
+}
+
+// stopAndWait - waits for all queued tasks to finish.
+func (p *copyPool) stopAndWait() {
+	close(p.queueCh)
+	<-p.doneCh
+	p.parallel.stopAndWait()
+}
+
+// copyPools - dispatches copy tasks to separate worker pools depending on
+// the size of the object and on the direction of the transfer. Without
+// any of these options, all tasks are directly queued in a single pool.
+type copyPools struct {
+	// Pool for all tasks when no other pool is configured.
+	parallel *ParallelManager
+	// Pool for all tasks not handled by any other pool.
+	def *copyPool
+	// Pool for large objects, only set in fair mode.
+	large *copyPool
+	// Pools for uploads and downloads, only set when limited.
+	uploads, downloads *copyPool
+}
+
+// newCopyPools - creates the worker pools, all of them send back their
+// results to resultCh and share the optional throttle.
+func newCopyPools(resultCh chan URLs, fair bool, maxUploads, maxDownloads int, throttle *errorThrottle) *copyPools {
+	if !fair && maxUploads <= 0 && maxDownloads <= 0 {
+		return &copyPools{parallel: newParallelManager(resultCh, throttle)}
+	}
+	c := &copyPools{def: newCopyPool(resultCh, maxParallelWorkers, throttle)}
+	if fair {
+		largeWorkers := runtime.NumCPU() / 2
+		if largeWorkers == 0 {
+			largeWorkers = 1
+		}
+		c.large = newCopyPool(resultCh, largeWorkers, throttle)
+	}
+	if maxUploads > 0 {
+		c.uploads = newCopyPool(resultCh, maxUploads, throttle)
+	}
+	if maxDownloads > 0 {
+		c.downloads = newCopyPool(resultCh, maxDownloads, throttle)
+	}
+	return c
+}
+
+// pool - returns the worker pool in charge of copying cpURLs.
+func (c *copyPools) pool(cpURLs URLs) *copyPool {
+	if cpURLs.SourceContent == nil || cpURLs.TargetContent == nil {
+		return c.def
+	}
+	if c.large != nil && cpURLs.SourceContent.Size >= fairLargeObjectSize {
+		return c.large
+	}
+	srcType, tgtType := cpURLs.SourceContent.URL.Type, cpURLs.TargetContent.URL.Type
+	if c.uploads != nil && srcType == fileSystem && tgtType == objectStorage {
+		return c.uploads
+	}
+	if c.downloads != nil && srcType == objectStorage && tgtType == fileSystem {
+		return c.downloads
+	}
+	return c.def
+}
+
+// queueTask - queues fn in the worker pool in charge of copying cpURLs.
+func (c *copyPools) queueTask(cpURLs URLs, fn func() URLs) {
+	if c.parallel != nil {
+		c.parallel.queueTask(fn)
+		return
+	}
+	c.pool(cpURLs).queueTask(fn)
+}
+
+// stopAndWait - waits for all the worker pools to finish their tasks.
+func (c *copyPools) stopAndWait() {
+	if c.parallel != nil {
+		c.parallel.stopAndWait()
+		return
+	}
+	for _, p := range []*copyPool{c.def, c.large, c.uploads, c.downloads} {
+		if p != nil {
+			p.stopAndWait()
+		}
+	}
+}
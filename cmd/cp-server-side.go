@@ -0,0 +1,96 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/minio/minio-go/v7/pkg/s3utils"
+)
+
+const (
+	copyMethodServerSide   = "server-side"
+	copyMethodClientStream = "client-stream"
+)
+
+// aliasRegions overrides the region configured for an alias, it is
+// populated by `cp --source-region` before any client is created.
+var aliasRegions = map[string]string{}
+
+// remoteCopyMethod tells how an object is copied between two aliases,
+// see canServerSideCopy. It is empty when either side is local.
+func remoteCopyMethod(sourceAlias, targetAlias string) string {
+	if mustGetHostConfig(sourceAlias) == nil || mustGetHostConfig(targetAlias) == nil {
+		return ""
+	}
+	if canServerSideCopy(sourceAlias, targetAlias) {
+		return copyMethodServerSide
+	}
+	return copyMethodClientStream
+}
+
+// canServerSideCopy returns true if the target of a copy can fetch the
+// object itself with CopyObject instead of the data going through mc.
+func canServerSideCopy(sourceAlias, targetAlias string) bool {
+	if sourceAlias == targetAlias {
+		return true
+	}
+	return sameProvider(mustGetHostConfig(sourceAlias), mustGetHostConfig(targetAlias))
+}
+
+// sameProvider returns true if both aliases reach the same service with
+// the same credentials, the region they are configured for may differ.
+// Regional AWS endpoints are considered as one provider since S3 copies
+// objects across the regions of a partition server side.
+func sameProvider(source, target *aliasConfigV10) bool {
+	if source == nil || target == nil {
+		return false
+	}
+	if source.AccessKey != target.AccessKey || source.SecretKey != target.SecretKey ||
+		source.SessionToken != target.SessionToken {
+		return false
+	}
+	sourceURL, e := url.Parse(source.URL)
+	if e != nil {
+		return false
+	}
+	targetURL, e := url.Parse(target.URL)
+	if e != nil {
+		return false
+	}
+	if strings.EqualFold(sourceURL.Host, targetURL.Host) {
+		return true
+	}
+	if !s3utils.IsAmazonEndpoint(*sourceURL) || !s3utils.IsAmazonEndpoint(*targetURL) {
+		return false
+	}
+	return awsPartition(*sourceURL) == awsPartition(*targetURL)
+}
+
+// awsPartition returns the AWS partition an S3 endpoint belongs to,
+// objects cannot be copied between partitions.
+func awsPartition(endpointURL url.URL) string {
+	switch {
+	case s3utils.IsAmazonGovCloudEndpoint(endpointURL):
+		return "aws-us-gov"
+	case strings.HasSuffix(endpointURL.Hostname(), ".amazonaws.com.cn"):
+		return "aws-cn"
+	}
+	return "aws"
+}
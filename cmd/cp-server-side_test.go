@@ -0,0 +1,44 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import "testing"
+
+func TestSameProvider(t *testing.T) {
+	alias := func(endpoint, accessKey string) *aliasConfigV10 {
+		return &aliasConfigV10{URL: endpoint, AccessKey: accessKey, SecretKey: "secret", API: "s3v4"}
+	}
+	testCases := []struct {
+		source   *aliasConfigV10
+		target   *aliasConfigV10
+		expected bool
+	}{
+		{alias("https://s3.us-east-1.amazonaws.com", "key"), alias("https://s3.eu-west-1.amazonaws.com", "key"), true},
+		{alias("https://s3.amazonaws.com", "key"), alias("https://s3.eu-west-1.amazonaws.com", "key"), true},
+		{alias("https://s3.us-east-1.amazonaws.com", "key"), alias("https://s3.eu-west-1.amazonaws.com", "other"), false},
+		{alias("https://s3.us-east-1.amazonaws.com", "key"), alias("https://s3.cn-north-1.amazonaws.com.cn", "key"), false},
+		{alias("https://minio.example.com", "key"), alias("https://MINIO.example.com", "key"), true},
+		{alias("https://minio.example.com", "key"), alias("https://backup.example.com", "key"), false},
+		{alias("https://minio.example.com", "key"), nil, false},
+	}
+	for i, testCase := range testCases {
+		if same := sameProvider(testCase.source, testCase.target); same != testCase.expected {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.expected, same)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"io"
+	"regexp"
+	"strings"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// plainMD5ETag matches ETags which can be the MD5 of the object content,
+// multipart objects have ETags of a different shape. ETags of objects
+// encrypted by the server have the same shape but are not their MD5.
+var plainMD5ETag = regexp.MustCompile("^[0-9a-f]{32}$")
+
+// serverSideEncrypted tells whether metadata, as returned by a stat, is
+// the one of an object encrypted with SSE-S3, SSE-KMS or SSE-C.
+func serverSideEncrypted(metadata map[string]string) bool {
+	for k := range metadata {
+		if strings.HasPrefix(strings.ToLower(k), serverEncryptionKeyPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeETag strips the quotes some servers put around ETags.
+func normalizeETag(etag string) string {
+	return strings.ToLower(strings.Trim(etag, "\""))
+}
+
+// contentMD5 returns the MD5 of an object content, taken from its ETag
+// when it is a plain MD5 or computed when the object is a local file.
+// An empty string is returned when the MD5 cannot be known cheaply.
+func contentMD5(ctx context.Context, alias string, content *ClientContent) (string, *probe.Error) {
+	if etag := normalizeETag(content.ETag); plainMD5ETag.MatchString(etag) && !serverSideEncrypted(content.Metadata) {
+		return etag, nil
+	}
+	if content.URL.Type != fileSystem {
+		return "", nil
+	}
+	return readContentMD5(ctx, alias, content)
+}
+
+// readContentMD5 returns the MD5 of an object content, computed from the
+// whole content whatever its ETag.
+func readContentMD5(ctx context.Context, alias string, content *ClientContent) (string, *probe.Error) {
+	clnt, err := newClientFromAlias(alias, content.URL.String())
+	if err != nil {
+		return "", err.Trace(content.URL.String())
+	}
+	reader, err := clnt.Get(ctx, GetOptions{})
+	if err != nil {
+		return "", err.Trace(content.URL.String())
+	}
+	defer reader.Close()
+	hasher := md5.New()
+	if _, e := io.Copy(hasher, reader); e != nil {
+		return "", probe.NewError(e).Trace(content.URL.String())
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// isSameETag reports whether the target of cpURLs already exists with the
+// same content as its source. ETags are compared first, when they cannot
+// tell, e.g. a multipart upload on one side or a local file, sizes and MD5
+// checksums are compared instead.
+func isSameETag(ctx context.Context, cpURLs URLs) (bool, *probe.Error) {
+	targetClnt, err := newClientFromAlias(cpURLs.TargetAlias, cpURLs.TargetContent.URL.String())
+	if err != nil {
+		return false, err.Trace(cpURLs.TargetContent.URL.String())
+	}
+	target, err := targetClnt.Stat(ctx, StatOptions{})
+	if err != nil {
+		// Missing target, or not accessible, copy it.
+		return false, nil
+	}
+	source := cpURLs.SourceContent
+	if source.Size != target.Size {
+		return false, nil
+	}
+	if sourceETag := normalizeETag(source.ETag); sourceETag != "" && sourceETag == normalizeETag(target.ETag) {
+		return true, nil
+	}
+
+	targetMD5, err := contentMD5(ctx, cpURLs.TargetAlias, target)
+	if err != nil || targetMD5 == "" {
+		return false, err
+	}
+	sourceMD5, err := contentMD5(ctx, cpURLs.SourceAlias, source)
+	if err != nil || sourceMD5 == "" {
+		return false, err
+	}
+	return sourceMD5 == targetMD5, nil
+}
+
+// isSameSize reports whether the target of cpURLs already exists with the
+// same size as its source, for --if-size-differs which trusts a size change
+// to signal a content change and spares the checksum comparisons.
+func isSameSize(ctx context.Context, cpURLs URLs) (bool, *probe.Error) {
+	targetClnt, err := newClientFromAlias(cpURLs.TargetAlias, cpURLs.TargetContent.URL.String())
+	if err != nil {
+		return false, err.Trace(cpURLs.TargetContent.URL.String())
+	}
+	target, err := targetClnt.Stat(ctx, StatOptions{})
+	if err != nil {
+		// Missing target, or not accessible, copy it.
+		return false, nil
+	}
+	return cpURLs.SourceContent.Size == target.Size, nil
+}
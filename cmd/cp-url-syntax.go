@@ -21,6 +21,7 @@ import (
 	"context"
 	"fmt"
 	"runtime"
+	"strings"
 	"time"
 
 	"github.com/minio/cli"
@@ -28,28 +29,36 @@ import (
 	"github.com/minio/pkg/console"
 )
 
-func checkCopySyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[string][]prefixSSEPair, isMvCmd bool) {
-	if len(cliCtx.Args()) < 2 {
+func checkCopySyntax(ctx context.Context, cliCtx *cli.Context, args []string, encKeyDB map[string][]prefixSSEPair, isMvCmd bool) {
+	if len(args) < 2 {
 		if isMvCmd {
 			cli.ShowCommandHelpAndExit(cliCtx, "mv", 1) // last argument is exit code.
 		}
 		cli.ShowCommandHelpAndExit(cliCtx, "cp", 1) // last argument is exit code.
 	}
 
-	// extract URLs.
-	URLs := cliCtx.Args()
-	if len(URLs) < 2 {
-		fatalIf(errDummy().Trace(cliCtx.Args()...), "Unable to parse source and target arguments.")
-	}
-
-	srcURLs := URLs[:len(URLs)-1]
-	tgtURL := URLs[len(URLs)-1]
+	srcURLs := args[:len(args)-1]
+	tgtURL := args[len(args)-1]
 	isRecursive := cliCtx.Bool("recursive")
 	timeRef := parseRewindFlag(cliCtx.String("rewind"))
 	versionID := cliCtx.String("version-id")
 
 	if versionID != "" && len(srcURLs) > 1 {
-		fatalIf(errDummy().Trace(cliCtx.Args()...), "Unable to pass --version flag with multiple copy sources arguments.")
+		fatalIf(errDummy().Trace(args...), "Unable to pass --version flag with multiple copy sources arguments.")
+	}
+
+	if cliCtx.Bool("versions") {
+		if !isRecursive {
+			fatalIf(errDummy().Trace(args...), "--versions can only be used with --recursive.")
+		}
+		if versionID != "" {
+			fatalIf(errDummy().Trace(args...), "--versions and --version-id cannot be used together.")
+		}
+		if cliCtx.Bool("continue") {
+			fatalIf(errDummy().Trace(args...), "--versions cannot be used with --continue.")
+		}
+	} else if cliCtx.String("exclude-versions-older-than") != "" {
+		fatalIf(errDummy().Trace(args...), "--exclude-versions-older-than can only be used with --versions.")
 	}
 
 	// Verify if source(s) exists.
@@ -86,6 +95,22 @@ func checkCopySyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[stri
 		fatalIf(errInvalidArgument().Trace(), fmt.Sprintf("Both object retention flags `--%s` and `--%s` are required.\n", rdFlag, rmFlag))
 	}
 
+	if cliCtx.String("content-type-map") != "" && !cliCtx.Bool("metadata-replace-content-type-by-extension") {
+		fatalIf(errInvalidArgument().Trace(), "--content-type-map can only be used with --metadata-replace-content-type-by-extension.")
+	}
+
+	// Fixing content-types in place copies each object onto itself.
+	if cliCtx.Bool("metadata-replace-content-type-by-extension") && len(srcURLs) == 1 && srcURLs[0] == tgtURL {
+		_, expandedURL, _ := mustExpandAlias(tgtURL)
+		if newClientURL(expandedURL).Type != objectStorage {
+			fatalIf(errInvalidArgument().Trace(tgtURL), "Content-type can only be fixed in place on object storage.")
+		}
+		if isRecursive && !strings.HasSuffix(tgtURL, "/") {
+			fatalIf(errInvalidArgument().Trace(tgtURL), "Please add a trailing `/` to `"+tgtURL+"` to fix content-types in place.")
+		}
+		return
+	}
+
 	operation := "copy"
 	if isMvCmd {
 		operation = "move"
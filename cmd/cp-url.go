@@ -158,7 +158,7 @@ func makeCopyContentTypeB(sourceAlias string, sourceContent *ClientContent, targ
 
 // SINGLE SOURCE - Type C: copy(d1..., d2) -> []copy(d1/f, d1/d2/f) -> []A
 // prepareCopyRecursiveURLTypeC - prepares target and source clientURLs for copying.
-func prepareCopyURLsTypeC(ctx context.Context, sourceURL, targetURL string, isRecursive bool, timeRef time.Time, encKeyDB map[string][]prefixSSEPair) <-chan URLs {
+func prepareCopyURLsTypeC(ctx context.Context, sourceURL, targetURL string, isRecursive, withVersions bool, timeRef time.Time, encKeyDB map[string][]prefixSSEPair) <-chan URLs {
 	// Extract alias before fiddling with the clientURL.
 	sourceAlias, _, _ := mustExpandAlias(sourceURL)
 	// Find alias and expanded clientURL.
@@ -173,13 +173,21 @@ func prepareCopyURLsTypeC(ctx context.Context, sourceURL, targetURL string, isRe
 			return
 		}
 
-		for sourceContent := range sourceClient.List(ctx, ListOptions{Recursive: isRecursive, TimeRef: timeRef, ShowDir: DirNone}) {
+		// Delete markers are listed to skip objects that were deleted
+		// at the rewind time, their older versions are not copied.
+		rewindVersions := withVersions && !timeRef.IsZero()
+		isDeleted := deletedAtRewind()
+		for sourceContent := range sourceClient.List(ctx, ListOptions{Recursive: isRecursive, WithOlderVersions: withVersions, WithDeleteMarkers: rewindVersions, TimeRef: timeRef, ShowDir: DirNone}) {
 			if sourceContent.Err != nil {
 				// Listing failed.
 				copyURLsCh <- URLs{Error: sourceContent.Err.Trace(sourceClient.GetURL().String())}
 				continue
 			}
 
+			if rewindVersions && isDeleted(sourceContent) {
+				continue
+			}
+
 			if !sourceContent.Type.IsRegular() {
 				// Source is not a regular file. Skip it for copy.
 				continue
@@ -192,6 +200,21 @@ func prepareCopyURLsTypeC(ctx context.Context, sourceURL, targetURL string, isRe
 	return copyURLsCh
 }
 
+// deletedAtRewind returns a function telling whether a listed version
+// is a delete marker or belongs to an object whose latest version, the
+// first one listed, is a delete marker.
+func deletedAtRewind() func(content *ClientContent) bool {
+	var key string
+	var deleted bool
+	return func(content *ClientContent) bool {
+		if content.URL.Path != key {
+			key = content.URL.Path
+			deleted = content.IsDeleteMarker
+		}
+		return deleted || content.IsDeleteMarker
+	}
+}
+
 // makeCopyContentTypeC - CopyURLs content for copying.
 func makeCopyContentTypeC(sourceAlias string, sourceURL ClientURL, sourceContent *ClientContent, targetAlias string, targetURL string, encKeyDB map[string][]prefixSSEPair) URLs {
 	newSourceURL := sourceContent.URL
@@ -207,12 +230,12 @@ func makeCopyContentTypeC(sourceAlias string, sourceURL ClientURL, sourceContent
 
 // MULTI-SOURCE - Type D: copy([](f|d...), d) -> []B
 // prepareCopyURLsTypeE - prepares target and source clientURLs for copying.
-func prepareCopyURLsTypeD(ctx context.Context, sourceURLs []string, targetURL string, isRecursive bool, timeRef time.Time, encKeyDB map[string][]prefixSSEPair) <-chan URLs {
+func prepareCopyURLsTypeD(ctx context.Context, sourceURLs []string, targetURL string, isRecursive, withVersions bool, timeRef time.Time, encKeyDB map[string][]prefixSSEPair) <-chan URLs {
 	copyURLsCh := make(chan URLs)
 	go func(sourceURLs []string, targetURL string, copyURLsCh chan URLs) {
 		defer close(copyURLsCh)
 		for _, sourceURL := range sourceURLs {
-			for cpURLs := range prepareCopyURLsTypeC(ctx, sourceURL, targetURL, isRecursive, timeRef, encKeyDB) {
+			for cpURLs := range prepareCopyURLsTypeC(ctx, sourceURL, targetURL, isRecursive, withVersions, timeRef, encKeyDB) {
 				copyURLsCh <- cpURLs
 			}
 		}
@@ -221,7 +244,7 @@ func prepareCopyURLsTypeD(ctx context.Context, sourceURLs []string, targetURL st
 }
 
 // prepareCopyURLs - prepares target and source clientURLs for copying.
-func prepareCopyURLs(ctx context.Context, sourceURLs []string, targetURL string, isRecursive bool, encKeyDB map[string][]prefixSSEPair, olderThan, newerThan string, timeRef time.Time, versionID string) chan URLs {
+func prepareCopyURLs(ctx context.Context, sourceURLs []string, targetURL string, isRecursive bool, encKeyDB map[string][]prefixSSEPair, olderThan, newerThan string, timeRef time.Time, versionID string, withVersions bool, excludeVersionsOlderThan string) chan URLs {
 	copyURLsCh := make(chan URLs)
 	go func(sourceURLs []string, targetURL string, copyURLsCh chan URLs, encKeyDB map[string][]prefixSSEPair, timeRef time.Time) {
 		defer close(copyURLsCh)
@@ -234,11 +257,11 @@ func prepareCopyURLs(ctx context.Context, sourceURLs []string, targetURL string,
 		case copyURLsTypeB:
 			copyURLsCh <- prepareCopyURLsTypeB(ctx, sourceURLs[0], cpVersion, targetURL, encKeyDB)
 		case copyURLsTypeC:
-			for cURLs := range prepareCopyURLsTypeC(ctx, sourceURLs[0], targetURL, isRecursive, timeRef, encKeyDB) {
+			for cURLs := range prepareCopyURLsTypeC(ctx, sourceURLs[0], targetURL, isRecursive, withVersions, timeRef, encKeyDB) {
 				copyURLsCh <- cURLs
 			}
 		case copyURLsTypeD:
-			for cURLs := range prepareCopyURLsTypeD(ctx, sourceURLs, targetURL, isRecursive, timeRef, encKeyDB) {
+			for cURLs := range prepareCopyURLsTypeD(ctx, sourceURLs, targetURL, isRecursive, withVersions, timeRef, encKeyDB) {
 				copyURLsCh <- cURLs
 			}
 		default:
@@ -260,6 +283,12 @@ func prepareCopyURLs(ctx context.Context, sourceURLs []string, targetURL string,
 				continue
 			}
 
+			// Skip noncurrent versions older than --exclude-versions-older-than if specified
+			if excludeVersionsOlderThan != "" && cpURLs.SourceContent != nil && !cpURLs.SourceContent.IsLatest &&
+				isOlder(cpURLs.SourceContent.Time, excludeVersionsOlderThan) {
+				continue
+			}
+
 			finalCopyURLsCh <- cpURLs
 		}
 	}()
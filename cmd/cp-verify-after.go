@@ -0,0 +1,100 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"path/filepath"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// verifyChunkSize is the size of the chunks compared by sameContent.
+const verifyChunkSize = 1 << 20
+
+// sameContent compares two streams byte for byte.
+func sameContent(a, b io.Reader) (bool, error) {
+	bufA := make([]byte, verifyChunkSize)
+	bufB := make([]byte, verifyChunkSize)
+	for {
+		nA, errA := io.ReadFull(a, bufA)
+		nB, errB := io.ReadFull(b, bufB)
+		if errA != nil && errA != io.EOF && errA != io.ErrUnexpectedEOF {
+			return false, errA
+		}
+		if errB != nil && errB != io.EOF && errB != io.ErrUnexpectedEOF {
+			return false, errB
+		}
+		if nA != nB || !bytes.Equal(bufA[:nA], bufB[:nB]) {
+			return false, nil
+		}
+		// A short read means the end of both streams was reached.
+		if errA != nil || errB != nil {
+			return errA != nil && errB != nil, nil
+		}
+	}
+}
+
+// verifyCopy downloads the target version uploaded for cpURLs and
+// compares it with its source, so that a concurrent upload of the same
+// object is not verified nor removed in its place. The uploaded version
+// is removed on mismatch when asked to.
+func verifyCopy(ctx context.Context, cpURLs URLs, encKeyDB map[string][]prefixSSEPair, removeOnMismatch bool) *probe.Error {
+	sourceURL := cpURLs.SourceContent.URL.String()
+	targetURL := cpURLs.TargetContent.URL.String()
+	sourcePath := filepath.ToSlash(filepath.Join(cpURLs.SourceAlias, cpURLs.SourceContent.URL.Path))
+	targetPath := filepath.ToSlash(filepath.Join(cpURLs.TargetAlias, cpURLs.TargetContent.URL.Path))
+
+	source, _, err := getSourceStream(ctx, cpURLs.SourceAlias, sourceURL, cpURLs.SourceContent.VersionID, false,
+		getSSE(sourcePath, encKeyDB[cpURLs.SourceAlias]), false)
+	if err != nil {
+		return err.Trace(sourceURL)
+	}
+	defer source.Close()
+
+	target, _, err := getSourceStream(ctx, cpURLs.TargetAlias, targetURL, cpURLs.targetVersionID, false,
+		getSSE(targetPath, encKeyDB[cpURLs.TargetAlias]), false)
+	if err != nil {
+		return err.Trace(targetURL)
+	}
+	defer target.Close()
+
+	same, e := sameContent(source, target)
+	if e != nil {
+		return probe.NewError(e).Trace(sourceURL, targetURL)
+	}
+	if same {
+		return nil
+	}
+
+	if removeOnMismatch {
+		clnt, err := newClientFromAlias(cpURLs.TargetAlias, targetURL)
+		if err != nil {
+			return err.Trace(targetURL)
+		}
+		contentCh := make(chan *ClientContent, 1)
+		contentCh <- &ClientContent{URL: cpURLs.TargetContent.URL, VersionID: cpURLs.targetVersionID}
+		close(contentCh)
+		for err := range clnt.Remove(ctx, false, false, false, contentCh) {
+			errorIf(err.Trace(targetURL), "Unable to remove `%s` after a failed verification.", targetPath)
+		}
+	}
+	return errVerifyMismatch(targetPath).Trace(sourceURL, targetURL)
+}
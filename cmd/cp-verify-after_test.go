@@ -0,0 +1,53 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSameContent(t *testing.T) {
+	large := strings.Repeat("x", 3*verifyChunkSize+7)
+	testCases := []struct {
+		a, b     string
+		expected bool
+	}{
+		{"", "", true},
+		{"hello", "hello", true},
+		{"hello", "hellp", false},
+		{"hello", "hello!", false},
+		{"hello!", "hello", false},
+		{large, large, true},
+		{large, large[:len(large)-1] + "y", false},
+		{large, large + "z", false},
+		{large[:verifyChunkSize], large[:verifyChunkSize], true},
+		{large[:verifyChunkSize], large[:verifyChunkSize+1], false},
+	}
+
+	for i, testCase := range testCases {
+		same, e := sameContent(bytes.NewReader([]byte(testCase.a)), strings.NewReader(testCase.b))
+		if e != nil {
+			t.Fatalf("Test %d: unexpected error: %v", i+1, e)
+		}
+		if same != testCase.expected {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.expected, same)
+		}
+	}
+}
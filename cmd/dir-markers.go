@@ -0,0 +1,45 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"strings"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// isDirMarker - reports whether the content is a pseudo-directory object,
+// some S3 tools create them to mimic folders. A directory marker is a
+// zero-byte object whose key ends with "/".
+func isDirMarker(content *ClientContent) bool {
+	if content == nil || content.Size != 0 {
+		return false
+	}
+	return strings.HasSuffix(content.URL.Path, "/")
+}
+
+// makeDirMarkerTarget - creates the real directory standing for a
+// directory marker on a filesystem target.
+func makeDirMarkerTarget(ctx context.Context, targetAlias, targetURL string) *probe.Error {
+	clnt, err := newClientFromAlias(targetAlias, targetURL)
+	if err != nil {
+		return err.Trace(targetAlias, targetURL)
+	}
+	return clnt.MakeBucket(ctx, "", true, false)
+}
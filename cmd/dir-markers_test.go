@@ -0,0 +1,42 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import "testing"
+
+func TestIsDirMarker(t *testing.T) {
+	testCases := []struct {
+		path     string
+		size     int64
+		expected bool
+	}{
+		{"/bucket/folder/", 0, true},
+		{"/bucket/folder/", 10, false},
+		{"/bucket/folder", 0, false},
+		{"/bucket/folder/object", 0, false},
+	}
+	for i, testCase := range testCases {
+		content := &ClientContent{URL: *newClientURL(testCase.path), Size: testCase.size}
+		if got := isDirMarker(content); got != testCase.expected {
+			t.Fatalf("Test %d: expected %v for %q, got %v", i+1, testCase.expected, testCase.path, got)
+		}
+	}
+	if isDirMarker(nil) {
+		t.Fatalf("Test %d: expected false for nil content", len(testCases)+1)
+	}
+}
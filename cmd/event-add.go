@@ -19,11 +19,14 @@ package cmd
 
 import (
 	"context"
+	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/fatih/color"
 	"github.com/minio/cli"
 	json "github.com/minio/colorjson"
+	"github.com/minio/madmin-go"
 	"github.com/minio/mc/pkg/probe"
 	"github.com/minio/pkg/console"
 )
@@ -47,9 +50,32 @@ var (
 			Name:  "ignore-existing, p",
 			Usage: "ignore if event already exists",
 		},
+		cli.StringFlag{
+			Name:  "queue-dir",
+			Usage: "persist undelivered events of a MinIO target in this server directory, and retry them until the target is back",
+		},
+		cli.IntFlag{
+			Name:  "queue-limit",
+			Usage: "maximum number of undelivered events kept in --queue-dir",
+		},
 	}
 )
 
+// notifyQueueTargets - MinIO notification target types which can queue
+// undelivered events.
+var notifyQueueTargets = map[string]bool{
+	"amqp":          true,
+	"elasticsearch": true,
+	"kafka":         true,
+	"mqtt":          true,
+	"mysql":         true,
+	"nats":          true,
+	"nsq":           true,
+	"postgresql":    true,
+	"redis":         true,
+	"webhook":       true,
+}
+
 var eventAddCmd = cli.Command{
 	Name:         "add",
 	Usage:        "add a new bucket notification",
@@ -78,6 +104,15 @@ EXAMPLES:
 
   4. Enable bucket notification for Replication and ILM transition events to a specific ARN
     {{.Prompt}} {{.HelpName}} myminio/mysourcebucket arn:aws:sqs:us-west-2:444455556666:your-queue --event replica,ilm
+
+  5. Enable bucket notification to a MinIO webhook target, queuing up to 10000 events while it is unavailable
+    {{.Prompt}} {{.HelpName}} myminio/mybucket arn:minio:sqs::1:webhook --queue-dir /var/minio/events --queue-limit 10000
+
+QUEUED DELIVERY:
+  --queue-dir and --queue-limit update the configuration of the MinIO notification target named by
+  ARN, events it fails to receive are stored in the queue directory on the server and retried until
+  delivered. The queue is shared by all the buckets notifying that target. Existing MinIO servers may
+  need a restart to apply it.
 `,
 }
 
@@ -86,15 +121,51 @@ func checkEventAddSyntax(ctx *cli.Context) {
 	if len(ctx.Args()) != 2 {
 		cli.ShowCommandHelpAndExit(ctx, "add", 1) // last argument is exit code
 	}
+	if ctx.IsSet("queue-limit") && ctx.Int("queue-limit") <= 0 {
+		fatalIf(errInvalidArgument().Trace(ctx.Args()...), "--queue-limit must be a positive number.")
+	}
+	if ctx.IsSet("queue-limit") && !ctx.IsSet("queue-dir") {
+		fatalIf(errInvalidArgument().Trace(ctx.Args()...), "--queue-limit requires --queue-dir.")
+	}
+}
+
+// notifyTargetQueueConfig - returns the server config setting the queue of
+// the MinIO notification target identified by arn, e.g.
+// `notify_webhook:1 queue_dir="/var/events" queue_limit="1000"`.
+func notifyTargetQueueConfig(arn, queueDir string, queueLimit int) (string, *probe.Error) {
+	// arn:minio:sqs:region:id:type
+	fields := strings.Split(arn, ":")
+	if len(fields) != 6 || fields[0] != "arn" || fields[1] != "minio" || fields[2] != "sqs" || fields[4] == "" {
+		return "", errInvalidArgument().Trace(arn)
+	}
+	id, targetType := fields[4], fields[5]
+	if !notifyQueueTargets[targetType] {
+		return "", errInvalidArgument().Trace(arn, targetType)
+	}
+
+	target := "notify_" + targetType
+	// The default target of a type has no name.
+	if id != "_" {
+		target += madmin.SubSystemSeparator + id
+	}
+	config := fmt.Sprintf("%s queue_dir=%s", target, strconv.Quote(queueDir))
+	if queueLimit > 0 {
+		config += fmt.Sprintf(" queue_limit=%s", strconv.Quote(strconv.Itoa(queueLimit)))
+	}
+	return config, nil
 }
 
 // eventAddMessage container
 type eventAddMessage struct {
-	ARN    string   `json:"arn"`
-	Event  []string `json:"event"`
-	Prefix string   `json:"prefix"`
-	Suffix string   `json:"suffix"`
-	Status string   `json:"status"`
+	ARN        string   `json:"arn"`
+	Event      []string `json:"event"`
+	Prefix     string   `json:"prefix"`
+	Suffix     string   `json:"suffix"`
+	QueueDir   string   `json:"queueDir,omitempty"`
+	QueueLimit int      `json:"queueLimit,omitempty"`
+	Restart    bool     `json:"restart,omitempty"`
+	Status     string   `json:"status"`
+	alias      string
 }
 
 // JSON jsonified update message.
@@ -107,6 +178,13 @@ func (u eventAddMessage) JSON() string {
 
 func (u eventAddMessage) String() string {
 	msg := console.Colorize("Event", "Successfully added "+u.ARN)
+	if u.QueueDir != "" {
+		msg += console.Colorize("Event", ", undelivered events are queued in `"+u.QueueDir+"`")
+	}
+	if u.Restart {
+		msg += console.Colorize("Event", fmt.Sprintf("\nPlease restart your server '%s' to apply the queue settings.",
+			color.RedString("mc admin service restart %s", u.alias)))
+	}
 	return msg
 }
 
@@ -137,13 +215,33 @@ func mainEventAdd(cliCtx *cli.Context) error {
 		fatalIf(errDummy().Trace(), "The provided url doesn't point to a S3 server.")
 	}
 
+	// Set up the target queue first, so that no event is lost once
+	// the notification is enabled.
+	var restart bool
+	queueDir := cliCtx.String("queue-dir")
+	queueLimit := cliCtx.Int("queue-limit")
+	alias, _ := url2Alias(path)
+	if queueDir != "" {
+		config, err := notifyTargetQueueConfig(arn, queueDir, queueLimit)
+		fatalIf(err, "Queued delivery requires the ARN of a MinIO notification target, e.g. `arn:minio:sqs::1:webhook`.")
+		adminClient, err := newAdminClient(alias)
+		fatalIf(err, "Unable to initialize admin connection.")
+		var e error
+		restart, e = adminClient.SetConfigKV(ctx, config)
+		fatalIf(probe.NewError(e).Trace(config), "Unable to configure the queue of the notification target.")
+	}
+
 	err = s3Client.AddNotificationConfig(ctx, arn, event, prefix, suffix, ignoreExisting)
 	fatalIf(err, "Unable to enable notification on the specified bucket.")
 	printMsg(eventAddMessage{
-		ARN:    arn,
-		Event:  event,
-		Prefix: prefix,
-		Suffix: suffix,
+		ARN:        arn,
+		Event:      event,
+		Prefix:     prefix,
+		Suffix:     suffix,
+		QueueDir:   queueDir,
+		QueueLimit: queueLimit,
+		Restart:    restart,
+		alias:      alias,
 	})
 
 	return nil
@@ -0,0 +1,48 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import "testing"
+
+func TestNotifyTargetQueueConfig(t *testing.T) {
+	testCases := []struct {
+		arn        string
+		queueDir   string
+		queueLimit int
+		expected   string
+		ok         bool
+	}{
+		{"arn:minio:sqs::1:webhook", "/var/events", 0, `notify_webhook:1 queue_dir="/var/events"`, true},
+		{"arn:minio:sqs:us-east-1:primary:kafka", "/var/events", 1000, `notify_kafka:primary queue_dir="/var/events" queue_limit="1000"`, true},
+		{"arn:minio:sqs::_:amqp", "/var/my events", 0, `notify_amqp queue_dir="/var/my events"`, true},
+		{"arn:aws:sqs:us-west-2:444455556666:your-queue", "/var/events", 0, "", false},
+		{"arn:minio:sqs::1:unknown", "/var/events", 0, "", false},
+		{"arn:minio:sqs:::webhook", "/var/events", 0, "", false},
+		{"webhook", "/var/events", 0, "", false},
+	}
+
+	for i, testCase := range testCases {
+		config, err := notifyTargetQueueConfig(testCase.arn, testCase.queueDir, testCase.queueLimit)
+		if (err == nil) != testCase.ok {
+			t.Fatalf("Test %d: expected ok %v, got error %v", i+1, testCase.ok, err)
+		}
+		if config != testCase.expected {
+			t.Fatalf("Test %d: expected %s, got %s", i+1, testCase.expected, config)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	humanize "github.com/dustin/go-humanize"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+// maxDuplicateChecksums bounds the objects read at once by --duplicate
+// to compute their checksum.
+const maxDuplicateChecksums = 8
+
+// duplicateCandidate - a matching object and its listed content.
+type duplicateCandidate struct {
+	msg      contentMessage
+	content  *ClientContent
+	checksum string
+}
+
+// duplicateMessage container for a set of objects with the same content.
+type duplicateMessage struct {
+	Status   string   `json:"status"`
+	Checksum string   `json:"checksum"`
+	Size     int64    `json:"size"`
+	Keys     []string `json:"keys"`
+}
+
+// String colorized duplicate message.
+func (d duplicateMessage) String() string {
+	var sb strings.Builder
+	sb.WriteString(console.Colorize("Duplicate", fmt.Sprintf("%d objects of %s with MD5 %s:", len(d.Keys), humanize.IBytes(uint64(d.Size)), d.Checksum)))
+	for _, key := range d.Keys {
+		sb.WriteString("\n  " + console.Colorize("Find", key))
+	}
+	return sb.String()
+}
+
+// JSON jsonified duplicate message.
+func (d duplicateMessage) JSON() string {
+	d.Status = "success"
+	duplicateMessageBytes, e := json.MarshalIndent(d, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(duplicateMessageBytes)
+}
+
+// sameSizeCandidates returns the candidates sharing their size with at
+// least another one, the only ones which can have duplicates.
+func sameSizeCandidates(candidates []duplicateCandidate) []duplicateCandidate {
+	sizes := make(map[int64]int)
+	for _, candidate := range candidates {
+		sizes[candidate.msg.Size]++
+	}
+	var sameSize []duplicateCandidate
+	for _, candidate := range candidates {
+		if sizes[candidate.msg.Size] > 1 {
+			sameSize = append(sameSize, candidate)
+		}
+	}
+	return sameSize
+}
+
+// groupDuplicates returns the sets of candidates with the same size and
+// checksum, sorted by checksum, candidates without checksum are ignored.
+func groupDuplicates(candidates []duplicateCandidate) []duplicateMessage {
+	type duplicateKey struct {
+		size     int64
+		checksum string
+	}
+	groups := make(map[duplicateKey][]string)
+	for _, candidate := range candidates {
+		if candidate.checksum == "" {
+			continue
+		}
+		key := duplicateKey{candidate.msg.Size, candidate.checksum}
+		groups[key] = append(groups[key], candidate.msg.Key)
+	}
+	var duplicates []duplicateMessage
+	for key, keys := range groups {
+		if len(keys) < 2 {
+			continue
+		}
+		sort.Strings(keys)
+		duplicates = append(duplicates, duplicateMessage{Checksum: key.checksum, Size: key.size, Keys: keys})
+	}
+	sort.Slice(duplicates, func(i, j int) bool {
+		return duplicates[i].Checksum < duplicates[j].Checksum
+	})
+	return duplicates
+}
+
+// forEachCandidate calls fn for the candidates at indexes, a few at a time.
+func forEachCandidate(indexes []int, fn func(i int)) {
+	var wg sync.WaitGroup
+	limitCh := make(chan struct{}, maxDuplicateChecksums)
+	for _, i := range indexes {
+		wg.Add(1)
+		limitCh <- struct{}{}
+		go func(i int) {
+			defer func() {
+				<-limitCh
+				wg.Done()
+			}()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
+
+// statEncrypted tells whether the object of content is encrypted by the
+// server, listings do not carry the encryption headers.
+func statEncrypted(ctx context.Context, alias string, content *ClientContent) (bool, *probe.Error) {
+	clnt, err := newClientFromAlias(alias, content.URL.String())
+	if err != nil {
+		return false, err.Trace(content.URL.String())
+	}
+	stat, err := clnt.Stat(ctx, StatOptions{versionID: content.VersionID})
+	if err != nil {
+		return false, err.Trace(content.URL.String())
+	}
+	return serverSideEncrypted(stat.Metadata), nil
+}
+
+// findDuplicates prints the sets of candidates with the same content. The
+// plain MD5 ETags of objects not encrypted by the server are trusted, the
+// other objects with the size of another one are read to compute their MD5.
+func findDuplicates(ctxCtx context.Context, ctx *findContext, candidates []duplicateCandidate) error {
+	candidates = sameSizeCandidates(candidates)
+
+	var retErr error
+	var mu sync.Mutex
+	fail := func(err *probe.Error, msg string) {
+		errorIf(err, msg)
+		mu.Lock()
+		retErr = exitStatus(globalErrorExitStatus)
+		mu.Unlock()
+	}
+
+	var toStat []int
+	for i, candidate := range candidates {
+		if plainMD5ETag.MatchString(normalizeETag(candidate.content.ETag)) && candidate.content.URL.Type == objectStorage {
+			toStat = append(toStat, i)
+		}
+	}
+	encrypted := make([]bool, len(candidates))
+	forEachCandidate(toStat, func(i int) {
+		isEncrypted, err := statEncrypted(ctxCtx, ctx.targetAlias, candidates[i].content)
+		if err != nil {
+			fail(err.Trace(candidates[i].msg.Key), "Unable to get the encryption of `"+candidates[i].msg.Key+"`.")
+			return
+		}
+		encrypted[i] = isEncrypted
+	})
+
+	var toRead []int
+	var toReadSize int64
+	for i, candidate := range candidates {
+		if etag := normalizeETag(candidate.content.ETag); plainMD5ETag.MatchString(etag) && !encrypted[i] {
+			candidates[i].checksum = etag
+			continue
+		}
+		toRead = append(toRead, i)
+		toReadSize += candidate.msg.Size
+	}
+	if len(toRead) > 0 && !globalQuiet && !globalJSON {
+		console.Infoln(fmt.Sprintf("Reading %d objects (%s) without a usable checksum to compute their MD5.",
+			len(toRead), humanize.IBytes(uint64(toReadSize))))
+	}
+
+	forEachCandidate(toRead, func(i int) {
+		checksum, err := readContentMD5(ctxCtx, ctx.targetAlias, candidates[i].content)
+		if err != nil {
+			fail(err.Trace(candidates[i].msg.Key), "Unable to compute the checksum of `"+candidates[i].msg.Key+"`.")
+			return
+		}
+		candidates[i].checksum = checksum
+	})
+
+	for _, duplicate := range groupDuplicates(candidates) {
+		if ctx.relative {
+			for i, key := range duplicate.Keys {
+				duplicate.Keys[i] = relativeKey(key, ctx.targetURL, ctx.clnt.GetURL().Separator)
+			}
+		}
+		printMsg(duplicate)
+	}
+	return retErr
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGroupDuplicates(t *testing.T) {
+	candidate := func(key string, size int64, checksum string) duplicateCandidate {
+		return duplicateCandidate{msg: contentMessage{Key: key, Size: size}, checksum: checksum}
+	}
+	candidates := []duplicateCandidate{
+		candidate("s3/bucket/a", 6, "b1946ac92492d2347c6235b4d2611184"),
+		candidate("s3/bucket/b", 3, "764efa883dda1e11db47671c4a3bbd9e"),
+		candidate("s3/bucket/x/a", 6, "b1946ac92492d2347c6235b4d2611184"),
+		candidate("s3/bucket/c", 6, "591785b794601e212b260e25925636fd"),
+		candidate("s3/bucket/d", 6, ""),
+		candidate("s3/bucket/e", 10, "0f0e3fe8f7b5a1a0e9f8d4b9e7ab1b06"),
+		candidate("s3/bucket/f", 10, "0f0e3fe8f7b5a1a0e9f8d4b9e7ab1b06"),
+		candidate("s3/bucket/g", 10, "0f0e3fe8f7b5a1a0e9f8d4b9e7ab1b06"),
+	}
+
+	sameSize := sameSizeCandidates(candidates)
+	if len(sameSize) != len(candidates)-1 {
+		t.Fatalf("Expected all candidates but the 3 bytes one, got %d", len(sameSize))
+	}
+
+	expected := []duplicateMessage{
+		{Checksum: "0f0e3fe8f7b5a1a0e9f8d4b9e7ab1b06", Size: 10, Keys: []string{"s3/bucket/e", "s3/bucket/f", "s3/bucket/g"}},
+		{Checksum: "b1946ac92492d2347c6235b4d2611184", Size: 6, Keys: []string{"s3/bucket/a", "s3/bucket/x/a"}},
+	}
+	if duplicates := groupDuplicates(sameSize); !reflect.DeepEqual(duplicates, expected) {
+		t.Fatalf("Expected %v, got %v", expected, duplicates)
+	}
+}
+
+func TestServerSideEncrypted(t *testing.T) {
+	testCases := []struct {
+		metadata  map[string]string
+		encrypted bool
+	}{
+		{nil, false},
+		{map[string]string{"Content-Type": "text/plain"}, false},
+		{map[string]string{"X-Amz-Server-Side-Encryption": "AES256"}, true},
+		{map[string]string{"X-Amz-Server-Side-Encryption": "aws:kms", "X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id": "my-key"}, true},
+		{map[string]string{"X-Amz-Server-Side-Encryption-Customer-Algorithm": "AES256"}, true},
+	}
+
+	for i, testCase := range testCases {
+		if encrypted := serverSideEncrypted(testCase.metadata); encrypted != testCase.encrypted {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.encrypted, encrypted)
+		}
+	}
+}
@@ -52,6 +52,10 @@ var (
 			Name:  "older-than",
 			Usage: "match all objects older than L days, M hours and N minutes",
 		},
+		cli.StringFlag{
+			Name:  "mtime",
+			Usage: "match all objects modified N days ago, more than (+N) or less than (-N) N days ago",
+		},
 		cli.StringFlag{
 			Name:  "path",
 			Usage: "match directory names matching wildcard pattern",
@@ -80,6 +84,14 @@ var (
 			Name:  "watch",
 			Usage: "monitor a specified path for newly created object(s)",
 		},
+		cli.StringFlag{
+			Name:  "output",
+			Usage: "print matching objects as delimited rows with a header line, one of 'csv' or 'tsv'",
+		},
+		cli.BoolFlag{
+			Name:  "duplicate",
+			Usage: "print the sets of matching objects with the same content (see DUPLICATES)",
+		},
 	}
 )
 
@@ -89,7 +101,7 @@ var findCmd = cli.Command{
 	Action:       mainFind,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        append(findFlags, globalFlags...),
+	Flags:        append(append(append(findFlags, timeFormatFlags...), keyPathFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -109,6 +121,10 @@ UNITS
   --older-than, --newer-than flags accept the string for days, hours and minutes 
   i.e. 1d2h30m states 1 day, 2 hours and 30 minutes.
 
+  --mtime flag accepts days as in unix find, the object age is rounded down to
+  whole days i.e. "+7" matches objects modified more than 7 days ago, "-1" matches
+  objects modified less than a day ago and "2" matches objects modified 2 days ago.
+
 FORMAT
   Support string substitutions with special interpretations for following keywords.
   Keywords supported if target is filesystem or object storage:
@@ -117,7 +133,7 @@ FORMAT
      {base} --> Substitutes to basename of path.
      {dir}  --> Substitutes to dirname of the path.
      {size} --> Substitutes to object size of the path.
-     {time} --> Substitutes to object modified time of the path.
+     {time} --> Substitutes to object modified time of the path, see --time-format and --tz.
 
   Keywords supported if target is object storage:
 
@@ -154,6 +170,28 @@ EXAMPLES:
 
   10. List all objects up to 3 levels sub-directory deep under "s3/bucket".
       {{.Prompt}} {{.HelpName}} s3/bucket --maxdepth 3
+
+  11. Find all objects modified more than 7 days ago under "s3/bucket".
+      {{.Prompt}} {{.HelpName}} s3/bucket --mtime +7
+
+  12. Export all objects larger than 1 GB under "s3/bucket" as TSV.
+      {{.Prompt}} {{.HelpName}} s3/bucket --larger 1GB --output tsv > large-objects.tsv
+
+  13. Print the modification time of all objects under "s3/bucket" as unix timestamps.
+      {{.Prompt}} {{.HelpName}} s3/bucket --time-format unix --print "{time} {}"
+
+  14. Find all jpg images under "s3/bucket/photos", printing their keys relative to "s3/bucket/photos".
+      {{.Prompt}} {{.HelpName}} s3/bucket/photos --name "*.jpg" --relative
+
+  15. Find the objects with the same content under "s3/bucket/photos", to reclaim space.
+      {{.Prompt}} {{.HelpName}} s3/bucket/photos --duplicate
+
+DUPLICATES:
+  --duplicate groups the matching objects by size, then by MD5 checksum. The checksum is taken from the
+  ETag when it is a plain MD5, the objects uploaded in parts, encrypted or on a local filesystem are read
+  in full to compute it, which can be costly. Only the objects with the size of another one are read,
+  those with a plain MD5 ETag are first checked for server side encryption, SSE-S3 and SSE-KMS ETags
+  are not the MD5 of the content.
 `,
 }
 
@@ -183,6 +221,15 @@ func checkFindSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[stri
 			fatalIf(err.Trace(url), "Unable to stat `"+url+"`.")
 		}
 	}
+
+	if cliCtx.String("output") != "" && (globalJSON || cliCtx.String("exec") != "" || cliCtx.String("print") != "") {
+		fatalIf(errInvalidArgument().Trace(args...), "--output cannot be used with --json, --exec or --print.")
+	}
+
+	if cliCtx.Bool("duplicate") && (cliCtx.Bool("watch") || cliCtx.String("exec") != "" || cliCtx.String("print") != "" ||
+		cliCtx.String("output") != "" || cliCtx.IsSet("maxdepth")) {
+		fatalIf(errInvalidArgument().Trace(args...), "--duplicate cannot be used with --watch, --exec, --print, --output or --maxdepth.")
+	}
 }
 
 // Find context is container to hold all parsed input arguments,
@@ -199,9 +246,14 @@ type findContext struct {
 	printFmt      string
 	olderThan     string
 	newerThan     string
+	mtime         *findMtime
 	largerSize    uint64
 	smallerSize   uint64
 	watch         bool
+	output        *tabularWriter
+	timeFormat    listTimeFormat
+	relative      bool
+	duplicate     bool
 
 	// Internal values
 	targetAlias   string
@@ -218,6 +270,7 @@ func mainFind(cliCtx *cli.Context) error {
 	// Additional command specific theme customization.
 	console.SetColor("Find", color.New(color.FgGreen, color.Bold))
 	console.SetColor("FindExecErr", color.New(color.FgRed, color.Italic, color.Bold))
+	console.SetColor("Duplicate", color.New(color.FgYellow, color.Bold))
 
 	// Parse encryption keys per command.
 	encKeyDB, err := getEncKeys(cliCtx)
@@ -259,6 +312,18 @@ func mainFind(cliCtx *cli.Context) error {
 		fatalIf(probe.NewError(e).Trace(cliCtx.String("smaller")), "Unable to parse input bytes.")
 	}
 
+	var mtime *findMtime
+	if cliCtx.String("mtime") != "" {
+		mtime, err = parseFindMtime(cliCtx.String("mtime"))
+		fatalIf(err.Trace(cliCtx.String("mtime")), "Unable to parse mtime.")
+	}
+
+	// Keys are full aliased paths unless --relative.
+	_, relative := keyPathFromContext(cliCtx)
+
+	output, err := newTabularWriter(cliCtx.String("output"), contentColumns(false, false))
+	fatalIf(err.Trace(cliCtx.String("output")), "--output accepts only 'csv' or 'tsv'.")
+
 	targetAlias, _, hostCfg, err := expandAlias(args[0])
 	fatalIf(err.Trace(args[0]), "Unable to expand alias.")
 
@@ -278,9 +343,14 @@ func mainFind(cliCtx *cli.Context) error {
 		ignorePattern: cliCtx.String("ignore"),
 		olderThan:     olderThan,
 		newerThan:     newerThan,
+		mtime:         mtime,
 		largerSize:    largerSize,
 		smallerSize:   smallerSize,
 		watch:         cliCtx.Bool("watch"),
+		output:        output,
+		timeFormat:    timeFormatFromContext(cliCtx),
+		relative:      relative,
+		duplicate:     cliCtx.Bool("duplicate"),
 		targetAlias:   targetAlias,
 		targetURL:     args[0],
 		targetFullURL: targetFullURL,
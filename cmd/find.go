@@ -165,9 +165,10 @@ func watchFind(ctxCtx context.Context, ctx *findContext) {
 				}
 
 				find(ctxCtx, ctx, contentMessage{
-					Key:  getAliasedPath(ctx, event.Path),
-					Time: time,
-					Size: event.Size,
+					Key:        getAliasedPath(ctx, event.Path),
+					Time:       time,
+					Size:       event.Size,
+					timeFormat: ctx.timeFormat,
 				})
 			}
 		case err, ok := <-watchObj.Errors():
@@ -233,9 +234,19 @@ func find(ctxCtx context.Context, ctx *findContext, fileContent contentMessage)
 		execFind(stringsReplace(ctxCtx, ctx.execCmd, fileContent))
 		return
 	}
+	if ctx.relative {
+		fileContent.Key = relativeKey(fileContent.Key, ctx.targetURL, ctx.clnt.GetURL().Separator)
+		if fileContent.Key == "" {
+			return
+		}
+	}
 	if ctx.printFmt != "" {
 		fileContent.Key = stringsReplace(ctxCtx, ctx.printFmt, fileContent)
 	}
+	if ctx.output != nil {
+		ctx.output.write(contentRow(fileContent, false, false))
+		return
+	}
 	printMsg(findMessage{fileContent})
 }
 
@@ -248,6 +259,7 @@ func doFind(ctxCtx context.Context, ctx *findContext) error {
 	defer watchFind(ctxCtx, ctx)
 
 	var prevKeyName string
+	var duplicateCandidates []duplicateCandidate
 
 	// iterate over all content which is within the given directory
 	for content := range ctx.clnt.List(globalContext, ListOptions{Recursive: true, ShowDir: DirFirst}) {
@@ -276,9 +288,12 @@ func doFind(ctxCtx context.Context, ctx *findContext) error {
 
 		fileKeyName := getAliasedPath(ctx, content.URL.String())
 		fileContent := contentMessage{
-			Key:  fileKeyName,
-			Time: content.Time.Local(),
-			Size: content.Size,
+			Key:          fileKeyName,
+			Time:         content.Time.Local(),
+			Size:         content.Size,
+			ETag:         strings.Trim(content.ETag, "\""),
+			storageClass: content.StorageClass,
+			timeFormat:   ctx.timeFormat,
 		}
 
 		// Match the incoming content, didn't match return.
@@ -288,18 +303,39 @@ func doFind(ctxCtx context.Context, ctx *findContext) error {
 
 		prevKeyName = fileKeyName
 
+		if ctx.duplicate {
+			if !content.Type.IsDir() {
+				duplicateCandidates = append(duplicateCandidates, duplicateCandidate{msg: fileContent, content: content})
+			}
+			continue
+		}
+
 		// proceed to either exec, format the output string.
 		if ctx.execCmd != "" {
 			execFind(stringsReplace(ctxCtx, ctx.execCmd, fileContent))
 			continue
 		}
+		if ctx.relative {
+			fileContent.Key = relativeKey(fileContent.Key, ctx.targetURL, ctx.clnt.GetURL().Separator)
+			if fileContent.Key == "" {
+				continue
+			}
+		}
 		if ctx.printFmt != "" {
 			fileContent.Key = stringsReplace(ctxCtx, ctx.printFmt, fileContent)
 		}
+		if ctx.output != nil {
+			ctx.output.write(contentRow(fileContent, false, false))
+			continue
+		}
 
 		printMsg(findMessage{fileContent})
 	}
 
+	if ctx.duplicate {
+		return findDuplicates(ctxCtx, ctx, duplicateCandidates)
+	}
+
 	// Success, notice watch will execute in defer only if enabled and this call
 	// will return after watch is canceled.
 	return nil
@@ -351,12 +387,12 @@ func stringsReplace(ctx context.Context, args string, fileContent contentMessage
 
 	// replace all instances of {time}
 	if strings.Contains(str, "{time}") {
-		str = strings.Replace(str, "{time}", fileContent.Time.Format(printDate), -1)
+		str = strings.Replace(str, "{time}", fileContent.timeFormat.format(fileContent.Time), -1)
 	}
 
 	// replace all instances of {"time"}
 	if strings.Contains(str, `{"time"}`) {
-		str = strings.Replace(str, `{"time"}`, strconv.Quote(fileContent.Time.Format(printDate)), -1)
+		str = strings.Replace(str, `{"time"}`, strconv.Quote(fileContent.timeFormat.format(fileContent.Time)), -1)
 	}
 
 	// replace all instances of {url}
@@ -402,6 +438,9 @@ func matchFind(ctx *findContext, fileContent contentMessage) (match bool) {
 	if match && ctx.newerThan != "" {
 		match = !isNewer(fileContent.Time, ctx.newerThan)
 	}
+	if match && ctx.mtime != nil {
+		match = ctx.mtime.match(time.Since(fileContent.Time))
+	}
 	if match && ctx.largerSize > 0 {
 		match = int64(ctx.largerSize) < fileContent.Size
 	}
@@ -411,6 +450,42 @@ func matchFind(ctx *findContext, fileContent contentMessage) (match bool) {
 	return match
 }
 
+// findMtime - unix find style "-mtime" expression.
+type findMtime struct {
+	// sign is '+' for more than, '-' for less than and 0 for exactly days.
+	sign byte
+	days int64
+}
+
+// parseFindMtime parses unix find style day expressions "+N", "-N" and "N".
+func parseFindMtime(expr string) (*findMtime, *probe.Error) {
+	m := &findMtime{}
+	s := expr
+	if strings.HasPrefix(s, "+") || strings.HasPrefix(s, "-") {
+		m.sign = s[0]
+		s = s[1:]
+	}
+	days, e := strconv.ParseInt(s, 10, 64)
+	if e != nil || days < 0 {
+		return nil, errInvalidArgument().Trace(expr)
+	}
+	m.days = days
+	return m, nil
+}
+
+// match reports whether an object of the given age matches, the age is
+// rounded down to whole days like unix find does.
+func (m *findMtime) match(age time.Duration) bool {
+	days := int64(age / (24 * time.Hour))
+	switch m.sign {
+	case '+':
+		return days > m.days
+	case '-':
+		return days < m.days
+	}
+	return days == m.days
+}
+
 // 7 days in seconds.
 var defaultSevenDays = time.Duration(604800) * time.Second
 
@@ -454,3 +454,40 @@ func TestGetExitStatus(t *testing.T) {
 		}
 	}
 }
+
+// Tests unix find style mtime expressions.
+func TestFindMtime(t *testing.T) {
+	day := 24 * time.Hour
+	testCases := []struct {
+		expr    string
+		age     time.Duration
+		match   bool
+		wantErr bool
+	}{
+		{"+7", 8 * day, true, false},
+		{"+7", 7*day + time.Hour, false, false},
+		{"-1", time.Hour, true, false},
+		{"-1", day, false, false},
+		{"2", 2*day + 3*time.Hour, true, false},
+		{"2", 3 * day, false, false},
+		{"0", time.Minute, true, false},
+		{"+", 0, false, true},
+		{"abc", 0, false, true},
+		{"--1", 0, false, true},
+	}
+	for i, testCase := range testCases {
+		m, err := parseFindMtime(testCase.expr)
+		if testCase.wantErr {
+			if err == nil {
+				t.Fatalf("Test %d: expected error for %q", i+1, testCase.expr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if got := m.match(testCase.age); got != testCase.match {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.match, got)
+		}
+	}
+}
@@ -50,6 +50,18 @@ var globalFlags = []cli.Flag{
 	},
 }
 
+// Flags to control dereferencing of symlinks on local filesystem, supported by cp, ls and stat.
+var symlinkFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "follow-symlink",
+		Usage: "dereference symlinks on local filesystem (default)",
+	},
+	cli.BoolFlag{
+		Name:  "no-follow-symlink",
+		Usage: "report symlinks on local filesystem as links, cp skips them",
+	},
+}
+
 // Flags common across all I/O commands such as cp, mirror, stat, pipe etc.
 var ioFlags = []cli.Flag{
 	cli.StringFlag{
@@ -61,6 +61,8 @@ var (
 	globalNoColor  = false // No Color flag set via command line
 	globalInsecure = false // Insecure flag set via command line
 
+	globalFollowSymlink = true // Dereference local symlinks, disabled with --no-follow-symlink
+
 	globalContext, globalCancel = context.WithCancel(context.Background())
 )
 
@@ -94,5 +96,9 @@ func setGlobalsFromContext(ctx *cli.Context) error {
 	noColor := ctx.IsSet("no-color") || ctx.GlobalIsSet("no-color")
 	insecure := ctx.IsSet("insecure") || ctx.GlobalIsSet("insecure")
 	setGlobals(quiet, debug, json, noColor, insecure)
+	if ctx.IsSet("follow-symlink") && ctx.IsSet("no-follow-symlink") {
+		fatalIf(errInvalidArgument(), "Flags --follow-symlink and --no-follow-symlink are mutually exclusive.")
+	}
+	globalFollowSymlink = !ctx.IsSet("no-follow-symlink")
 	return nil
 }
@@ -0,0 +1,50 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// canonicalJSON marshals v into indented JSON where the keys of every
+// object, including nested maps, are sorted. Two messages holding the
+// same values therefore always render identically and can be diffed.
+func canonicalJSON(v interface{}) ([]byte, *probe.Error) {
+	buf, e := json.Marshal(v)
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+
+	// Decoding into generic maps drops struct field ordering, numbers
+	// are kept as is to not lose any precision.
+	var generic interface{}
+	dec := json.NewDecoder(bytes.NewReader(buf))
+	dec.UseNumber()
+	if e = dec.Decode(&generic); e != nil {
+		return nil, probe.NewError(e)
+	}
+
+	buf, e = json.MarshalIndent(generic, "", " ")
+	if e != nil {
+		return nil, probe.NewError(e)
+	}
+	return buf, nil
+}
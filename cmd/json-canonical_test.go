@@ -0,0 +1,83 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestCanonicalJSON(t *testing.T) {
+	testCases := []struct {
+		input    interface{}
+		expected string
+	}{
+		{
+			input: struct {
+				Zeta  string `json:"zeta"`
+				Alpha int64  `json:"alpha"`
+			}{"z", 9007199254740993},
+			expected: "{\n \"alpha\": 9007199254740993,\n \"zeta\": \"z\"\n}",
+		},
+		{
+			input: statMessage{
+				Key:      "obj",
+				Date:     time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC),
+				Metadata: map[string]string{"X-Amz-Meta-B": "2", "Content-Type": "text/plain", "X-Amz-Meta-A": "1"},
+			},
+			expected: `{
+ "etag": "",
+ "expiration": "0001-01-01T00:00:00Z",
+ "expirationRuleID": "",
+ "expires": "0001-01-01T00:00:00Z",
+ "lastModified": "2021-01-02T03:04:05Z",
+ "metadata": {
+  "Content-Type": "text/plain",
+  "X-Amz-Meta-A": "1",
+  "X-Amz-Meta-B": "2"
+ },
+ "name": "obj",
+ "replicationStatus": "",
+ "schemaVersion": 0,
+ "size": 0,
+ "status": "",
+ "type": ""
+}`,
+		},
+	}
+
+	for i, testCase := range testCases {
+		buf, err := canonicalJSON(testCase.input)
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error: %v", i+1, err)
+		}
+		// Indentation depends on stdout being a terminal.
+		var got, expected bytes.Buffer
+		if e := json.Compact(&got, buf); e != nil {
+			t.Fatalf("Test %d: %v", i+1, e)
+		}
+		if e := json.Compact(&expected, []byte(testCase.expected)); e != nil {
+			t.Fatalf("Test %d: %v", i+1, e)
+		}
+		if got.String() != expected.String() {
+			t.Fatalf("Test %d: expected %s, got %s", i+1, expected.String(), got.String())
+		}
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"strings"
+
+	"github.com/minio/cli"
+)
+
+// keyPathFlags control whether listing commands print keys as full
+// aliased paths or relative to the listed prefix.
+var keyPathFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "absolute",
+		Usage: "print keys as full 'alias/bucket/key' paths",
+	},
+	cli.BoolFlag{
+		Name:  "relative",
+		Usage: "print keys relative to the listed prefix",
+	},
+}
+
+// keyPathFromContext returns whether --absolute or --relative is set,
+// exiting when both are.
+func keyPathFromContext(ctx *cli.Context) (absolute, relative bool) {
+	absolute, relative = ctx.Bool("absolute"), ctx.Bool("relative")
+	if absolute && relative {
+		fatalIf(errInvalidArgument().Trace(ctx.Args()...), "--absolute and --relative cannot be used together.")
+	}
+	return absolute, relative
+}
+
+// listedPrefix returns the part of a listed URL up to its last separator,
+// which keys relative to the listed prefix are appended to.
+func listedPrefix(targetURL string, separator rune) string {
+	return targetURL[:strings.LastIndex(targetURL, string(separator))+1]
+}
+
+// relativeKey returns key relative to the listed prefix targetURL.
+func relativeKey(key, targetURL string, separator rune) string {
+	prefix := strings.TrimSuffix(targetURL, string(separator)) + string(separator)
+	if key == strings.TrimSuffix(targetURL, string(separator)) {
+		return ""
+	}
+	return strings.TrimPrefix(key, prefix)
+}
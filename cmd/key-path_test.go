@@ -0,0 +1,55 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import "testing"
+
+func TestKeyPath(t *testing.T) {
+	prefixCases := []struct {
+		targetURL string
+		expected  string
+	}{
+		{"myminio/", "myminio/"},
+		{"myminio/bucket/", "myminio/bucket/"},
+		{"myminio/bucket/dir/", "myminio/bucket/dir/"},
+		{"myminio/bucket/pre", "myminio/bucket/"},
+		{"file", ""},
+	}
+	for i, testCase := range prefixCases {
+		if prefix := listedPrefix(testCase.targetURL, '/'); prefix != testCase.expected {
+			t.Fatalf("Test %d: expected %q, got %q", i+1, testCase.expected, prefix)
+		}
+	}
+
+	relativeCases := []struct {
+		key       string
+		targetURL string
+		expected  string
+	}{
+		{"s3/bucket/photos/a.jpg", "s3/bucket/photos", "a.jpg"},
+		{"s3/bucket/photos/a.jpg", "s3/bucket/photos/", "a.jpg"},
+		{"s3/bucket/photos/2021/a.jpg", "s3/bucket/photos", "2021/a.jpg"},
+		{"s3/bucket/photos/", "s3/bucket/photos", ""},
+		{"s3/bucket/photos", "s3/bucket/photos/", ""},
+	}
+	for i, testCase := range relativeCases {
+		if key := relativeKey(testCase.key, testCase.targetURL, '/'); key != testCase.expected {
+			t.Fatalf("Test %d: expected %q, got %q", i+1, testCase.expected, key)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/minio/cli"
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+// listOnlyMessage container for a source and its computed target, printed
+// by cp and mirror with --list-only instead of transferring.
+type listOnlyMessage struct {
+	Status    string `json:"status"`
+	Source    string `json:"source"`
+	VersionID string `json:"versionId,omitempty"`
+	Target    string `json:"target"`
+	Size      int64  `json:"size"`
+}
+
+// String colorized list-only message.
+func (l listOnlyMessage) String() string {
+	if l.VersionID != "" {
+		return console.Colorize("ListOnly", fmt.Sprintf("`%s` (%s) -> `%s`", l.Source, l.VersionID, l.Target))
+	}
+	return console.Colorize("ListOnly", fmt.Sprintf("`%s` -> `%s`", l.Source, l.Target))
+}
+
+// JSON jsonified list-only message.
+func (l listOnlyMessage) JSON() string {
+	l.Status = "success"
+	listOnlyMessageBytes, e := json.MarshalIndent(l, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(listOnlyMessageBytes)
+}
+
+// newListOnlyMessage builds a message with aliased source and target paths.
+func newListOnlyMessage(urls URLs) listOnlyMessage {
+	return listOnlyMessage{
+		Source:    filepath.ToSlash(filepath.Join(urls.SourceAlias, urls.SourceContent.URL.Path)),
+		VersionID: urls.SourceContent.VersionID,
+		Target:    filepath.ToSlash(filepath.Join(urls.TargetAlias, urls.TargetContent.URL.Path)),
+		Size:      urls.SourceContent.Size,
+	}
+}
+
+// doCopyListOnly prints the source objects cp would act on along with
+// their target, without looking at the target nor transferring anything.
+func doCopyListOnly(ctx context.Context, cliCtx *cli.Context, args []string, encKeyDB map[string][]prefixSSEPair) error {
+	sourceURLs := args[:len(args)-1]
+	targetURL := args[len(args)-1] // Last one is target
+
+	var retErr error
+	for cpURLs := range prepareCopyURLs(ctx, sourceURLs, targetURL, cliCtx.Bool("recursive"),
+		encKeyDB, cliCtx.String("older-than"), cliCtx.String("newer-than"),
+		parseRewindFlag(cliCtx.String("rewind")), cliCtx.String("version-id"),
+		cliCtx.Bool("versions"), cliCtx.String("exclude-versions-older-than")) {
+		if cpURLs.Error != nil {
+			errorIf(cpURLs.Error.Trace(), "Unable to list objects to copy.")
+			retErr = exitStatus(globalErrorExitStatus)
+			continue
+		}
+		printMsg(newListOnlyMessage(cpURLs))
+	}
+	return retErr
+}
+
+// doMirrorListOnly prints the source objects mirror would act on along
+// with their target, the target is not compared against the source.
+func doMirrorListOnly(ctx context.Context, cliCtx *cli.Context, srcURL, tgtURL string) error {
+	sourceSeparator := string(newClientURL(srcURL).Separator)
+	if !strings.HasSuffix(srcURL, sourceSeparator) {
+		srcURL = srcURL + sourceSeparator
+	}
+	targetSeparator := string(newClientURL(tgtURL).Separator)
+	if !strings.HasSuffix(tgtURL, targetSeparator) {
+		tgtURL = tgtURL + targetSeparator
+	}
+
+	sourceAlias, sourceURL, _ := mustExpandAlias(srcURL)
+	targetAlias, targetURL, _ := mustExpandAlias(tgtURL)
+
+	sourceClnt, err := newClientFromAlias(sourceAlias, sourceURL)
+	fatalIf(err.Trace(sourceAlias, sourceURL), "Unable to initialize `"+srcURL+"`.")
+
+	excludeOptions := cliCtx.StringSlice("exclude")
+	olderThan := cliCtx.String("older-than")
+	newerThan := cliCtx.String("newer-than")
+
+	var retErr error
+	for content := range sourceClnt.List(ctx, ListOptions{Recursive: true, ShowDir: DirNone}) {
+		if content.Err != nil {
+			errorIf(content.Err.Trace(srcURL), "Unable to list objects to mirror.")
+			retErr = exitStatus(globalErrorExitStatus)
+			continue
+		}
+		if !content.Type.IsRegular() {
+			continue
+		}
+		sourceSuffix := strings.TrimPrefix(content.URL.String(), sourceURL)
+		if matchExcludeOptions(excludeOptions, sourceSuffix) {
+			continue
+		}
+		if isOlder(content.Time, olderThan) || isNewer(content.Time, newerThan) {
+			continue
+		}
+		printMsg(newListOnlyMessage(URLs{
+			SourceAlias:   sourceAlias,
+			SourceContent: content,
+			TargetAlias:   targetAlias,
+			TargetContent: &ClientContent{URL: *newClientURL(urlJoinPath(targetURL, sourceSuffix))},
+		}))
+	}
+	return retErr
+}
@@ -41,6 +41,14 @@ var (
 			Name:  "versions",
 			Usage: "list all versions",
 		},
+		cli.BoolFlag{
+			Name:  "older-versions-count",
+			Usage: "list each object once with the count and size of its noncurrent versions and delete markers",
+		},
+		cli.BoolFlag{
+			Name:  "sort-by-versions",
+			Usage: "sort objects by their number of noncurrent versions, implies --older-versions-count",
+		},
 		cli.BoolFlag{
 			Name:  "recursive, r",
 			Usage: "list recursively",
@@ -53,6 +61,18 @@ var (
 			Name:  "summarize",
 			Usage: "display summary information (number of objects, total size)",
 		},
+		cli.BoolFlag{
+			Name:  "replication",
+			Usage: "display the replication status of objects",
+		},
+		cli.BoolFlag{
+			Name:  "failed-only",
+			Usage: "list only objects which failed to replicate, implies --replication",
+		},
+		cli.StringFlag{
+			Name:  "output",
+			Usage: "print the listing as delimited rows with a header line, one of 'csv' or 'tsv'",
+		},
 	}
 )
 
@@ -63,7 +83,7 @@ var lsCmd = cli.Command{
 	Action:       mainList,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        append(lsFlags, globalFlags...),
+	Flags:        append(append(append(append(lsFlags, symlinkFlags...), timeFormatFlags...), keyPathFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -73,6 +93,13 @@ USAGE:
 FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}
+JSON OUTPUT:
+  With --json every entry is printed with sorted keys and 'lastModified' in UTC:
+    etag, isDeleteMarker, key, lastModified, replicationStatus, schemaVersion,
+    size, status, type, url, versionId, versionIndex, versionOrdinal
+  Empty optional fields are omitted. 'schemaVersion' is only increased when fields
+  are removed, renamed or change meaning.
+
 EXAMPLES:
   1. List buckets on Amazon S3 cloud storage.
      {{.Prompt}} {{.HelpName}} s3
@@ -102,6 +129,24 @@ EXAMPLES:
 
   9. List all objects on mybucket, summarize the number of objects and total size.
      {{.Prompt}} {{.HelpName}} --summarize s3/mybucket/
+
+  10. List all objects on mybucket recursively along with their replication status.
+      {{.Prompt}} {{.HelpName}} --recursive --replication myminio/mybucket/
+
+  11. List all objects on mybucket which failed to replicate.
+      {{.Prompt}} {{.HelpName}} --recursive --failed-only myminio/mybucket/
+
+  12. Find the objects of a versioned bucket with the most noncurrent versions.
+      {{.Prompt}} {{.HelpName}} --recursive --sort-by-versions myminio/mybucket/
+
+  13. Export the listing of all versions on mybucket as CSV.
+      {{.Prompt}} {{.HelpName}} --recursive --versions --output csv myminio/mybucket/ > mybucket.csv
+
+  14. List objects on mybucket with modification times in UTC, formatted as RFC3339.
+      {{.Prompt}} {{.HelpName}} --time-format rfc3339 --tz UTC myminio/mybucket/
+
+  15. List all objects on mybucket with their full path, ready to be passed to other commands.
+      {{.Prompt}} {{.HelpName}} --recursive --absolute myminio/mybucket/
 `,
 }
 
@@ -147,7 +192,7 @@ func parseRewindFlag(rewind string) (timeRef time.Time) {
 }
 
 // checkListSyntax - validate all the passed arguments
-func checkListSyntax(ctx context.Context, cliCtx *cli.Context) ([]string, bool, bool, bool, time.Time, bool) {
+func checkListSyntax(ctx context.Context, cliCtx *cli.Context) ([]string, doListOptions) {
 	args := cliCtx.Args()
 	if !cliCtx.Args().Present() {
 		args = []string{"."}
@@ -168,7 +213,38 @@ func checkListSyntax(ctx context.Context, cliCtx *cli.Context) ([]string, bool,
 		timeRef = time.Now().UTC()
 	}
 
-	return args, isRecursive, isIncomplete, isSummary, timeRef, withOlderVersions
+	failedOnly := cliCtx.Bool("failed-only")
+	withReplication := cliCtx.Bool("replication") || failedOnly
+
+	sortByVersions := cliCtx.Bool("sort-by-versions")
+	versionsCount := cliCtx.Bool("older-versions-count") || sortByVersions
+	if versionsCount && (withOlderVersions || isIncomplete) {
+		fatalIf(errInvalidArgument().Trace(args...), "--older-versions-count cannot be used with --versions or --incomplete.")
+	}
+
+	// Keys are relative to the listed prefix unless --absolute.
+	absolute, _ := keyPathFromContext(cliCtx)
+
+	output, err := newTabularWriter(cliCtx.String("output"), contentColumns(withOlderVersions, withReplication))
+	fatalIf(err.Trace(args...), "--output accepts only 'csv' or 'tsv'.")
+	if output != nil && (globalJSON || isSummary || versionsCount) {
+		fatalIf(errInvalidArgument().Trace(args...), "--output cannot be used with --json, --summarize or --older-versions-count.")
+	}
+
+	return args, doListOptions{
+		timeRef:           timeRef,
+		isRecursive:       isRecursive,
+		isIncomplete:      isIncomplete,
+		isSummary:         isSummary,
+		withOlderVersions: withOlderVersions,
+		withReplication:   withReplication,
+		failedOnly:        failedOnly,
+		versionsCount:     versionsCount,
+		sortByVersions:    sortByVersions,
+		output:            output,
+		timeFormat:        timeFormatFromContext(cliCtx),
+		absolute:          absolute,
+	}
 }
 
 // mainList - is a handler for mc ls command
@@ -186,9 +262,10 @@ func mainList(cliCtx *cli.Context) error {
 	console.SetColor("Size", color.New(color.FgYellow))
 	console.SetColor("Time", color.New(color.FgGreen))
 	console.SetColor("Summarize", color.New(color.Bold))
+	console.SetColor("Replication", color.New(color.FgHiCyan))
 
 	// check 'ls' cliCtx arguments.
-	args, isRecursive, isIncomplete, isSummary, timeRef, withOlderVersions := checkListSyntax(ctx, cliCtx)
+	args, opts := checkListSyntax(ctx, cliCtx)
 
 	var cErr error
 	for _, targetURL := range args {
@@ -196,14 +273,17 @@ func mainList(cliCtx *cli.Context) error {
 		fatalIf(err.Trace(targetURL), "Unable to initialize target `"+targetURL+"`.")
 		if !strings.HasSuffix(targetURL, string(clnt.GetURL().Separator)) {
 			var st *ClientContent
-			st, err = clnt.Stat(ctx, StatOptions{incomplete: isIncomplete})
+			st, err = clnt.Stat(ctx, StatOptions{incomplete: opts.isIncomplete})
 			if st != nil && err == nil && st.Type.IsDir() {
 				targetURL = targetURL + string(clnt.GetURL().Separator)
 				clnt, err = newClient(targetURL)
 				fatalIf(err.Trace(targetURL), "Unable to initialize target `"+targetURL+"`.")
 			}
 		}
-		if e := doList(ctx, clnt, isRecursive, isIncomplete, isSummary, timeRef, withOlderVersions); e != nil {
+		if opts.absolute {
+			opts.keyPrefix = listedPrefix(targetURL, clnt.GetURL().Separator)
+		}
+		if e := doList(ctx, clnt, opts); e != nil {
 			cErr = e
 		}
 	}
@@ -20,6 +20,7 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"os"
 	"path/filepath"
 	"runtime"
 	"sort"
@@ -29,6 +30,7 @@ import (
 	humanize "github.com/dustin/go-humanize"
 	json "github.com/minio/colorjson"
 	"github.com/minio/mc/pkg/probe"
+	minio "github.com/minio/minio-go/v7"
 	"github.com/minio/pkg/console"
 )
 
@@ -37,25 +39,39 @@ const (
 	printDate = "2006-01-02 15:04:05 MST"
 )
 
+// amzReplicationStatusHeader - metadata key holding the replication status of an object.
+const amzReplicationStatusHeader = "X-Amz-Replication-Status"
+
+// lsSchemaVersion is the version of the JSON output of ls and find, it
+// is bumped on changes which break existing consumers only.
+const lsSchemaVersion = 1
+
 // contentMessage container for content message structure.
 type contentMessage struct {
-	Status   string    `json:"status"`
-	Filetype string    `json:"type"`
-	Time     time.Time `json:"lastModified"`
-	Size     int64     `json:"size"`
-	Key      string    `json:"key"`
-	ETag     string    `json:"etag"`
-	URL      string    `json:"url,omitempty"`
+	SchemaVersion int       `json:"schemaVersion"`
+	Status        string    `json:"status"`
+	Filetype      string    `json:"type"`
+	Time          time.Time `json:"lastModified"`
+	Size          int64     `json:"size"`
+	Key           string    `json:"key"`
+	ETag          string    `json:"etag"`
+	URL           string    `json:"url,omitempty"`
 
 	VersionID      string `json:"versionId,omitempty"`
 	VersionOrd     int    `json:"versionOrdinal,omitempty"`
 	VersionIndex   int    `json:"versionIndex,omitempty"`
 	IsDeleteMarker bool   `json:"isDeleteMarker,omitempty"`
+
+	ReplicationStatus string `json:"replicationStatus,omitempty"`
+	showReplication   bool
+
+	storageClass string
+	timeFormat   listTimeFormat
 }
 
 // String colorized string message.
 func (c contentMessage) String() string {
-	message := console.Colorize("Time", fmt.Sprintf("[%s]", c.Time.Format(printDate)))
+	message := console.Colorize("Time", fmt.Sprintf("[%s]", c.timeFormat.format(c.Time)))
 	message += console.Colorize("Size", fmt.Sprintf("%7s", strings.Join(strings.Fields(humanize.IBytes(uint64(c.Size))), "")))
 	fileDesc := ""
 
@@ -68,6 +84,14 @@ func (c contentMessage) String() string {
 		}
 	}
 
+	if c.showReplication {
+		replStatus := c.ReplicationStatus
+		if replStatus == "" {
+			replStatus = "-"
+		}
+		fileDesc += console.Colorize("Replication", fmt.Sprintf(" %-9s", replStatus))
+	}
+
 	fileDesc += " " + c.Key
 
 	if c.Filetype == "folder" {
@@ -81,8 +105,10 @@ func (c contentMessage) String() string {
 // JSON jsonified content message.
 func (c contentMessage) JSON() string {
 	c.Status = "success"
-	jsonMessageBytes, e := json.MarshalIndent(c, "", " ")
-	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	c.SchemaVersion = lsSchemaVersion
+	c.Time = c.Time.UTC()
+	jsonMessageBytes, err := canonicalJSON(c)
+	fatalIf(err, "Unable to marshal into JSON.")
 
 	return string(jsonMessageBytes)
 }
@@ -108,6 +134,22 @@ func getKey(c *ClientContent) string {
 	return getOSDependantKey(c.URL.Path, c.Type.IsDir())
 }
 
+// getReplicationStatus - returns the replication status of an object, either
+// as reported by the listing or as found in its metadata.
+func getReplicationStatus(c *ClientContent) string {
+	if c.ReplicationStatus != "" {
+		return c.ReplicationStatus
+	}
+	for _, metadata := range []map[string]string{c.Metadata, c.UserMetadata} {
+		for k, v := range metadata {
+			if strings.EqualFold(k, amzReplicationStatusHeader) {
+				return v
+			}
+		}
+	}
+	return ""
+}
+
 // Generate printable listing from a list of sorted client
 // contents, the latest created content comes first.
 func generateContentMessages(clntURL ClientURL, ctnts []*ClientContent, printAllVersions bool) (msgs []contentMessage) {
@@ -134,6 +176,9 @@ func generateContentMessages(clntURL ClientURL, ctnts []*ClientContent, printAll
 			if c.Type.IsDir() {
 				return "folder"
 			}
+			if c.Type&os.ModeSymlink == os.ModeSymlink {
+				return "symlink"
+			}
 			return "file"
 		}()
 
@@ -146,6 +191,8 @@ func generateContentMessages(clntURL ClientURL, ctnts []*ClientContent, printAll
 		contentMsg.VersionID = c.VersionID
 		contentMsg.IsDeleteMarker = c.IsDeleteMarker
 		contentMsg.VersionOrd = nrVersions - i
+		contentMsg.ReplicationStatus = getReplicationStatus(c)
+		contentMsg.storageClass = c.StorageClass
 		// URL is empty by default
 		// Set it to either relative dir (host) or public url (remote)
 		contentMsg.URL = clntURL.String()
@@ -192,17 +239,103 @@ func (s summaryMessage) JSON() string {
 	return string(jsonMessageBytes)
 }
 
+// versionsCountMessage container for the versions summary of one object.
+type versionsCountMessage struct {
+	Status             string    `json:"status"`
+	Key                string    `json:"key"`
+	Time               time.Time `json:"lastModified"`
+	Size               int64     `json:"size"`
+	IsDeleted          bool      `json:"isDeleted,omitempty"`
+	NoncurrentVersions int       `json:"noncurrentVersions"`
+	NoncurrentSize     int64     `json:"noncurrentSize"`
+	DeleteMarkers      int       `json:"deleteMarkers"`
+	timeFormat         listTimeFormat
+}
+
+// String colorized versions summary message.
+func (v versionsCountMessage) String() string {
+	message := console.Colorize("Time", fmt.Sprintf("[%s]", v.timeFormat.format(v.Time)))
+	message += console.Colorize("Size", fmt.Sprintf("%7s", strings.Join(strings.Fields(humanize.IBytes(uint64(v.Size))), "")))
+	message += console.Colorize("VersionOrd", fmt.Sprintf(" %6d noncurrent", v.NoncurrentVersions))
+	message += console.Colorize("Size", fmt.Sprintf(" %7s", strings.Join(strings.Fields(humanize.IBytes(uint64(v.NoncurrentSize))), "")))
+	message += console.Colorize("DEL", fmt.Sprintf(" %4d DEL", v.DeleteMarkers))
+	return message + console.Colorize("File", " "+v.Key)
+}
+
+// JSON jsonified versions summary message.
+func (v versionsCountMessage) JSON() string {
+	v.Status = "success"
+	v.Time = v.Time.UTC()
+	jsonMessageBytes, e := json.MarshalIndent(v, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// newVersionsCountMessage counts the noncurrent versions and delete
+// markers of one object, the latest version comes first.
+func newVersionsCountMessage(clntURL ClientURL, ctntVersions []*ClientContent) versionsCountMessage {
+	sortObjectVersions(ctntVersions)
+	latest := generateContentMessages(clntURL, ctntVersions, false)[0]
+	msg := versionsCountMessage{
+		Key:       latest.Key,
+		Time:      latest.Time,
+		IsDeleted: latest.IsDeleteMarker,
+	}
+	for i, c := range ctntVersions {
+		switch {
+		case c.IsDeleteMarker:
+			msg.DeleteMarkers++
+		case i == 0:
+			msg.Size = c.Size
+		default:
+			msg.NoncurrentVersions++
+			msg.NoncurrentSize += c.Size
+		}
+	}
+	return msg
+}
+
+// doListOptions - options to control the listing of a folder.
+type doListOptions struct {
+	timeRef           time.Time
+	isRecursive       bool
+	isIncomplete      bool
+	isSummary         bool
+	withOlderVersions bool
+	withReplication   bool
+	failedOnly        bool
+	versionsCount     bool
+	sortByVersions    bool
+	output            *tabularWriter
+	timeFormat        listTimeFormat
+
+	// absolute prefixes keys with keyPrefix, the listed prefix.
+	absolute  bool
+	keyPrefix string
+}
+
 // Pretty print the list of versions belonging to one object
-func printObjectVersions(clntURL ClientURL, ctntVersions []*ClientContent, printAllVersions, isSummary bool) {
+func printObjectVersions(clntURL ClientURL, ctntVersions []*ClientContent, opts doListOptions) {
 	sortObjectVersions(ctntVersions)
-	msgs := generateContentMessages(clntURL, ctntVersions, printAllVersions)
+	msgs := generateContentMessages(clntURL, ctntVersions, opts.withOlderVersions)
 	for _, msg := range msgs {
+		if opts.failedOnly && msg.ReplicationStatus != string(minio.ReplicationStatusFailed) {
+			continue
+		}
+		msg.showReplication = opts.withReplication
+		msg.timeFormat = opts.timeFormat
+		msg.Key = opts.keyPrefix + msg.Key
+		if opts.output != nil {
+			opts.output.write(contentRow(msg, opts.withOlderVersions, opts.withReplication))
+			continue
+		}
 		printMsg(msg)
 	}
 }
 
 // doList - list all entities inside a folder.
-func doList(ctx context.Context, clnt Client, isRecursive, isIncomplete, isSummary bool, timeRef time.Time, withOlderVersions bool) error {
+func doList(ctx context.Context, clnt Client, opts doListOptions) error {
 
 	var (
 		lastPath          string
@@ -210,14 +343,36 @@ func doList(ctx context.Context, clnt Client, isRecursive, isIncomplete, isSumma
 		cErr              error
 		totalSize         int64
 		totalObjects      int64
+		versionsCounts    []versionsCountMessage
 	)
 
+	// printObject prints the listed versions of one object, or only
+	// counts them when asked for a versions summary.
+	printObject := func(ctntVersions []*ClientContent) {
+		if !opts.versionsCount {
+			printObjectVersions(clnt.GetURL(), ctntVersions, opts)
+			return
+		}
+		if len(ctntVersions) == 0 {
+			return
+		}
+		msg := newVersionsCountMessage(clnt.GetURL(), ctntVersions)
+		msg.timeFormat = opts.timeFormat
+		msg.Key = opts.keyPrefix + msg.Key
+		if opts.sortByVersions {
+			versionsCounts = append(versionsCounts, msg)
+			return
+		}
+		printMsg(msg)
+	}
+
 	for content := range clnt.List(ctx, ListOptions{
-		Recursive:         isRecursive,
-		Incomplete:        isIncomplete,
-		TimeRef:           timeRef,
-		WithOlderVersions: withOlderVersions || !timeRef.IsZero(),
+		Recursive:         opts.isRecursive,
+		Incomplete:        opts.isIncomplete,
+		TimeRef:           opts.timeRef,
+		WithOlderVersions: opts.withOlderVersions || opts.versionsCount || !opts.timeRef.IsZero(),
 		WithDeleteMarkers: true,
+		WithMetadata:      opts.withReplication,
 		ShowDir:           DirNone,
 	}) {
 		if content.Err != nil {
@@ -247,7 +402,7 @@ func doList(ctx context.Context, clnt Client, isRecursive, isIncomplete, isSumma
 
 		if lastPath != content.URL.Path {
 			// Print any object in the current list before reinitializing it
-			printObjectVersions(clnt.GetURL(), perObjectVersions, withOlderVersions, isSummary)
+			printObject(perObjectVersions)
 			lastPath = content.URL.Path
 			perObjectVersions = []*ClientContent{}
 		}
@@ -257,9 +412,17 @@ func doList(ctx context.Context, clnt Client, isRecursive, isIncomplete, isSumma
 		totalObjects++
 	}
 
-	printObjectVersions(clnt.GetURL(), perObjectVersions, withOlderVersions, isSummary)
+	printObject(perObjectVersions)
+
+	// Worst offenders first.
+	sort.SliceStable(versionsCounts, func(i, j int) bool {
+		return versionsCounts[i].NoncurrentVersions > versionsCounts[j].NoncurrentVersions
+	})
+	for _, msg := range versionsCounts {
+		printMsg(msg)
+	}
 
-	if isSummary {
+	if opts.isSummary {
 		printMsg(summaryMessage{
 			TotalObjects: totalObjects,
 			TotalSize:    totalSize,
@@ -16,3 +16,56 @@
 // along with this program.  If not, see <http://www.gnu.org/licenses/>.
 
 package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewVersionsCountMessage(t *testing.T) {
+	now := time.Now()
+	clntURL := *newClientURL("s3/bucket/")
+	version := func(size int64, age time.Duration, isLatest, isDeleteMarker bool) *ClientContent {
+		return &ClientContent{
+			URL:            *newClientURL("s3/bucket/dir/object"),
+			Size:           size,
+			Time:           now.Add(-age),
+			IsLatest:       isLatest,
+			IsDeleteMarker: isDeleteMarker,
+		}
+	}
+
+	testCases := []struct {
+		versions []*ClientContent
+		expected versionsCountMessage
+	}{
+		{
+			versions: []*ClientContent{version(10, 0, true, false)},
+			expected: versionsCountMessage{Key: "dir/object", Size: 10},
+		},
+		{
+			versions: []*ClientContent{
+				version(5, 3*time.Hour, false, false),
+				version(10, 0, true, false),
+				version(0, 2*time.Hour, false, true),
+				version(7, time.Hour, false, false),
+			},
+			expected: versionsCountMessage{Key: "dir/object", Size: 10, NoncurrentVersions: 2, NoncurrentSize: 12, DeleteMarkers: 1},
+		},
+		{
+			versions: []*ClientContent{
+				version(0, 0, true, true),
+				version(7, time.Hour, false, false),
+			},
+			expected: versionsCountMessage{Key: "dir/object", IsDeleted: true, NoncurrentVersions: 1, NoncurrentSize: 7, DeleteMarkers: 1},
+		},
+	}
+
+	for i, testCase := range testCases {
+		msg := newVersionsCountMessage(clntURL, testCase.versions)
+		msg.Time = time.Time{}
+		if msg != testCase.expected {
+			t.Fatalf("Test %d: expected %+v, got %+v", i+1, testCase.expected, msg)
+		}
+	}
+}
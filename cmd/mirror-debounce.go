@@ -0,0 +1,92 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"time"
+)
+
+// maxDebounceFactor caps how long events of a continuously changing
+// object are delayed, as a multiple of the debounce window.
+const maxDebounceFactor = 10
+
+// debouncedEvent is the latest event seen for a path along with the
+// time of the first and the last event received for it.
+type debouncedEvent struct {
+	event       EventInfo
+	first, last time.Time
+}
+
+// eventDebouncer coalesces watch events per path, so that only the final
+// state of an object is mirrored once no event is received for it during
+// the debounce window, or once the maximum delay is reached.
+type eventDebouncer struct {
+	window   time.Duration
+	maxDelay time.Duration
+
+	pending map[string]*debouncedEvent
+	// order of paths by their first event.
+	order []string
+}
+
+func newEventDebouncer(window time.Duration) *eventDebouncer {
+	return &eventDebouncer{
+		window:   window,
+		maxDelay: maxDebounceFactor * window,
+		pending:  make(map[string]*debouncedEvent),
+	}
+}
+
+// add records events, replacing any pending event for the same path.
+func (d *eventDebouncer) add(events []EventInfo, now time.Time) {
+	for _, event := range events {
+		if p, ok := d.pending[event.Path]; ok {
+			p.event = event
+			p.last = now
+			continue
+		}
+		d.pending[event.Path] = &debouncedEvent{event: event, first: now, last: now}
+		d.order = append(d.order, event.Path)
+	}
+}
+
+// due returns, in order of arrival, the events which are settled at now,
+// all pending events are returned when flushAll is set.
+func (d *eventDebouncer) due(now time.Time, flushAll bool) (events []EventInfo) {
+	order := d.order[:0]
+	for _, path := range d.order {
+		p := d.pending[path]
+		if flushAll || now.Sub(p.last) >= d.window || now.Sub(p.first) >= d.maxDelay {
+			events = append(events, p.event)
+			delete(d.pending, path)
+			continue
+		}
+		order = append(order, path)
+	}
+	d.order = order
+	return events
+}
+
+// tick returns the interval at which pending events should be checked.
+func (d *eventDebouncer) tick() time.Duration {
+	t := d.window / 2
+	if t < 10*time.Millisecond {
+		t = 10 * time.Millisecond
+	}
+	return t
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEventDebouncer(t *testing.T) {
+	start := time.Now()
+	d := newEventDebouncer(time.Second)
+
+	d.add([]EventInfo{{Path: "a", Size: 1}, {Path: "b", Size: 1}}, start)
+	d.add([]EventInfo{{Path: "a", Size: 2}}, start.Add(500*time.Millisecond))
+
+	if events := d.due(start.Add(900*time.Millisecond), false); len(events) != 0 {
+		t.Fatalf("Test 1: expected no settled events, got %v", events)
+	}
+	events := d.due(start.Add(time.Second), false)
+	if len(events) != 1 || events[0].Path != "b" {
+		t.Fatalf("Test 2: expected only 'b' to be settled, got %v", events)
+	}
+	events = d.due(start.Add(1500*time.Millisecond), false)
+	if len(events) != 1 || events[0].Path != "a" || events[0].Size != 2 {
+		t.Fatalf("Test 3: expected the latest event of 'a', got %v", events)
+	}
+
+	// A continuously changing object is flushed once the maximum delay is reached.
+	for i := 0; i < 30; i++ {
+		now := start.Add(time.Duration(i) * 500 * time.Millisecond)
+		d.add([]EventInfo{{Path: "c"}}, now)
+		if events = d.due(now, false); len(events) > 0 {
+			break
+		}
+	}
+	if len(events) != 1 || events[0].Path != "c" {
+		t.Fatalf("Test 4: expected 'c' to be flushed after the maximum delay, got %v", events)
+	}
+
+	d.add([]EventInfo{{Path: "d"}}, start)
+	if events = d.due(start, true); len(events) != 1 || events[0].Path != "d" {
+		t.Fatalf("Test 5: expected all pending events to be flushed, got %v", events)
+	}
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"strings"
+
+	json "github.com/minio/colorjson"
+	"github.com/minio/mc/pkg/probe"
+	"github.com/minio/pkg/console"
+)
+
+// staleDeleteMarkerMessage reports a delete marker removed from the
+// mirror target, or that would be removed with --fake.
+type staleDeleteMarkerMessage struct {
+	Status    string `json:"status"`
+	Key       string `json:"key"`
+	VersionID string `json:"versionId"`
+	DryRun    bool   `json:"dryRun,omitempty"`
+}
+
+func (s staleDeleteMarkerMessage) String() string {
+	if s.DryRun {
+		return console.Colorize("Mirror", "Would remove stale delete marker `"+s.Key+"` ("+s.VersionID+").")
+	}
+	return console.Colorize("Mirror", "Removed stale delete marker `"+s.Key+"` ("+s.VersionID+").")
+}
+
+func (s staleDeleteMarkerMessage) JSON() string {
+	s.Status = "success"
+	jsonMessageBytes, e := json.MarshalIndent(s, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+
+	return string(jsonMessageBytes)
+}
+
+// staleDeleteMarkers returns the delete markers among the versions of
+// one object when they hide nothing, i.e. when the object has no other
+// version. Removing them leaves the visible content unchanged.
+func staleDeleteMarkers(versions []*ClientContent) []*ClientContent {
+	for _, version := range versions {
+		if !version.IsDeleteMarker {
+			return nil
+		}
+	}
+	return versions
+}
+
+// removeStaleDeleteMarkers removes the delete markers on the versioned
+// target which hide no version and whose object does not exist on the
+// source either. With dryRun they are only reported.
+func removeStaleDeleteMarkers(ctx context.Context, sourceURL, targetURL string, dryRun bool) *probe.Error {
+	tgtClnt, err := newClient(targetURL)
+	if err != nil {
+		return err.Trace(targetURL)
+	}
+	if tgtClnt.GetURL().Type != objectStorage {
+		return errInvalidArgument().Trace(targetURL)
+	}
+	tgtPrefix := tgtClnt.GetURL().Path
+
+	var stale []*ClientContent
+	var versions []*ClientContent
+	collect := func() *probe.Error {
+		markers := staleDeleteMarkers(versions)
+		versions = nil
+		if len(markers) == 0 {
+			return nil
+		}
+		key := strings.TrimPrefix(markers[0].URL.Path, tgtPrefix)
+		srcClnt, err := newClient(urlJoinPath(sourceURL, key))
+		if err != nil {
+			return err.Trace(sourceURL, key)
+		}
+		if _, err = srcClnt.Stat(ctx, StatOptions{}); err == nil {
+			// The next mirror uploads it again, leave it alone.
+			return nil
+		}
+		stale = append(stale, markers...)
+		return nil
+	}
+
+	for content := range tgtClnt.List(ctx, ListOptions{
+		Recursive:         true,
+		WithOlderVersions: true,
+		WithDeleteMarkers: true,
+		ShowDir:           DirNone,
+	}) {
+		if content.Err != nil {
+			return content.Err.Trace(targetURL)
+		}
+		if len(versions) > 0 && versions[0].URL.Path != content.URL.Path {
+			if err = collect(); err != nil {
+				return err
+			}
+		}
+		versions = append(versions, content)
+	}
+	if err = collect(); err != nil {
+		return err
+	}
+
+	contentCh := make(chan *ClientContent, len(stale))
+	for _, marker := range stale {
+		printMsg(staleDeleteMarkerMessage{
+			Key:       strings.TrimPrefix(marker.URL.Path, tgtPrefix),
+			VersionID: marker.VersionID,
+			DryRun:    dryRun,
+		})
+		if !dryRun {
+			contentCh <- marker
+		}
+	}
+	close(contentCh)
+	if dryRun {
+		return nil
+	}
+	for err := range tgtClnt.Remove(ctx, false, false, false, contentCh) {
+		return err.Trace(targetURL)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import "testing"
+
+func TestStaleDeleteMarkers(t *testing.T) {
+	marker := &ClientContent{VersionID: "m1", IsDeleteMarker: true}
+	marker2 := &ClientContent{VersionID: "m2", IsDeleteMarker: true}
+	object := &ClientContent{VersionID: "v1"}
+
+	testCases := []struct {
+		versions []*ClientContent
+		expected int
+	}{
+		{nil, 0},
+		{[]*ClientContent{marker}, 1},
+		{[]*ClientContent{marker, marker2}, 2},
+		// A delete marker hiding an older version is not stale.
+		{[]*ClientContent{marker, object}, 0},
+		{[]*ClientContent{object}, 0},
+	}
+
+	for i, testCase := range testCases {
+		if got := len(staleDeleteMarkers(testCase.versions)); got != testCase.expected {
+			t.Fatalf("Test %d: expected %d stale markers, got %d", i+1, testCase.expected, got)
+		}
+	}
+}
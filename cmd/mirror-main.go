@@ -58,10 +58,18 @@ var (
 			Name:  "fake",
 			Usage: "perform a fake mirror operation",
 		},
+		cli.BoolFlag{
+			Name:  "list-only",
+			Usage: "list source objects and their computed target without comparing or mirroring",
+		},
 		cli.BoolFlag{
 			Name:  "watch, w",
 			Usage: "watch and synchronize changes",
 		},
+		cli.DurationFlag{
+			Name:  "debounce",
+			Usage: "with --watch, coalesce changes of an object within this window (e.g. `2s`), delayed at most 10 times the window",
+		},
 		cli.BoolFlag{
 			Name:  "remove",
 			Usage: "remove extraneous object(s) on target",
@@ -75,6 +83,18 @@ var (
 			Name:  "preserve, a",
 			Usage: "preserve file(s)/object(s) attributes and bucket(s) policy/locking configuration(s) on target bucket(s)",
 		},
+		cli.IntFlag{
+			Name:  "limit-objects",
+			Usage: "stop after mirroring N objects, useful to sample a large source",
+		},
+		cli.BoolFlag{
+			Name:  "skip-dir-markers",
+			Usage: "create real directories for zero-byte directory marker objects on a filesystem target (see DIRECTORY MARKERS)",
+		},
+		cli.BoolFlag{
+			Name:  "remove-stale-delete-markers",
+			Usage: "remove delete markers hiding nothing on a versioned target (see DELETE MARKERS)",
+		},
 		cli.BoolFlag{
 			Name:  "md5",
 			Usage: "force all upload(s) to calculate md5sum checksum",
@@ -92,10 +112,22 @@ var (
 			Name:  "disable-multipart",
 			Usage: "disable multipart upload feature",
 		},
+		cli.BoolFlag{
+			Name:  "adaptive-part-size",
+			Usage: "pick the multipart part size of each object from its size, aiming at about 1000 parts of at most 256MiB, each buffered in memory",
+		},
+		cli.BoolFlag{
+			Name:  "throttle-on-error",
+			Usage: "reduce concurrency and delay requests while the target returns throttling errors",
+		},
 		cli.StringSliceFlag{
 			Name:  "exclude",
 			Usage: "exclude object(s) that match specified object name pattern",
 		},
+		cli.StringSliceFlag{
+			Name:  "metadata-exclude",
+			Usage: "with -a, do not copy the source metadata matching this header name pattern, e.g. 'x-amz-meta-internal-*'",
+		},
 		cli.StringFlag{
 			Name:  "older-than",
 			Usage: "filter object(s) older than L days, M hours and N minutes",
@@ -130,7 +162,7 @@ var mirrorCmd = cli.Command{
 	Action:       mainMirror,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        append(append(mirrorFlags, ioFlags...), globalFlags...),
+	Flags:        append(append(append(append(mirrorFlags, transferHookFlags...), checksumManifestFlags...), ioFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -144,6 +176,37 @@ ENVIRONMENT VARIABLES:
    MC_ENCRYPT:      list of comma delimited prefixes
    MC_ENCRYPT_KEY:  list of comma delimited prefix=secret values
 
+DIRECTORY MARKERS:
+  Some S3 tools create zero-byte objects with a key ending in "/" to mimic
+  folders. With --skip-dir-markers such objects are not copied when the target
+  is a filesystem, an empty directory is created in their place instead.
+
+DELETE MARKERS:
+  Objects removed from a versioned target leave delete markers behind, which
+  pile up over time. Once mirroring is done, --remove-stale-delete-markers
+  removes the delete markers of objects which have no other version on the
+  target and do not exist on the source. Nothing visible on the target changes,
+  markers hiding older versions are always kept. Use --fake to preview them.
+
+HOOKS:
+  --on-success, --on-failure commands are run after each transferred object,
+  without a shell. The command is split into arguments on spaces, then each
+  argument gets the following substitutions:
+
+     {key}  --> Substitutes to the target path of the object.
+     {size} --> Substitutes to the size of the object.
+     {etag} --> Substitutes to the ETag of the source object.
+
+  Objects only faked with --fake run no command.
+
+MANIFEST:
+  --checksum-manifest appends one JSON line per transferred object to the file:
+
+     {"key":"<target>","source":"<source>","size":<size>,"sha256":"<checksum>","etag":"<target etag>"}
+
+  The SHA256 checksum is computed over the transferred data, it is omitted
+  for server side copies where data does not go through this client.
+
 EXAMPLES:
   01. Mirror a bucket recursively from MinIO cloud storage to a bucket on Amazon S3 cloud storage.
       {{.Prompt}} {{.HelpName}} play/photos/2014 s3/backup-photos
@@ -197,6 +260,37 @@ EXAMPLES:
   16. Cross mirror between sites in a active-active deployment.
       Site-A: {{.Prompt}} {{.HelpName}} --active-active siteA siteB
       Site-B: {{.Prompt}} {{.HelpName}} --active-active siteB siteA
+
+  17. Mirror a local folder and run a command for each object which failed to upload.
+      {{.Prompt}} {{.HelpName}} --on-failure "logger -t mc-mirror {key}" backup/ s3/archive
+
+  18. List all objects of a local folder and their target on Amazon S3 cloud storage, without mirroring.
+      {{.Prompt}} {{.HelpName}} --list-only backup/ s3/archive
+
+  19. Continuously mirror a local folder rewritten often by other tools, only transfer
+      objects once they have not changed for 5 seconds.
+      {{.Prompt}} {{.HelpName}} --watch --debounce 5s backup/ s3/archive
+
+  20. Mirror a bucket to another site and record an auditable manifest of the transferred objects.
+      {{.Prompt}} {{.HelpName}} --checksum-manifest migration.jsonl play/photos s3/photos
+
+  21. Try out a mirror on the first 100 objects of a large bucket.
+      {{.Prompt}} {{.HelpName}} --limit-objects 100 s3/logs ./logs-sample
+
+  22. Mirror a bucket laid out with folder marker objects to a local folder.
+      {{.Prompt}} {{.HelpName}} --skip-dir-markers s3/shared ./shared
+
+  23. Preview the delete markers which would be cleaned up on a versioned backup bucket.
+      {{.Prompt}} {{.HelpName}} --fake --remove-stale-delete-markers s3/data backup/data
+
+  24. Mirror a folder of mixed size files, sizing the upload parts of each object after its size.
+      {{.Prompt}} {{.HelpName}} --adaptive-part-size ./backups/ s3/backups/
+
+  25. Mirror a bucket to an overloaded target, backing off while it asks to slow down.
+      {{.Prompt}} {{.HelpName}} --throttle-on-error --debug s3/data backup/data
+
+  26. Mirror a bucket with its metadata, except the internal metadata of the source.
+      {{.Prompt}} {{.HelpName}} -a --metadata-exclude "x-amz-meta-internal-*" old/photos new/photos
 `,
 }
 
@@ -348,6 +442,53 @@ func (mj *mirrorJob) doRemove(ctx context.Context, sURLs URLs) URLs {
 	return sURLs.WithError(nil)
 }
 
+// doRemoveBatch - removes several target objects of the same alias with a
+// single remove call. Status of all but the last one are sent directly.
+func (mj *mirrorJob) doRemoveBatch(ctx context.Context, sURLs []URLs) URLs {
+	last := sURLs[len(sURLs)-1]
+	if mj.opts.isFake {
+		for _, u := range sURLs[:len(sURLs)-1] {
+			mj.statusCh <- u.WithError(nil)
+		}
+		return last.WithError(nil)
+	}
+
+	clnt, pErr := newClient(filepath.Join(last.TargetAlias, last.TargetContent.URL.Path))
+	if pErr != nil {
+		return last.WithError(pErr)
+	}
+	clnt.AddUserAgent(uaMirrorAppName, ReleaseTag)
+	contentCh := make(chan *ClientContent, len(sURLs))
+	for _, u := range sURLs {
+		contentCh <- &ClientContent{URL: *newClientURL(u.TargetContent.URL.Path)}
+	}
+	close(contentCh)
+
+	var removeErr *probe.Error
+	for pErr := range clnt.Remove(ctx, false, false, false, contentCh) {
+		if pErr != nil {
+			switch pErr.ToGoError().(type) {
+			case PathInsufficientPermission:
+				// Ignore Permission error.
+				continue
+			}
+			if removeErr != nil {
+				mj.statusCh <- URLs{Error: removeErr}
+			}
+			removeErr = pErr
+		}
+	}
+	if removeErr != nil {
+		// Removal errors cannot be matched to a target, report them only.
+		return URLs{Error: removeErr}
+	}
+
+	for _, u := range sURLs[:len(sURLs)-1] {
+		mj.statusCh <- u.WithError(nil)
+	}
+	return last.WithError(nil)
+}
+
 // doMirror - Mirror an object to multiple destination. URLs status contains a copy of sURLs and error if any.
 func (mj *mirrorJob) doMirrorWatch(ctx context.Context, targetPath string, tgtSSE encrypt.ServerSide, sURLs URLs) URLs {
 	shouldQueue := false
@@ -392,6 +533,7 @@ func (mj *mirrorJob) doMirror(ctx context.Context, sURLs URLs) URLs {
 			mj.status.Add(sURLs.SourceContent.Size)
 		}
 		mj.status.Update()
+		sURLs.notTransferred = true
 		return sURLs.WithError(nil)
 	}
 
@@ -435,6 +577,9 @@ func (mj *mirrorJob) doMirror(ctx context.Context, sURLs URLs) URLs {
 	})
 	sURLs.MD5 = mj.opts.md5
 	sURLs.DisableMultipart = mj.opts.disableMultipart
+	sURLs.AdaptivePartSize = mj.opts.adaptivePartSize
+	sURLs.withChecksum = mj.opts.manifest != nil
+	sURLs.metadataExclude = mj.opts.metadataExclude
 	return uploadSourceToTargetURL(ctx, sURLs, mj.status, mj.opts.encKeyDB, mj.opts.isMetadata)
 }
 
@@ -478,6 +623,8 @@ func (mj *mirrorJob) monitorMirrorStatus() (errDuringMirror bool) {
 		}
 
 		if sURLs.SourceContent != nil {
+			mj.opts.hook.run(sURLs)
+			errorIf(mj.opts.manifest.add(sURLs), "Unable to record `%s` in checksum manifest.", sURLs.SourceContent.URL.String())
 			s3mirrorTotalUploadedBytes.Add(float64(sURLs.SourceContent.Size))
 		} else if sURLs.TargetContent != nil {
 			// Construct user facing message and path.
@@ -487,10 +634,27 @@ func (mj *mirrorJob) monitorMirrorStatus() (errDuringMirror bool) {
 		}
 	}
 
+	if mj.opts.hook.wait() {
+		errDuringMirror = true
+	}
+	if err := mj.opts.manifest.close(); err != nil {
+		errorIf(err, "Unable to save checksum manifest.")
+		errDuringMirror = true
+	}
 	return
 }
 
 func (mj *mirrorJob) watchMirrorEvents(ctx context.Context, events []EventInfo) {
+	// Removals are sent in a single batch when events are debounced.
+	var removeURLs []URLs
+	defer func() {
+		if len(removeURLs) > 0 {
+			mj.parallel.queueTask(func() URLs {
+				return mj.doRemoveBatch(ctx, removeURLs)
+			})
+		}
+	}()
+
 	for _, event := range events {
 		// It will change the expanded alias back to the alias
 		// again, by replacing the sourceUrlFull with the sourceAlias.
@@ -547,6 +711,7 @@ func (mj *mirrorJob) watchMirrorEvents(ctx context.Context, events []EventInfo)
 				TargetContent:    &ClientContent{URL: *targetURL},
 				MD5:              mj.opts.md5,
 				DisableMultipart: mj.opts.disableMultipart,
+				AdaptivePartSize: mj.opts.adaptivePartSize,
 				encKeyDB:         mj.opts.encKeyDB,
 			}
 			if mj.opts.activeActive &&
@@ -576,6 +741,10 @@ func (mj *mirrorJob) watchMirrorEvents(ctx context.Context, events []EventInfo)
 			mirrorURL.TotalCount = mj.status.GetCounts()
 			mirrorURL.TotalSize = mj.status.Get()
 			if mirrorURL.TargetContent != nil && (mj.opts.isRemove || mj.opts.activeActive) {
+				if mj.opts.debounce > 0 {
+					removeURLs = append(removeURLs, mirrorURL)
+					continue
+				}
 				mj.parallel.queueTask(func() URLs {
 					return mj.doRemove(ctx, mirrorURL)
 				})
@@ -605,14 +774,34 @@ func (mj *mirrorJob) watchMirrorEvents(ctx context.Context, events []EventInfo)
 
 // this goroutine will watch for notifications, and add modified objects to the queue
 func (mj *mirrorJob) watchMirror(ctx context.Context, stopParallel func()) {
+	var debouncer *eventDebouncer
+	var debounceCh <-chan time.Time
+	if mj.opts.debounce > 0 {
+		debouncer = newEventDebouncer(mj.opts.debounce)
+		ticker := time.NewTicker(debouncer.tick())
+		defer ticker.Stop()
+		debounceCh = ticker.C
+	}
+
 	for {
 		select {
 		case events, ok := <-mj.watcher.Events():
 			if !ok {
+				if debouncer != nil {
+					mj.watchMirrorEvents(ctx, debouncer.due(time.Now(), true))
+				}
 				stopParallel()
 				return
 			}
+			if debouncer != nil {
+				debouncer.add(events, time.Now())
+				continue
+			}
 			mj.watchMirrorEvents(ctx, events)
+		case now := <-debounceCh:
+			if events := debouncer.due(now, false); len(events) > 0 {
+				mj.watchMirrorEvents(ctx, events)
+			}
 		case err, ok := <-mj.watcher.Errors():
 			if !ok {
 				stopParallel()
@@ -646,15 +835,31 @@ func (mj *mirrorJob) startMirror(ctx context.Context, cancelMirror context.Cance
 	mj.m.Lock()
 	defer mj.m.Unlock()
 
-	URLsCh := prepareMirrorURLs(ctx, mj.sourceURL, mj.targetURL, mj.opts)
+	listCtx, cancelList := context.WithCancel(ctx)
+	defer cancelList()
 
+	URLsCh := prepareMirrorURLs(listCtx, mj.sourceURL, mj.targetURL, mj.opts)
+
+	var queued int64
+	// Set when --limit-objects left objects out of the mirror.
+	var limitReached bool
 	for {
 		select {
 		case sURLs, ok := <-URLsCh:
 			if !ok {
+				if limitReached {
+					mj.status.PrintMsg(limitReachedMessage{Limit: mj.opts.limit})
+				}
 				stopParallel()
 				return
 			}
+			if mj.opts.limit > 0 && queued >= mj.opts.limit {
+				// Limit reached with objects left, stop listing and
+				// drain what is left.
+				limitReached = limitReached || sURLs.Error == nil
+				cancelList()
+				continue
+			}
 			if sURLs.Error != nil {
 				mj.statusCh <- sURLs
 				continue
@@ -682,10 +887,12 @@ func (mj *mirrorJob) startMirror(ctx context.Context, cancelMirror context.Cance
 			sURLs.TotalSize = mj.status.Get()
 
 			if sURLs.SourceContent != nil {
+				queued++
 				mj.parallel.queueTask(func() URLs {
 					return mj.doMirror(ctx, sURLs)
 				})
 			} else if sURLs.TargetContent != nil && mj.opts.isRemove {
+				queued++
 				mj.parallel.queueTask(func() URLs {
 					return mj.doRemove(ctx, sURLs)
 				})
@@ -752,7 +959,11 @@ func newMirrorJob(srcURL, dstURL string, opts mirrorOptions) *mirrorJob {
 		watcher:   NewWatcher(UTCNow()),
 	}
 
-	mj.parallel = newParallelManager(mj.statusCh)
+	var throttle *errorThrottle
+	if opts.throttleOnError {
+		throttle = newErrorThrottle(maxParallelWorkers)
+	}
+	mj.parallel = newParallelManager(mj.statusCh, throttle)
 
 	// we'll define the status to use here,
 	// do we want the quiet status? or the progressbar
@@ -846,6 +1057,9 @@ func runMirror(ctx context.Context, cancelMirror context.CancelFunc, srcURL, dst
 	// preserve is also expected to be overwritten if necessary
 	isMetadata := cli.Bool("a") || isWatch || len(userMetadata) > 0
 	isOverwrite = isOverwrite || isMetadata
+	if len(cli.StringSlice("metadata-exclude")) > 0 && !isMetadata {
+		fatalIf(errInvalidArgument().Trace(cli.Args()...), "--metadata-exclude requires -a.")
+	}
 
 	mopts := mirrorOptions{
 		isFake:           cli.Bool("fake"),
@@ -855,6 +1069,8 @@ func runMirror(ctx context.Context, cancelMirror context.CancelFunc, srcURL, dst
 		isMetadata:       isMetadata,
 		md5:              cli.Bool("md5"),
 		disableMultipart: cli.Bool("disable-multipart"),
+		adaptivePartSize: cli.Bool("adaptive-part-size"),
+		throttleOnError:  cli.Bool("throttle-on-error"),
 		excludeOptions:   cli.StringSlice("exclude"),
 		olderThan:        cli.String("older-than"),
 		newerThan:        cli.String("newer-than"),
@@ -862,6 +1078,19 @@ func runMirror(ctx context.Context, cancelMirror context.CancelFunc, srcURL, dst
 		userMetadata:     userMetadata,
 		encKeyDB:         encKeyDB,
 		activeActive:     isWatch,
+		limit:            int64(cli.Int("limit-objects")),
+		skipDirMarkers:   cli.Bool("skip-dir-markers"),
+		metadataExclude:  cli.StringSlice("metadata-exclude"),
+	}
+	if isWatch {
+		mopts.debounce = cli.Duration("debounce")
+	}
+	if !mopts.isFake {
+		mopts.hook = newTransferHook(cli)
+		if manifestFile := cli.String("checksum-manifest"); manifestFile != "" {
+			mopts.manifest, err = newChecksumManifest(manifestFile)
+			fatalIf(err.Trace(manifestFile), "Unable to open checksum manifest file.")
+		}
 	}
 
 	// Create a new mirror job and execute it
@@ -959,6 +1188,7 @@ func runMirror(ctx context.Context, cancelMirror context.CancelFunc, srcURL, dst
 func mainMirror(cliCtx *cli.Context) error {
 	// Additional command specific theme customization.
 	console.SetColor("Mirror", color.New(color.FgGreen, color.Bold))
+	console.SetColor("LimitReached", color.New(color.FgYellow))
 
 	ctx, cancelMirror := context.WithCancel(globalContext)
 	defer cancelMirror()
@@ -970,6 +1200,11 @@ func mainMirror(cliCtx *cli.Context) error {
 	// check 'mirror' cli arguments.
 	srcURL, tgtURL := checkMirrorSyntax(ctx, cliCtx, encKeyDB)
 
+	if cliCtx.Bool("list-only") {
+		console.SetColor("ListOnly", color.New(color.FgGreen))
+		return doMirrorListOnly(ctx, cliCtx, srcURL, tgtURL)
+	}
+
 	if prometheusAddress := cliCtx.String("monitoring-address"); prometheusAddress != "" {
 		http.Handle("/metrics", promhttp.Handler())
 		go func() {
@@ -987,6 +1222,11 @@ func mainMirror(cliCtx *cli.Context) error {
 			return exitStatus(globalErrorExitStatus)
 		default:
 			errorDetected := runMirror(ctx, cancelMirror, srcURL, tgtURL, cliCtx, encKeyDB)
+			if cliCtx.Bool("remove-stale-delete-markers") && !errorDetected {
+				err := removeStaleDeleteMarkers(ctx, srcURL, tgtURL, cliCtx.Bool("fake"))
+				errorIf(err, "Unable to remove stale delete markers on `"+tgtURL+"`.")
+				errorDetected = err != nil
+			}
 			if cliCtx.Bool("watch") || cliCtx.Bool("multi-master") || cliCtx.Bool("active-active") {
 				s3mirrorRestarts.Inc()
 				time.Sleep(time.Duration(r.Float64() * float64(2*time.Second)))
@@ -51,6 +51,13 @@ func checkMirrorSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[st
 		errorIf(errInvalidArgument().Trace(URLs...), "`--force` is deprecated, please use `--overwrite` instead for the same functionality.")
 	}
 
+	if cliCtx.Int("limit-objects") > 0 && (cliCtx.Bool("watch") || cliCtx.Bool("active-active") || cliCtx.Bool("multi-master")) {
+		fatalIf(errInvalidArgument().Trace(URLs...), "`--limit-objects` cannot be used with `--watch`.")
+	}
+	if cliCtx.Bool("remove-stale-delete-markers") && (cliCtx.Bool("watch") || cliCtx.Bool("active-active") || cliCtx.Bool("multi-master")) {
+		fatalIf(errInvalidArgument().Trace(URLs...), "`--remove-stale-delete-markers` cannot be used with `--watch`.")
+	}
+
 	_, expandedSourcePath, _ := mustExpandAlias(srcURL)
 	srcClient := newClientURL(expandedSourcePath)
 	_, expandedTargetPath, _ := mustExpandAlias(tgtURL)
@@ -147,6 +154,18 @@ func deltaSourceTarget(ctx context.Context, sourceURL, targetURL string, opts mi
 			continue
 		}
 
+		// Directory markers would end up as odd files on a filesystem
+		// target, create the real directory instead.
+		if opts.skipDirMarkers && isDirMarker(diffMsg.firstContent) && newClientURL(targetURL).Type == fileSystem {
+			if diffMsg.Diff == differInFirst && !opts.isFake {
+				targetPath := urlJoinPath(targetURL, srcSuffix)
+				if err := makeDirMarkerTarget(ctx, targetAlias, targetPath); err != nil {
+					URLsCh <- URLs{Error: err.Trace(targetPath)}
+				}
+			}
+			continue
+		}
+
 		switch diffMsg.Diff {
 		case differInNone:
 			// No difference, continue.
@@ -208,9 +227,16 @@ type mirrorOptions struct {
 	excludeOptions                    []string
 	encKeyDB                          map[string][]prefixSSEPair
 	md5, disableMultipart             bool
+	adaptivePartSize, throttleOnError bool
 	olderThan, newerThan              string
 	storageClass                      string
 	userMetadata                      map[string]string
+	hook                              *transferHook
+	debounce                          time.Duration
+	manifest                          *checksumManifest
+	limit                             int64
+	skipDirMarkers                    bool
+	metadataExclude                   []string
 }
 
 // Prepares urls that need to be copied or removed based on requested options.
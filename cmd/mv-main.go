@@ -225,7 +225,7 @@ func mainMove(cliCtx *cli.Context) error {
 	}
 
 	// check 'copy' cli arguments.
-	checkCopySyntax(ctx, cliCtx, encKeyDB, true)
+	checkCopySyntax(ctx, cliCtx, cliCtx.Args(), encKeyDB, true)
 
 	if cliCtx.NArg() == 2 {
 		args := cliCtx.Args()
@@ -307,7 +307,7 @@ func mainMove(cliCtx *cli.Context) error {
 		}
 	}
 
-	e := doCopySession(ctx, cancelMove, cliCtx, session, encKeyDB, true)
+	e := doCopySession(ctx, cancelMove, cliCtx, cliCtx.Args(), session, encKeyDB, true)
 	if session != nil {
 		session.Delete()
 	}
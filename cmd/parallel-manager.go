@@ -59,18 +59,24 @@ type ParallelManager struct {
 	// Current threads number
 	workersNum uint32
 
+	// Maximum threads number
+	maxWorkers uint32
+
 	// Channel to receive tasks to run
 	queueCh chan task
 
 	// Channel to send back results
 	resultCh chan URLs
 
+	// Backpressure on throttling errors, optional.
+	throttle *errorThrottle
+
 	stopMonitorCh chan struct{}
 }
 
 // addWorker creates a new worker to process tasks
 func (p *ParallelManager) addWorker() {
-	if atomic.LoadUint32(&p.workersNum) >= maxParallelWorkers {
+	if atomic.LoadUint32(&p.workersNum) >= p.maxWorkers {
 		// Number of maximum workers is reached, no need to
 		// to create a new one.
 		return
@@ -92,7 +98,14 @@ func (p *ParallelManager) addWorker() {
 			}
 
 			// Execute the task and send the result to channel.
-			p.resultCh <- t.fn()
+			if p.throttle != nil {
+				startTime := p.throttle.acquire()
+				result := t.fn()
+				p.throttle.release(startTime, result.Error)
+				p.resultCh <- result
+			} else {
+				p.resultCh <- t.fn()
+			}
 
 			if t.barrier {
 				p.barrierSync.Unlock()
@@ -180,14 +193,26 @@ func (p *ParallelManager) stopAndWait() {
 	close(p.stopMonitorCh)
 }
 
-// newParallelManager starts new workers waiting for executing tasks
-func newParallelManager(resultCh chan URLs) *ParallelManager {
+// newParallelManager starts new workers waiting for executing tasks,
+// throttle is optional.
+func newParallelManager(resultCh chan URLs, throttle *errorThrottle) *ParallelManager {
+	return newParallelManagerWithMaxWorkers(resultCh, maxParallelWorkers, throttle)
+}
+
+// newParallelManagerWithMaxWorkers starts new workers waiting for executing
+// tasks, the number of workers never goes beyond maxWorkers.
+func newParallelManagerWithMaxWorkers(resultCh chan URLs, maxWorkers int, throttle *errorThrottle) *ParallelManager {
+	if maxWorkers <= 0 || maxWorkers > maxParallelWorkers {
+		maxWorkers = maxParallelWorkers
+	}
 	p := &ParallelManager{
 		wg:            &sync.WaitGroup{},
 		workersNum:    0,
+		maxWorkers:    uint32(maxWorkers),
 		stopMonitorCh: make(chan struct{}),
 		queueCh:       make(chan task),
 		resultCh:      resultCh,
+		throttle:      throttle,
 	}
 
 	// Start with runtime.NumCPU().
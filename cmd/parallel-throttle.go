@@ -0,0 +1,151 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+	minio "github.com/minio/minio-go/v7"
+	"github.com/minio/pkg/console"
+)
+
+const (
+	// Delay injected before each task when the target starts throttling.
+	throttleMinDelay = 100 * time.Millisecond
+
+	// Maximum delay injected before each task.
+	throttleMaxDelay = 10 * time.Second
+
+	// Number of consecutive successful tasks before recovering one step.
+	throttleRecoverAfter = 10
+)
+
+// errorThrottle - adaptive backpressure shared by the workers of one or
+// more ParallelManager, it reduces the number of tasks running at once
+// and delays new tasks when the target returns throttling errors, and
+// recovers progressively as the errors subside.
+type errorThrottle struct {
+	mu   sync.Mutex
+	cond *sync.Cond
+
+	// Maximum and current number of tasks allowed to run at once
+	maxLimit, limit int
+	// Number of tasks currently running
+	active int
+	// Delay before starting a new task
+	delay time.Duration
+	// Consecutive successful tasks since the last adjustment
+	successes int
+	// Time of the last reduction, throttling errors of tasks started
+	// before it are not counted again.
+	lastReduced time.Time
+}
+
+// newErrorThrottle - returns a throttle allowing maxLimit tasks at once
+// until the target starts throttling.
+func newErrorThrottle(maxLimit int) *errorThrottle {
+	t := &errorThrottle{maxLimit: maxLimit, limit: maxLimit}
+	t.cond = sync.NewCond(&t.mu)
+	return t
+}
+
+// acquire - waits until a new task is allowed to run and returns its
+// start time, to be passed back to release.
+func (t *errorThrottle) acquire() time.Time {
+	t.mu.Lock()
+	for t.active >= t.limit {
+		t.cond.Wait()
+	}
+	t.active++
+	delay := t.delay
+	t.mu.Unlock()
+
+	if delay > 0 {
+		time.Sleep(delay)
+	}
+	return time.Now()
+}
+
+// release - records the outcome of a task started at startTime and
+// adjusts the concurrency and the delay accordingly.
+func (t *errorThrottle) release(startTime time.Time, err *probe.Error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	defer t.cond.Broadcast()
+
+	running := t.active
+	t.active--
+
+	if isThrottleError(err) {
+		t.successes = 0
+		if startTime.Before(t.lastReduced) {
+			// Already slowed down since this task was started.
+			return
+		}
+		t.lastReduced = time.Now()
+		if running < t.limit {
+			t.limit = running
+		}
+		if t.limit /= 2; t.limit < 1 {
+			t.limit = 1
+		}
+		if t.delay *= 2; t.delay < throttleMinDelay {
+			t.delay = throttleMinDelay
+		} else if t.delay > throttleMaxDelay {
+			t.delay = throttleMaxDelay
+		}
+		if globalDebug {
+			console.Debugln(fmt.Sprintf("Target is throttling requests, reducing concurrency to %d and delaying requests by %s.", t.limit, t.delay))
+		}
+		return
+	}
+
+	if t.delay == 0 && t.limit == t.maxLimit {
+		return
+	}
+	if t.successes++; t.successes < throttleRecoverAfter {
+		return
+	}
+	t.successes = 0
+	if t.delay /= 2; t.delay < throttleMinDelay {
+		t.delay = 0
+	}
+	if t.limit < t.maxLimit {
+		t.limit++
+	}
+	if globalDebug {
+		console.Debugln(fmt.Sprintf("Target throttling subsides, raising concurrency to %d and delaying requests by %s.", t.limit, t.delay))
+	}
+}
+
+// isThrottleError - returns true if err is a request rate error of an S3 target.
+func isThrottleError(err *probe.Error) bool {
+	if err == nil {
+		return false
+	}
+	errResp := minio.ToErrorResponse(err.ToGoError())
+	switch errResp.Code {
+	case "SlowDown", "SlowDownRead", "SlowDownWrite", "ServiceUnavailable", "RequestLimitExceeded":
+		return true
+	}
+	return errResp.StatusCode == http.StatusServiceUnavailable || errResp.StatusCode == http.StatusTooManyRequests
+}
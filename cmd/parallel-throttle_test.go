@@ -0,0 +1,76 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+	minio "github.com/minio/minio-go/v7"
+)
+
+func TestErrorThrottle(t *testing.T) {
+	slowDown := probe.NewError(minio.ErrorResponse{Code: "SlowDown", StatusCode: http.StatusServiceUnavailable})
+	if !isThrottleError(slowDown) {
+		t.Fatal("expected SlowDown to be a throttling error")
+	}
+	if isThrottleError(probe.NewError(minio.ErrorResponse{Code: "NoSuchKey", StatusCode: http.StatusNotFound})) {
+		t.Fatal("expected NoSuchKey not to be a throttling error")
+	}
+
+	throttle := newErrorThrottle(16)
+	throttle.active = 8
+
+	// The first throttling error halves the running tasks, the
+	// errors of tasks started before it are not counted again.
+	startTime := time.Now()
+	throttle.release(startTime, slowDown)
+	throttle.release(startTime, slowDown)
+	if throttle.limit != 4 || throttle.delay != throttleMinDelay {
+		t.Fatalf("expected limit 4 and delay %s, got %d and %s", throttleMinDelay, throttle.limit, throttle.delay)
+	}
+
+	// Throttling errors of new tasks keep reducing.
+	throttle.active = 4
+	throttle.release(time.Now(), slowDown)
+	if throttle.limit != 2 || throttle.delay != 2*throttleMinDelay {
+		t.Fatalf("expected limit 2 and delay %s, got %d and %s", 2*throttleMinDelay, throttle.limit, throttle.delay)
+	}
+
+	// Successful tasks recover one step at a time.
+	release := func(n int) {
+		for i := 0; i < n; i++ {
+			throttle.active = 1
+			throttle.release(time.Now(), nil)
+		}
+	}
+	release(throttleRecoverAfter)
+	if throttle.limit != 3 || throttle.delay != throttleMinDelay {
+		t.Fatalf("expected limit 3 and delay %s, got %d and %s", throttleMinDelay, throttle.limit, throttle.delay)
+	}
+	release(throttleRecoverAfter)
+	if throttle.limit != 4 || throttle.delay != 0 {
+		t.Fatalf("expected limit 4 and no delay, got %d and %s", throttle.limit, throttle.delay)
+	}
+	release(12 * throttleRecoverAfter)
+	if throttle.limit != 16 || throttle.delay != 0 {
+		t.Fatalf("expected limit 16 and no delay, got %d and %s", throttle.limit, throttle.delay)
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	humanize "github.com/dustin/go-humanize"
+)
+
+const (
+	// adaptivePartCount is the number of parts --adaptive-part-size aims at.
+	adaptivePartCount = 1000
+
+	// Objects smaller than the default multipart part size of the
+	// client are uploaded in a single part, keep doing so.
+	minAdaptivePartSize = 16 * humanize.MiByte
+
+	// Parts are buffered in memory, larger objects are split in more
+	// parts rather than in larger ones.
+	maxAdaptivePartSize = 256 * humanize.MiByte
+
+	// S3 limit on the number of parts of an object.
+	maxPartCount = 10000
+)
+
+// adaptivePartSize returns a multipart part size splitting an object of
+// size bytes in about adaptivePartCount parts, rounded up to a MiB and at
+// most maxAdaptivePartSize unless the object would need more than
+// maxPartCount parts. Zero is returned when the size is unknown, letting
+// the client pick its default.
+func adaptivePartSize(size int64) uint64 {
+	if size <= 0 {
+		return 0
+	}
+	partSize := roundUpToMiB(uint64(size+adaptivePartCount-1) / adaptivePartCount)
+	if partSize < minAdaptivePartSize {
+		return minAdaptivePartSize
+	}
+	if partSize > maxAdaptivePartSize {
+		partSize = roundUpToMiB(uint64(size+maxPartCount-1) / maxPartCount)
+		if partSize < maxAdaptivePartSize {
+			return maxAdaptivePartSize
+		}
+	}
+	return partSize
+}
+
+// roundUpToMiB rounds size up to a multiple of a MiB.
+func roundUpToMiB(size uint64) uint64 {
+	return (size + humanize.MiByte - 1) / humanize.MiByte * humanize.MiByte
+}
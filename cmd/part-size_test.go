@@ -0,0 +1,47 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+
+	humanize "github.com/dustin/go-humanize"
+)
+
+func TestAdaptivePartSize(t *testing.T) {
+	testCases := []struct {
+		size     int64
+		expected uint64
+	}{
+		{-1, 0},
+		{0, 0},
+		{1, minAdaptivePartSize},
+		{humanize.GiByte, minAdaptivePartSize},
+		{100 * humanize.GiByte, 103 * humanize.MiByte},
+		{200 * humanize.GiByte, 205 * humanize.MiByte},
+		{humanize.TiByte, maxAdaptivePartSize},
+		{4 * humanize.TiByte, 420 * humanize.MiByte},
+		{5 * humanize.TiByte, 525 * humanize.MiByte},
+	}
+
+	for i, testCase := range testCases {
+		if partSize := adaptivePartSize(testCase.size); partSize != testCase.expected {
+			t.Fatalf("Test %d: expected %d, got %d", i+1, testCase.expected, partSize)
+		}
+	}
+}
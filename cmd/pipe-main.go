@@ -18,7 +18,11 @@
 package cmd
 
 import (
+	"bytes"
+	"io"
+	"net/http"
 	"os"
+	"strings"
 	"syscall"
 
 	"github.com/minio/cli"
@@ -43,9 +47,16 @@ var (
 			Name:  "tags",
 			Usage: "apply tags to the uploaded objects",
 		},
+		cli.BoolFlag{
+			Name:  "detect-content-type",
+			Usage: "set the content-type of the object from the first bytes of its content, unless set with --attr",
+		},
 	}
 )
 
+// sniffLen is the number of bytes looked at by http.DetectContentType.
+const sniffLen = 512
+
 // Display contents of a file.
 var pipeCmd = cli.Command{
 	Name:         "pipe",
@@ -58,7 +69,7 @@ var pipeCmd = cli.Command{
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}} [FLAGS] [TARGET]
+  {{.HelpName}} [FLAGS] [TARGET [TARGET...]]
 {{if .VisibleFlags}}
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -88,41 +99,96 @@ EXAMPLES:
 
   7. Set tags to the uploaded objects
       {{.Prompt}} tar cvf - . | {{.HelpName}} --tags "category=backup" play/mybucket/backup.tar
+
+  8. Upload a generated image, with its content-type detected so that browsers display it.
+      {{.Prompt}} convert logo.svg png:- | {{.HelpName}} --detect-content-type play/mybucket/logo.png
+
+  9. Stream a database dump to a primary and a backup bucket at once, failing if any of them fails.
+      {{.Prompt}} pg_dump accountsdb | {{.HelpName}} s3/primary/accountsdb.sql backup/sql/accountsdb.sql
 `,
 }
 
-func pipe(targetURL string, encKeyDB map[string][]prefixSSEPair, storageClass string, meta map[string]string) *probe.Error {
-	if targetURL == "" {
+// detectContentType reads the first bytes of reader to guess their
+// content-type, the returned reader yields the whole content again.
+func detectContentType(reader io.Reader) (string, io.Reader, *probe.Error) {
+	buf := make([]byte, sniffLen)
+	n, e := io.ReadFull(reader, buf)
+	if e != nil && e != io.EOF && e != io.ErrUnexpectedEOF {
+		return "", nil, probe.NewError(e)
+	}
+	buf = buf[:n]
+	return http.DetectContentType(buf), io.MultiReader(bytes.NewReader(buf), reader), nil
+}
+
+// hasContentType returns true when the content-type is part of meta.
+func hasContentType(meta map[string]string) bool {
+	for k := range meta {
+		if strings.EqualFold(k, "Content-Type") {
+			return true
+		}
+	}
+	return false
+}
+
+func pipe(targetURLs []string, encKeyDB map[string][]prefixSSEPair, storageClass string, meta map[string]string, detectType bool) *probe.Error {
+	if len(targetURLs) == 0 {
 		// When no target is specified, pipe cat's stdin to stdout.
 		return catOut(os.Stdin, -1).Trace()
 	}
-	alias, _ := url2Alias(targetURL)
-	sseKey := getSSE(targetURL, encKeyDB[alias])
 
 	// Stream from stdin to multiple objects until EOF.
 	// Ignore size, since os.Stat() would not return proper size all the time
 	// for local filesystem for example /proc files.
-	opts := PutOptions{
-		sse:          sseKey,
-		storageClass: storageClass,
-		metadata:     meta,
+	var reader io.Reader = os.Stdin
+	if detectType && !hasContentType(meta) {
+		contentType, r, err := detectContentType(reader)
+		if err != nil {
+			return err.Trace(targetURLs...)
+		}
+		meta["Content-Type"] = contentType
+		reader = r
 	}
-	_, err := putTargetStreamWithURL(targetURL, os.Stdin, -1, opts)
-	// TODO: See if this check is necessary.
-	switch e := err.ToGoError().(type) {
-	case *os.PathError:
-		if e.Err == syscall.EPIPE {
-			// stdin closed by the user. Gracefully exit.
-			return nil
+
+	put := func(targetURL string, reader io.Reader) *probe.Error {
+		alias, _ := url2Alias(targetURL)
+		sseKey := getSSE(targetURL, encKeyDB[alias])
+
+		// Each target gets its own metadata, the content-type is set in it.
+		metadata := make(map[string]string, len(meta))
+		for k, v := range meta {
+			metadata[k] = v
 		}
+		opts := PutOptions{
+			sse:          sseKey,
+			storageClass: storageClass,
+			metadata:     metadata,
+		}
+		_, err := putTargetStreamWithURL(targetURL, reader, -1, opts)
+		// TODO: See if this check is necessary.
+		switch e := err.ToGoError().(type) {
+		case *os.PathError:
+			if e.Err == syscall.EPIPE {
+				// stdin closed by the user. Gracefully exit.
+				return nil
+			}
+		}
+		return err.Trace(targetURL)
 	}
-	return err.Trace(targetURL)
+	if len(targetURLs) == 1 {
+		return put(targetURLs[0], reader)
+	}
+	// Tee stdin to all targets.
+	return teeStream(reader, targetURLs, put)
 }
 
 // check pipe input arguments.
 func checkPipeSyntax(ctx *cli.Context) {
-	if len(ctx.Args()) > 1 {
-		cli.ShowCommandHelpAndExit(ctx, "pipe", 1) // last argument is exit code.
+	seen := make(map[string]bool)
+	for _, arg := range ctx.Args() {
+		if seen[arg] {
+			fatalIf(errInvalidArgument().Trace(arg), "Target `"+arg+"` is given more than once.")
+		}
+		seen[arg] = true
 	}
 }
 
@@ -144,13 +210,13 @@ func mainPipe(ctx *cli.Context) error {
 		meta["X-Amz-Tagging"] = tags
 	}
 	if len(ctx.Args()) == 0 {
-		err = pipe("", nil, ctx.String("storage-class"), meta)
+		err = pipe(nil, nil, ctx.String("storage-class"), meta, false)
 		fatalIf(err.Trace("stdout"), "Unable to write to one or more targets.")
 	} else {
 		// extract URLs.
 		URLs := ctx.Args()
-		err = pipe(URLs[0], encKeyDB, ctx.String("storage-class"), meta)
-		fatalIf(err.Trace(URLs[0]), "Unable to write to one or more targets.")
+		err = pipe(URLs, encKeyDB, ctx.String("storage-class"), meta, ctx.Bool("detect-content-type"))
+		fatalIf(err.Trace(URLs...), "Unable to write to one or more targets.")
 	}
 
 	// Done.
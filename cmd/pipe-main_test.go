@@ -0,0 +1,102 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+func TestDetectContentType(t *testing.T) {
+	png := "\x89PNG\x0D\x0A\x1A\x0A" + strings.Repeat("\x00", 1000)
+	testCases := []struct {
+		content  string
+		expected string
+	}{
+		{"", "text/plain; charset=utf-8"},
+		{"hello world", "text/plain; charset=utf-8"},
+		{"<html><body>hi</body></html>", "text/html; charset=utf-8"},
+		{png, "image/png"},
+	}
+
+	for i, testCase := range testCases {
+		contentType, reader, err := detectContentType(strings.NewReader(testCase.content))
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error: %v", i+1, err)
+		}
+		if contentType != testCase.expected {
+			t.Fatalf("Test %d: expected %s, got %s", i+1, testCase.expected, contentType)
+		}
+		content, e := ioutil.ReadAll(reader)
+		if e != nil {
+			t.Fatalf("Test %d: unexpected error: %v", i+1, e)
+		}
+		if string(content) != testCase.content {
+			t.Fatalf("Test %d: content was not preserved", i+1)
+		}
+	}
+}
+
+func TestPutTargetStreamContentType(t *testing.T) {
+	var contentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte("<LocationConstraint xmlns=\"http://doc.s3.amazonaws.com/2006-03-01\"></LocationConstraint>"))
+		case http.MethodPut:
+			contentType = r.Header.Get("Content-Type")
+			ioutil.ReadAll(r.Body)
+			w.Header().Set("ETag", "5eb63bbbe01eeed093cb22bb8f5acdc3")
+		}
+	}))
+	defer server.Close()
+
+	savedLoadMcConfig := loadMcConfig
+	defer func() { loadMcConfig = savedLoadMcConfig }()
+	loadMcConfig = func() (*configV10, *probe.Error) {
+		return &configV10{Aliases: map[string]aliasConfigV10{
+			"pipetest": {URL: server.URL, AccessKey: "minio", SecretKey: "minio123", API: "S3v4", Path: "auto"},
+		}}, nil
+	}
+
+	testCases := []struct {
+		metadata map[string]string
+		expected string
+	}{
+		{nil, "text/plain"},
+		{map[string]string{"Content-Type": "image/png"}, "image/png"},
+		{map[string]string{"content-type": "application/json"}, "application/json"},
+	}
+
+	for i, testCase := range testCases {
+		contentType = ""
+		data := "hello world"
+		_, err := putTargetStreamWithURL("pipetest/bucket/object.txt", strings.NewReader(data), int64(len(data)), PutOptions{metadata: testCase.metadata})
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error: %v", i+1, err)
+		}
+		if !strings.HasPrefix(contentType, testCase.expected) {
+			t.Fatalf("Test %d: expected %s, got %s", i+1, testCase.expected, contentType)
+		}
+	}
+}
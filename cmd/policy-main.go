@@ -38,12 +38,17 @@ var (
 			Name:  "recursive, r",
 			Usage: "list recursively",
 		},
+		cli.BoolFlag{
+			Name:  "prefixes",
+			Usage: "with links, only report the prefixes anonymously readable per policy",
+		},
 	}
 )
 
 // Manage anonymous access to buckets and objects.
 var policyCmd = cli.Command{
 	Name:         "policy",
+	Aliases:      []string{"anonymous"},
 	Usage:        "manage anonymous access to buckets and objects",
 	Action:       mainPolicy,
 	OnUsageError: onUsageError,
@@ -58,6 +63,7 @@ USAGE:
   {{.HelpName}} [FLAGS] get TARGET
   {{.HelpName}} [FLAGS] get-json TARGET
   {{.HelpName}} [FLAGS] list TARGET
+  {{.HelpName}} [FLAGS] links TARGET
 {{if .VisibleFlags}}
 FLAGS:
   {{range .VisibleFlags}}{{.}}
@@ -95,6 +101,9 @@ EXAMPLES:
 
   9. List public object URLs recursively.
      {{.Prompt}} {{.HelpName}} --recursive links s3/shared/
+
+  10. Report the prefixes of a bucket which are anonymously readable, without listing objects.
+     {{.Prompt}} {{.HelpName}} --prefixes --json links s3/shared
 `,
 }
 
@@ -352,7 +361,7 @@ func runPolicyListCmd(args cli.Args) {
 }
 
 // Run policy links command
-func runPolicyLinksCmd(args cli.Args, recursive bool) {
+func runPolicyLinksCmd(args cli.Args, recursive, prefixesOnly bool) {
 	ctx, cancelPolicyLinks := context.WithCancel(globalContext)
 	defer cancelPolicyLinks()
 
@@ -390,6 +399,10 @@ func runPolicyLinksCmd(args cli.Args, recursive bool) {
 		}
 		// Construct the new path to search for public objects
 		newURL := alias + "/" + policyPath
+		if prefixesOnly {
+			printMsg(policyRules{Resource: newURL, Allow: v})
+			continue
+		}
 		clnt, err := newClient(newURL)
 		fatalIf(err.Trace(newURL), "Unable to initialize target `"+targetURL+"`.")
 		// Search for public objects
@@ -489,7 +502,7 @@ func mainPolicy(ctx *cli.Context) error {
 		runPolicyListCmd(ctx.Args().Tail())
 	case "links":
 		// policy links alias/bucket/prefix
-		runPolicyLinksCmd(ctx.Args().Tail(), ctx.Bool("recursive"))
+		runPolicyLinksCmd(ctx.Args().Tail(), ctx.Bool("recursive"), ctx.Bool("prefixes"))
 	default:
 		// Shows command example and exit
 		cli.ShowCommandHelpAndExit(ctx, "policy", 1)
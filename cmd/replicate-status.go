@@ -19,23 +19,37 @@ package cmd
 
 import (
 	"context"
+	"fmt"
 
 	humanize "github.com/dustin/go-humanize"
 	"github.com/fatih/color"
 	"github.com/minio/cli"
 	json "github.com/minio/colorjson"
 	"github.com/minio/mc/pkg/probe"
+	minio "github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/replication"
 	"github.com/minio/pkg/console"
 )
 
+var replicateStatusFlags = []cli.Flag{
+	cli.BoolFlag{
+		Name:  "divergence",
+		Usage: "list a sample of objects pending or failed to replicate",
+	},
+	cli.IntFlag{
+		Name:  "sample",
+		Usage: "maximum number of diverging objects to list with --divergence",
+		Value: 10,
+	},
+}
+
 var replicateStatusCmd = cli.Command{
 	Name:         "status",
 	Usage:        "show server side replication status",
 	Action:       mainReplicateStatus,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        globalFlags,
+	Flags:        append(globalFlags, replicateStatusFlags...),
 	CustomHelpTemplate: `NAME:
    {{.HelpName}} - {{.Usage}}
 
@@ -48,6 +62,9 @@ FLAGS:
 EXAMPLES:
   1. Get server side replication metrics for bucket "mybucket" for alias "myminio".
 	   {{.Prompt}} {{.HelpName}} myminio/mybucket
+
+  2. Get server side replication metrics for bucket "mybucket" along with up to 20 objects not yet replicated.
+	   {{.Prompt}} {{.HelpName}} --json --divergence --sample 20 myminio/mybucket
 `,
 }
 
@@ -59,10 +76,25 @@ func checkReplicateStatusSyntax(ctx *cli.Context) {
 }
 
 type replicateStatusMessage struct {
-	Op                string              `json:"op"`
-	URL               string              `json:"url"`
-	Status            string              `json:"status"`
-	ReplicationStatus replication.Metrics `json:"replicationStatus"`
+	Op                string               `json:"op"`
+	URL               string               `json:"url"`
+	Status            string               `json:"status"`
+	ReplicationStatus replication.Metrics  `json:"replicationStatus"`
+	Divergence        *replicateDivergence `json:"divergence,omitempty"`
+}
+
+// replicateDivergence - objects of a bucket which are not replicated yet.
+type replicateDivergence struct {
+	PendingCount uint64            `json:"pendingCount"`
+	FailedCount  uint64            `json:"failedCount"`
+	Objects      []divergentObject `json:"objects"`
+}
+
+// divergentObject - object pending or failed to replicate.
+type divergentObject struct {
+	Key       string `json:"key"`
+	VersionID string `json:"versionId,omitempty"`
+	Status    string `json:"status"`
 }
 
 func (s replicateStatusMessage) JSON() string {
@@ -106,9 +138,54 @@ func (s replicateStatusMessage) String() string {
 		).buildRow(row[0], row[1], row[2])+"\n")
 		rows += r
 	}
+	if s.Divergence != nil && len(s.Divergence.Objects) > 0 {
+		rows += "\n" + console.Colorize("Headers", "Diverging objects:") + "\n"
+		for _, o := range s.Divergence.Objects {
+			th := "Pending"
+			if o.Status == string(minio.ReplicationStatusFailed) {
+				th = "Failed"
+			}
+			rows += console.Colorize(th, fmt.Sprintf("%-10s %s", o.Status, o.Key))
+			if o.VersionID != "" {
+				rows += console.Colorize(th, " ("+o.VersionID+")")
+			}
+			rows += "\n"
+		}
+	}
 	return console.Colorize("replicateStatusMessage", rows)
 }
 
+// getReplicateDivergence - lists up to sample objects which are pending or
+// failed to replicate.
+func getReplicateDivergence(ctx context.Context, client Client, metrics replication.Metrics, sample int) (*replicateDivergence, *probe.Error) {
+	divergence := &replicateDivergence{
+		PendingCount: metrics.PendingCount,
+		FailedCount:  metrics.FailedCount,
+		Objects:      []divergentObject{},
+	}
+	if sample <= 0 || metrics.PendingCount+metrics.FailedCount == 0 {
+		return divergence, nil
+	}
+	for content := range client.List(ctx, ListOptions{Recursive: true, WithMetadata: true, ShowDir: DirNone}) {
+		if content.Err != nil {
+			return nil, content.Err.Trace(client.GetURL().String())
+		}
+		status := getReplicationStatus(content)
+		if status != string(minio.ReplicationStatusPending) && status != string(minio.ReplicationStatusFailed) {
+			continue
+		}
+		divergence.Objects = append(divergence.Objects, divergentObject{
+			Key:       getKey(content),
+			VersionID: content.VersionID,
+			Status:    status,
+		})
+		if len(divergence.Objects) >= sample {
+			break
+		}
+	}
+	return divergence, nil
+}
+
 func mainReplicateStatus(cliCtx *cli.Context) error {
 	ctx, cancelReplicateStatus := context.WithCancel(globalContext)
 	defer cancelReplicateStatus()
@@ -129,11 +206,18 @@ func mainReplicateStatus(cliCtx *cli.Context) error {
 	replicateStatus, err := client.GetReplicationMetrics(ctx)
 	fatalIf(err.Trace(args...), "Unable to get replication status")
 
+	var divergence *replicateDivergence
+	if cliCtx.Bool("divergence") {
+		divergence, err = getReplicateDivergence(ctx, client, replicateStatus, cliCtx.Int("sample"))
+		fatalIf(err.Trace(args...), "Unable to list objects not replicated")
+	}
+
 	printReplicateStatusHeader()
 	printMsg(replicateStatusMessage{
 		Op:                "status",
 		URL:               aliasedURL,
 		ReplicationStatus: replicateStatus,
+		Divergence:        divergence,
 	})
 
 	return nil
@@ -68,6 +68,10 @@ USAGE:
 FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}
+DESCRIPTION:
+  Governance retention is cleared using governance bypass. Objects locked
+  in compliance mode cannot be cleared before their retention expires,
+  they are reported and skipped.
 
 EXAMPLES:
   1. Clear object retention for a specific object
@@ -38,6 +38,8 @@ type retentionCmdMessage struct {
 	VersionID string              `json:"versionID"`
 	Status    string              `json:"status"`
 	Err       error               `json:"error"`
+	// RetainUntil is only set for objects skipped by clear.
+	RetainUntil *time.Time `json:"retainUntil,omitempty"`
 }
 
 // Colorized message for console printing.
@@ -48,7 +50,11 @@ func (m retentionCmdMessage) String() string {
 		ed = "ed"
 	}
 
-	if m.Err != nil {
+	if m.Status == "skipped" {
+		color = "RetentionFailure"
+		msg = fmt.Sprintf("Skipped %s object retention on `%s`, %s mode is enforced until %s",
+			m.Op, m.URLPath, m.Mode, m.RetainUntil.Format(time.RFC3339))
+	} else if m.Err != nil {
 		color = "RetentionFailure"
 		msg = fmt.Sprintf("Unable to %s object retention on `%s`: %s", m.Op, m.URLPath, m.Err)
 	} else {
@@ -137,6 +143,19 @@ func setRetentionSingle(ctx context.Context, op lockOpType, alias, url, versionI
 		VersionID: versionID,
 	}
 
+	// Compliance mode cannot be cleared before the retention expires,
+	// even with governance bypass, report and skip such objects.
+	if op == lockOpClear {
+		if curMode, until, err := newClnt.GetObjectRetention(ctx, versionID); err == nil &&
+			curMode == minio.Compliance && until.After(UTCNow()) {
+			msg.Mode = curMode
+			msg.Status = "skipped"
+			msg.RetainUntil = &until
+			printMsg(msg)
+			return nil
+		}
+	}
+
 	err = newClnt.PutObjectRetention(ctx, versionID, mode, retainUntil, bypassGovernance)
 	if err != nil {
 		msg.Err = err.ToGoError()
@@ -27,6 +27,7 @@ import (
 	"strings"
 	"time"
 
+	humanize "github.com/dustin/go-humanize"
 	"github.com/fatih/color"
 	"github.com/minio/cli"
 	json "github.com/minio/colorjson"
@@ -67,7 +68,7 @@ var (
 			Usage: "remove incomplete uploads",
 		},
 		cli.BoolFlag{
-			Name:  "fake",
+			Name:  "fake, dry-run",
 			Usage: "perform a fake remove operation",
 		},
 		cli.BoolFlag{
@@ -137,16 +138,19 @@ EXAMPLES:
   09. Drop all incomplete uploads on the bucket 'jazz-songs'.
       {{.Prompt}} {{.HelpName}} --incomplete --recursive --force s3/jazz-songs/
 
-  10. Remove an encrypted object from Amazon S3 cloud storage.
+  10. Report the space held by incomplete uploads older than 7 days on the bucket 'jazz-songs', without removing them.
+      {{.Prompt}} {{.HelpName}} --incomplete --recursive --force --older-than 7d --dry-run s3/jazz-songs/
+
+  11. Remove an encrypted object from Amazon S3 cloud storage.
       {{.Prompt}} {{.HelpName}} --encrypt-key "s3/sql-backups/=32byteslongsecretkeymustbegiven1" s3/sql-backups/1999/old-backup.tgz
 
-  11. Bypass object retention in governance mode and delete the object.
+  12. Bypass object retention in governance mode and delete the object.
       {{.Prompt}} {{.HelpName}} --bypass s3/pop-songs/
 
-  12. Remove a particular version ID.
+  13. Remove a particular version ID.
       {{.Prompt}} {{.HelpName}} s3/docs/money.xls --version-id "f20f3792-4bd4-4288-8d3c-b9d05b3b62f6"
 
-  13. Remove all object versions older than one year.
+  14. Remove all object versions older than one year.
       {{.Prompt}} {{.HelpName}} s3/docs/ --recursive --versions --rewind 365d
 
 `,
@@ -154,15 +158,20 @@ EXAMPLES:
 
 // Structured message depending on the type of console.
 type rmMessage struct {
-	Status    string    `json:"status"`
-	Key       string    `json:"key"`
-	VersionID string    `json:"versionID"`
-	ModTime   time.Time `json:"modTime"`
-	Size      int64     `json:"size"`
+	Status     string    `json:"status"`
+	Key        string    `json:"key"`
+	VersionID  string    `json:"versionID"`
+	ModTime    time.Time `json:"modTime"`
+	Size       int64     `json:"size"`
+	Incomplete bool      `json:"incomplete,omitempty"`
 }
 
 // Colorized message for console printing.
 func (r rmMessage) String() string {
+	if r.Incomplete {
+		return console.Colorize("Remove", fmt.Sprintf("Removing incomplete upload `%s`", r.Key)) +
+			fmt.Sprintf(" (initiated=%s, size=%s).", r.ModTime.Local().Format(printDate), humanize.IBytes(uint64(r.Size)))
+	}
 	msg := console.Colorize("Remove", fmt.Sprintf("Removing `%s`", r.Key))
 	if r.VersionID != "" {
 		if !r.ModTime.IsZero() {
@@ -183,6 +192,53 @@ func (r rmMessage) JSON() string {
 	return string(msgBytes)
 }
 
+// rmSummaryMessage - total of incomplete uploads which are removed.
+type rmSummaryMessage struct {
+	Status       string `json:"status"`
+	TotalUploads int64  `json:"totalUploads"`
+	TotalSize    int64  `json:"totalSize"`
+	Fake         bool   `json:"fake,omitempty"`
+}
+
+// Colorized message for console printing.
+func (r rmSummaryMessage) String() string {
+	if r.Fake {
+		return console.Colorize("Remove", fmt.Sprintf("Found %d incomplete upload(s), %s can be reclaimed.",
+			r.TotalUploads, humanize.IBytes(uint64(r.TotalSize))))
+	}
+	return console.Colorize("Remove", fmt.Sprintf("Removed %d incomplete upload(s), %s reclaimed.",
+		r.TotalUploads, humanize.IBytes(uint64(r.TotalSize))))
+}
+
+// JSON'ified message for scripting.
+func (r rmSummaryMessage) JSON() string {
+	r.Status = "success"
+	msgBytes, e := json.MarshalIndent(r, "", " ")
+	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	return string(msgBytes)
+}
+
+// add - accounts an incomplete upload queued for removal.
+func (r *rmSummaryMessage) add(size int64) {
+	if r == nil {
+		return
+	}
+	r.TotalUploads++
+	r.TotalSize += size
+}
+
+// notRemoved - discounts the incomplete upload which pErr failed to remove,
+// the summary then only accounts the removals which succeeded.
+func (r *rmSummaryMessage) notRemoved(pErr *probe.Error) {
+	if r == nil {
+		return
+	}
+	if e, ok := pErr.ToGoError().(IncompleteUploadNotRemoved); ok {
+		r.TotalUploads--
+		r.TotalSize -= e.Size
+	}
+}
+
 // Validate command line arguments.
 func checkRmSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[string][]prefixSSEPair) {
 	// Set command flags from context.
@@ -241,7 +297,7 @@ func checkRmSyntax(ctx context.Context, cliCtx *cli.Context, encKeyDB map[string
 }
 
 // Remove a single object or a single version in a versioned bucket
-func removeSingle(url, versionID string, isIncomplete, isFake, isForce, isBypass bool, olderThan, newerThan string, encKeyDB map[string][]prefixSSEPair) error {
+func removeSingle(url, versionID string, isIncomplete, isFake, isForce, isBypass bool, olderThan, newerThan string, encKeyDB map[string][]prefixSSEPair, summary *rmSummaryMessage) error {
 	ctx, cancel := context.WithCancel(globalContext)
 	defer cancel()
 
@@ -289,11 +345,16 @@ func removeSingle(url, versionID string, isIncomplete, isFake, isForce, isBypass
 		return nil
 	}
 
-	printMsg(rmMessage{
-		Key:       url,
-		Size:      size,
-		VersionID: versionID,
-	})
+	msg := rmMessage{
+		Key:        url,
+		Size:       size,
+		VersionID:  versionID,
+		Incomplete: isIncomplete,
+	}
+	if isIncomplete {
+		msg.ModTime = modTime
+	}
+	printMsg(msg)
 
 	if !isFake {
 		targetAlias, targetURL, _ := mustExpandAlias(url)
@@ -317,13 +378,16 @@ func removeSingle(url, versionID string, isIncomplete, isFake, isForce, isBypass
 				errorIf(pErr.Trace(url), "Failed to remove `"+url+"`.")
 				switch pErr.ToGoError().(type) {
 				case PathInsufficientPermission:
-					// Ignore Permission error.
-					continue
+					// Ignore Permission error, nothing was removed.
+					return nil
 				}
 				return exitStatus(globalErrorExitStatus)
 			}
 		}
 	}
+	if isIncomplete {
+		summary.add(size)
+	}
 	return nil
 }
 
@@ -331,7 +395,7 @@ func removeSingle(url, versionID string, isIncomplete, isFake, isForce, isBypass
 //   Use cases:
 //      * Remove objects recursively
 //      * Remove all versions of a single object
-func listAndRemove(url string, timeRef time.Time, withVersions, isRecursive, isIncomplete, isFake, isBypass bool, olderThan, newerThan string, encKeyDB map[string][]prefixSSEPair) error {
+func listAndRemove(url string, timeRef time.Time, withVersions, isRecursive, isIncomplete, isFake, isBypass bool, olderThan, newerThan string, encKeyDB map[string][]prefixSSEPair, summary *rmSummaryMessage) error {
 	ctx, cancelRemove := context.WithCancel(globalContext)
 	defer cancelRemove()
 
@@ -403,11 +467,15 @@ func listAndRemove(url string, timeRef time.Time, withVersions, isRecursive, isI
 		}
 
 		printMsg(rmMessage{
-			Key:       targetAlias + urlString,
-			Size:      content.Size,
-			VersionID: content.VersionID,
-			ModTime:   content.Time,
+			Key:        targetAlias + urlString,
+			Size:       content.Size,
+			VersionID:  content.VersionID,
+			ModTime:    content.Time,
+			Incomplete: isIncomplete,
 		})
+		if isIncomplete {
+			summary.add(content.Size)
+		}
 
 		if !isFake {
 			sent := false
@@ -416,6 +484,7 @@ func listAndRemove(url string, timeRef time.Time, withVersions, isRecursive, isI
 				case contentCh <- content:
 					sent = true
 				case pErr := <-errorCh:
+					summary.notRemoved(pErr)
 					errorIf(pErr.Trace(urlString), "Failed to remove `"+urlString+"`.")
 					switch pErr.ToGoError().(type) {
 					case PathInsufficientPermission:
@@ -431,6 +500,7 @@ func listAndRemove(url string, timeRef time.Time, withVersions, isRecursive, isI
 
 	close(contentCh)
 	for pErr := range errorCh {
+		summary.notRemoved(pErr)
 		errorIf(pErr.Trace(url), "Failed to remove `"+url+"` recursively.")
 		switch pErr.ToGoError().(type) {
 		case PathInsufficientPermission:
@@ -480,14 +550,22 @@ func mainRm(cliCtx *cli.Context) error {
 	// Set color.
 	console.SetColor("Remove", color.New(color.FgGreen, color.Bold))
 
+	var summary *rmSummaryMessage
+	if isIncomplete {
+		summary = &rmSummaryMessage{Fake: isFake}
+		defer func() {
+			printMsg(*summary)
+		}()
+	}
+
 	var rerr error
 	var e error
 	// Support multiple targets.
 	for _, url := range cliCtx.Args() {
 		if isRecursive || withVersions {
-			e = listAndRemove(url, rewind, withVersions, isRecursive, isIncomplete, isFake, isBypass, olderThan, newerThan, encKeyDB)
+			e = listAndRemove(url, rewind, withVersions, isRecursive, isIncomplete, isFake, isBypass, olderThan, newerThan, encKeyDB, summary)
 		} else {
-			e = removeSingle(url, versionID, isIncomplete, isFake, isForce, isBypass, olderThan, newerThan, encKeyDB)
+			e = removeSingle(url, versionID, isIncomplete, isFake, isForce, isBypass, olderThan, newerThan, encKeyDB, summary)
 		}
 		if rerr == nil {
 			rerr = e
@@ -502,9 +580,9 @@ func mainRm(cliCtx *cli.Context) error {
 	for scanner.Scan() {
 		url := scanner.Text()
 		if isRecursive || withVersions {
-			e = listAndRemove(url, rewind, withVersions, isRecursive, isIncomplete, isFake, isBypass, olderThan, newerThan, encKeyDB)
+			e = listAndRemove(url, rewind, withVersions, isRecursive, isIncomplete, isFake, isBypass, olderThan, newerThan, encKeyDB, summary)
 		} else {
-			e = removeSingle(url, versionID, isIncomplete, isFake, isForce, isBypass, olderThan, newerThan, encKeyDB)
+			e = removeSingle(url, versionID, isIncomplete, isFake, isForce, isBypass, olderThan, newerThan, encKeyDB, summary)
 		}
 		if rerr == nil {
 			rerr = e
@@ -51,6 +51,7 @@ type sessionV8Header struct {
 	LastRemoved        string            `json:"lastRemoved"`
 	TotalBytes         int64             `json:"totalBytes"`
 	TotalObjects       int64             `json:"totalObjects"`
+	LimitReached       bool              `json:"limitReached,omitempty"`
 	UserMetaData       map[string]string `json:"metaData"`
 }
 
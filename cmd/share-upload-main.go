@@ -23,6 +23,7 @@ import (
 	"strings"
 	"time"
 
+	humanize "github.com/dustin/go-humanize"
 	"github.com/minio/cli"
 	"github.com/minio/mc/pkg/probe"
 )
@@ -35,6 +36,10 @@ var (
 		},
 		shareFlagExpire,
 		shareFlagContentType,
+		cli.StringFlag{
+			Name:  "max-size",
+			Usage: "limit the size of the uploaded object, e.g. 10MiB",
+		},
 	}
 )
 
@@ -67,6 +72,9 @@ EXAMPLES:
 
   4. Generate a curl command to allow upload access to any objects matching the key prefix 'backup/'. Command expires in 2 hours.
      {{.Prompt}} {{.HelpName}} --recursive --expire=2h s3/backup/2007-Mar-2/backup/
+
+  5. Generate the form fields of an upload policy accepting only images of up to 5MiB to a folder.
+     {{.Prompt}} {{.HelpName}} --json --recursive --content-type="image/*" --max-size=5MiB s3/uploads/avatars/
 `,
 }
 
@@ -99,6 +107,11 @@ func checkShareUploadSyntax(ctx *cli.Context) {
 			"Expiry cannot be larger than 7 days.")
 	}
 
+	if maxSize := ctx.String("max-size"); maxSize != "" {
+		_, e := humanize.ParseBytes(maxSize)
+		fatalIf(probe.NewError(e).Trace(maxSize), "Unable to parse max-size=`"+maxSize+"`.")
+	}
+
 	for _, targetURL := range ctx.Args() {
 		url := newClientURL(targetURL)
 		if strings.HasSuffix(targetURL, string(url.Separator)) && !isRecursive {
@@ -145,14 +158,14 @@ func saveSharedURL(objectURL string, shareURL string, expiry time.Duration, cont
 }
 
 // doShareUploadURL uploads files to the target.
-func doShareUploadURL(ctx context.Context, objectURL string, isRecursive bool, expiry time.Duration, contentType string) *probe.Error {
+func doShareUploadURL(ctx context.Context, objectURL string, isRecursive bool, expiry time.Duration, contentType string, maxSize int64) *probe.Error {
 	clnt, err := newClient(objectURL)
 	if err != nil {
 		return err.Trace(objectURL)
 	}
 
 	// Generate pre-signed access info.
-	shareURL, uploadInfo, err := clnt.ShareUpload(context.Background(), isRecursive, expiry, contentType)
+	shareURL, uploadInfo, err := clnt.ShareUpload(context.Background(), isRecursive, expiry, contentType, maxSize)
 	if err != nil {
 		return err.Trace(objectURL, "expiry="+expiry.String(), "contentType="+contentType)
 	}
@@ -171,6 +184,9 @@ func doShareUploadURL(ctx context.Context, objectURL string, isRecursive bool, e
 		ShareURL:    curlCmd,
 		TimeLeft:    expiry,
 		ContentType: contentType,
+		MaxSize:     maxSize,
+		PostURL:     shareURL,
+		FormData:    uploadInfo,
 	})
 
 	// save shared URL to disk.
@@ -196,6 +212,12 @@ func mainShareUpload(cliCtx *cli.Context) error {
 	expireArg := cliCtx.String("expire")
 	expiry := shareDefaultExpiry
 	contentType := cliCtx.String("content-type")
+	var maxSize int64
+	if cliCtx.String("max-size") != "" {
+		size, e := humanize.ParseBytes(cliCtx.String("max-size"))
+		fatalIf(probe.NewError(e), "Unable to parse max-size=`"+cliCtx.String("max-size")+"`.")
+		maxSize = int64(size)
+	}
 	if expireArg != "" {
 		var e error
 		expiry, e = time.ParseDuration(expireArg)
@@ -203,7 +225,7 @@ func mainShareUpload(cliCtx *cli.Context) error {
 	}
 
 	for _, targetURL := range cliCtx.Args() {
-		err := doShareUploadURL(ctx, targetURL, isRecursive, expiry, contentType)
+		err := doShareUploadURL(ctx, targetURL, isRecursive, expiry, contentType, maxSize)
 		if err != nil {
 			switch err.ToGoError().(type) {
 			case APINotImplemented:
@@ -25,6 +25,7 @@ import (
 	"strings"
 	"time"
 
+	humanize "github.com/dustin/go-humanize"
 	"github.com/fatih/color"
 	"github.com/minio/cli"
 	json "github.com/minio/colorjson"
@@ -57,6 +58,11 @@ type shareMesssage struct {
 	ShareURL    string        `json:"share"`
 	TimeLeft    time.Duration `json:"timeLeft"`
 	ContentType string        `json:"contentType,omitempty"` // Only used by upload cmd.
+
+	// Only used by upload cmd, the form fields of the presigned POST policy.
+	MaxSize  int64             `json:"maxSize,omitempty"`
+	PostURL  string            `json:"postURL,omitempty"`
+	FormData map[string]string `json:"formData,omitempty"`
 }
 
 // String - Themefied string message for console printing.
@@ -66,6 +72,9 @@ func (s shareMesssage) String() string {
 	if s.ContentType != "" {
 		msg += console.Colorize("Content-type", fmt.Sprintf("Content-Type: %s\n", s.ContentType))
 	}
+	if s.MaxSize > 0 {
+		msg += console.Colorize("Content-type", fmt.Sprintf("Max-Size: %s\n", humanize.IBytes(uint64(s.MaxSize))))
+	}
 
 	// Highlight <FILE> specifically. "share upload" sub-commands use this identifier.
 	shareURL := strings.Replace(s.ShareURL, "<FILE>", console.Colorize("File", "<FILE>"), 1)
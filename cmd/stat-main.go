@@ -56,7 +56,7 @@ var statCmd = cli.Command{
 	Action:       mainStat,
 	OnUsageError: onUsageError,
 	Before:       setGlobalsFromContext,
-	Flags:        append(append(statFlags, ioFlags...), globalFlags...),
+	Flags:        append(append(append(statFlags, symlinkFlags...), ioFlags...), globalFlags...),
 	CustomHelpTemplate: `NAME:
   {{.HelpName}} - {{.Usage}}
 
@@ -69,6 +69,14 @@ FLAGS:
 ENVIRONMENT VARIABLES:
   MC_ENCRYPT_KEY:  list of comma delimited prefix=secret values
 
+JSON OUTPUT:
+  With --json every object is printed with sorted keys, times are in UTC:
+    deleteMarker, etag, expiration, expirationRuleID, expires, lastModified,
+    metadata, name, replicationStatus, schemaVersion, size, status, type, versionID
+  Keys of 'metadata' are sorted as well, so the output for two objects can be
+  compared line by line. 'schemaVersion' is only increased when fields are
+  removed, renamed or change meaning.
+
 EXAMPLES:
   1. Stat all contents of mybucket on Amazon S3 cloud storage.
      {{.Prompt}} {{.HelpName}} s3/mybucket/
@@ -91,6 +99,9 @@ EXAMPLES:
 
   7. Stat all objects versions recursively created before 1st January 2020.
      {{.Prompt}} {{.HelpName}} --versions --rewind 2020.01.01T00:00 s3/personal-docs/
+
+  8. Compare the properties of an object on two clusters.
+     {{.Prompt}} diff <({{.HelpName}} --json site1/mybucket/object) <({{.HelpName}} --json site2/mybucket/object)
 `,
 }
 
@@ -37,8 +37,13 @@ import (
 	"github.com/minio/pkg/console"
 )
 
+// statSchemaVersion is the version of the JSON output of stat, it is
+// bumped on changes which break existing consumers only.
+const statSchemaVersion = 1
+
 // contentMessage container for content message structure.
 type statMessage struct {
+	SchemaVersion     int               `json:"schemaVersion"`
 	Status            string            `json:"status"`
 	Key               string            `json:"name"`
 	Date              time.Time         `json:"lastModified"`
@@ -113,7 +118,7 @@ func (stat statMessage) String() (msg string) {
 		}
 	}
 	if stat.ReplicationStatus != "" {
-		msgBuilder.WriteString(fmt.Sprintf("%-10s: %s ", "Replication Status", stat.ReplicationStatus))
+		msgBuilder.WriteString(fmt.Sprintf("%-10s: %s ", "Replication Status", stat.ReplicationStatus) + "\n")
 	}
 
 	return msgBuilder.String()
@@ -122,8 +127,14 @@ func (stat statMessage) String() (msg string) {
 // JSON jsonified content message.
 func (stat statMessage) JSON() string {
 	stat.Status = "success"
-	jsonMessageBytes, e := json.MarshalIndent(stat, "", " ")
-	fatalIf(probe.NewError(e), "Unable to marshal into JSON.")
+	stat.SchemaVersion = statSchemaVersion
+	// Report times in UTC so that output does not depend on the local
+	// timezone.
+	stat.Date = stat.Date.UTC()
+	stat.Expires = stat.Expires.UTC()
+	stat.Expiration = stat.Expiration.UTC()
+	jsonMessageBytes, err := canonicalJSON(stat)
+	fatalIf(err, "Unable to marshal into JSON.")
 
 	return string(jsonMessageBytes)
 }
@@ -137,6 +148,9 @@ func parseStat(c *ClientContent) statMessage {
 		if c.Type.IsDir() {
 			return "folder"
 		}
+		if c.Type&os.ModeSymlink == os.ModeSymlink {
+			return "symlink"
+		}
 		return "file"
 	}()
 	content.Size = c.Size
@@ -148,7 +162,7 @@ func parseStat(c *ClientContent) statMessage {
 	content.Expires = c.Expires
 	content.Expiration = c.Expiration
 	content.ExpirationRuleID = c.ExpirationRuleID
-	content.ReplicationStatus = c.ReplicationStatus
+	content.ReplicationStatus = getReplicationStatus(c)
 	return content
 }
 
@@ -0,0 +1,97 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/csv"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+// tabularWriter prints listings as delimited rows, the header line is
+// printed once before the first row.
+type tabularWriter struct {
+	w          *csv.Writer
+	columns    []string
+	headerDone bool
+}
+
+// newTabularWriter returns a writer for the given output format, either
+// "csv" or "tsv". An empty format disables tabular output.
+func newTabularWriter(format string, columns []string) (*tabularWriter, *probe.Error) {
+	var comma rune
+	switch format {
+	case "":
+		return nil, nil
+	case "csv":
+		comma = ','
+	case "tsv":
+		comma = '\t'
+	default:
+		return nil, errInvalidArgument().Trace(format)
+	}
+	w := csv.NewWriter(os.Stdout)
+	w.Comma = comma
+	return &tabularWriter{w: w, columns: columns}, nil
+}
+
+// write prints one row, rows are flushed right away so that
+// long running listings stream their output.
+func (t *tabularWriter) write(row []string) {
+	if !t.headerDone {
+		t.headerDone = true
+		fatalIf(probe.NewError(t.w.Write(t.columns)), "Unable to write output.")
+	}
+	fatalIf(probe.NewError(t.w.Write(row)), "Unable to write output.")
+	t.w.Flush()
+	fatalIf(probe.NewError(t.w.Error()), "Unable to write output.")
+}
+
+// contentColumns returns the header of a tabular listing.
+func contentColumns(withVersions, withReplication bool) []string {
+	columns := []string{"key", "size", "lastModified", "etag", "storageClass"}
+	if withVersions {
+		columns = append(columns, "versionId", "versionOrdinal", "isDeleteMarker")
+	}
+	if withReplication {
+		columns = append(columns, "replicationStatus")
+	}
+	return columns
+}
+
+// contentRow returns the fields of a content message in the order
+// of contentColumns.
+func contentRow(c contentMessage, withVersions, withReplication bool) []string {
+	row := []string{
+		c.Key,
+		strconv.FormatInt(c.Size, 10),
+		c.Time.Format(time.RFC3339),
+		c.ETag,
+		c.storageClass,
+	}
+	if withVersions {
+		row = append(row, c.VersionID, strconv.Itoa(c.VersionOrd), strconv.FormatBool(c.IsDeleteMarker))
+	}
+	if withReplication {
+		row = append(row, c.ReplicationStatus)
+	}
+	return row
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestContentRow(t *testing.T) {
+	mtime := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	msg := contentMessage{
+		Key:               "dir/a,b.txt",
+		Size:              42,
+		Time:              mtime,
+		ETag:              "abc",
+		VersionID:         "v1",
+		VersionOrd:        2,
+		ReplicationStatus: "COMPLETED",
+		storageClass:      "STANDARD",
+	}
+	testCases := []struct {
+		withVersions    bool
+		withReplication bool
+		expected        []string
+	}{
+		{false, false, []string{"dir/a,b.txt", "42", "2021-03-04T05:06:07Z", "abc", "STANDARD"}},
+		{true, false, []string{"dir/a,b.txt", "42", "2021-03-04T05:06:07Z", "abc", "STANDARD", "v1", "2", "false"}},
+		{false, true, []string{"dir/a,b.txt", "42", "2021-03-04T05:06:07Z", "abc", "STANDARD", "COMPLETED"}},
+	}
+	for i, testCase := range testCases {
+		columns := contentColumns(testCase.withVersions, testCase.withReplication)
+		row := contentRow(msg, testCase.withVersions, testCase.withReplication)
+		if len(columns) != len(row) {
+			t.Fatalf("Test %d: expected %d columns, got %d", i+1, len(columns), len(row))
+		}
+		if !reflect.DeepEqual(row, testCase.expected) {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.expected, row)
+		}
+	}
+}
+
+func TestNewTabularWriter(t *testing.T) {
+	testCases := []struct {
+		format  string
+		comma   rune
+		success bool
+	}{
+		{"", 0, true},
+		{"csv", ',', true},
+		{"tsv", '\t', true},
+		{"xml", 0, false},
+	}
+	for i, testCase := range testCases {
+		w, err := newTabularWriter(testCase.format, nil)
+		if testCase.success != (err == nil) {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if w != nil && w.w.Comma != testCase.comma {
+			t.Fatalf("Test %d: expected delimiter %q, got %q", i+1, testCase.comma, w.w.Comma)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"io"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/hookreader"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// teeStream reads reader once and streams it to all targets concurrently,
+// each one through put. A target failing aborts the others, the error of
+// the first failing target is returned.
+func teeStream(reader io.Reader, targets []string, put func(target string, reader io.Reader) *probe.Error) *probe.Error {
+	pipeReaders := make([]*io.PipeReader, len(targets))
+	pipeWriters := make([]*io.PipeWriter, len(targets))
+	writers := make([]io.Writer, len(targets))
+	for i := range targets {
+		pipeReaders[i], pipeWriters[i] = io.Pipe()
+		writers[i] = pipeWriters[i]
+	}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr *probe.Error
+	)
+	for i, target := range targets {
+		wg.Add(1)
+		go func(i int, target string) {
+			defer wg.Done()
+			err := put(target, pipeReaders[i])
+			if err == nil {
+				pipeReaders[i].Close()
+				return
+			}
+			mu.Lock()
+			if firstErr == nil {
+				firstErr = err.Trace(target)
+			}
+			mu.Unlock()
+			// Fail the writes to this target, which stops the copy.
+			pipeReaders[i].CloseWithError(err.ToGoError())
+		}(i, target)
+	}
+
+	_, e := io.Copy(io.MultiWriter(writers...), reader)
+	for _, pipeWriter := range pipeWriters {
+		// A nil error ends the streams with io.EOF.
+		pipeWriter.CloseWithError(e)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	if e != nil {
+		return probe.NewError(e)
+	}
+	return nil
+}
+
+// teeTargetURL - returns the object written for sourceURL to targetURL,
+// targets ending with a separator get the name of the source.
+func teeTargetURL(sourceURL, targetURL string) string {
+	if strings.HasSuffix(targetURL, string(newClientURL(targetURL).Separator)) {
+		return urlJoinPath(targetURL, path.Base(newClientURL(sourceURL).Path))
+	}
+	return targetURL
+}
+
+// teeUnsupportedFlags - cp flags not implemented when copying with --tee.
+var (
+	teeUnsupportedBoolFlags = []string{
+		"preserve", "disable-multipart", "preserve-version-id", "adaptive-part-size",
+		"skip-existing-with-same-etag", "if-size-differs", "newest", "oldest",
+		"verify-after", "remove-on-mismatch", "md5", "metadata-replace-content-type-by-extension",
+		"fair", "throttle-on-error", "no-follow-symlink",
+	}
+	teeUnsupportedStringFlags = []string{
+		"exclude-versions-older-than", "older-than", "newer-than", "tags",
+		"metadata-map-file", "content-type-map", rmFlag, rdFlag, lhFlag,
+		"on-success", "on-failure", "checksum-manifest", "source-region",
+	}
+	teeUnsupportedIntFlags = []string{
+		"limit-objects", "max-concurrent-uploads", "max-concurrent-downloads",
+	}
+)
+
+// checkCopyTeeSyntax - rejects the cp flags the --tee copy ignores.
+func checkCopyTeeSyntax(cliCtx *cli.Context, args []string) {
+	if len(args) < 2 {
+		cli.ShowCommandHelpAndExit(cliCtx, "cp", 1) // last argument is exit code
+	}
+	if cliCtx.Bool("recursive") || cliCtx.Bool("versions") || cliCtx.Bool("continue") || cliCtx.Bool("list-only") {
+		fatalIf(errInvalidArgument().Trace(args...), "--tee cannot be used with --recursive, --versions, --continue or --list-only.")
+	}
+	for _, flag := range teeUnsupportedBoolFlags {
+		if cliCtx.Bool(flag) {
+			fatalIf(errInvalidArgument().Trace(args...), "--tee cannot be used with --"+flag+".")
+		}
+	}
+	for _, flag := range teeUnsupportedStringFlags {
+		if cliCtx.String(flag) != "" {
+			fatalIf(errInvalidArgument().Trace(args...), "--tee cannot be used with --"+flag+".")
+		}
+	}
+	for _, flag := range teeUnsupportedIntFlags {
+		if cliCtx.IsSet(flag) {
+			fatalIf(errInvalidArgument().Trace(args...), "--tee cannot be used with --"+flag+".")
+		}
+	}
+	if len(cliCtx.StringSlice("metadata-exclude")) > 0 {
+		fatalIf(errInvalidArgument().Trace(args...), "--tee cannot be used with --metadata-exclude.")
+	}
+}
+
+// doCopyTee copies the single source to all the following targets,
+// reading it only once.
+func doCopyTee(ctx context.Context, cliCtx *cli.Context, args []string, encKeyDB map[string][]prefixSSEPair, userMetaMap map[string]string) error {
+	checkCopyTeeSyntax(cliCtx, args)
+	sourceURL, targetURLs := args[0], make([]string, 0, len(args)-1)
+	for _, targetURL := range args[1:] {
+		targetURLs = append(targetURLs, teeTargetURL(sourceURL, targetURL))
+	}
+
+	versionID := cliCtx.String("version-id")
+	_, content, err := url2Stat(ctx, sourceURL, versionID, false, encKeyDB, parseRewindFlag(cliCtx.String("rewind")))
+	fatalIf(err.Trace(sourceURL), "Unable to stat `"+sourceURL+"`.")
+	if content.Type.IsDir() {
+		fatalIf(errInvalidArgument().Trace(sourceURL), "--tee copies a single object, `"+sourceURL+"` is a folder.")
+	}
+
+	reader, err := getSourceStreamFromURL(ctx, sourceURL, content.VersionID, encKeyDB)
+	fatalIf(err.Trace(sourceURL), "Unable to read from `"+sourceURL+"`.")
+	defer reader.Close()
+
+	var source io.Reader = reader
+	var pg *progressBar
+	if !globalQuiet && !globalJSON {
+		pg = newProgressBar(content.Size)
+		source = hookreader.NewHook(reader, pg)
+	}
+
+	storageClass := cliCtx.String("storage-class")
+	err = teeStream(source, targetURLs, func(targetURL string, reader io.Reader) *probe.Error {
+		alias, _ := url2Alias(targetURL)
+		// Each target gets its own metadata, the content-type is set in it.
+		metadata := make(map[string]string, len(userMetaMap))
+		for k, v := range userMetaMap {
+			metadata[k] = v
+		}
+		_, err := putTargetStreamWithURL(targetURL, reader, content.Size, PutOptions{
+			sse:          getSSE(targetURL, encKeyDB[alias]),
+			storageClass: storageClass,
+			metadata:     metadata,
+		})
+		return err
+	})
+	if pg != nil {
+		pg.Finish()
+	}
+	fatalIf(err.Trace(sourceURL), "Unable to copy `"+sourceURL+"` to all targets.")
+
+	for _, targetURL := range targetURLs {
+		printMsg(copyMessage{
+			Source:     sourceURL,
+			Target:     targetURL,
+			Size:       content.Size,
+			TotalCount: 1,
+			TotalSize:  content.Size,
+		})
+	}
+	return nil
+}
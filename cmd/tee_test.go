@@ -0,0 +1,89 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/minio/mc/pkg/probe"
+)
+
+func TestTeeStream(t *testing.T) {
+	content := strings.Repeat("0123456789", 100000)
+	targets := []string{"s3/primary/a", "backup/a", "local/a"}
+
+	var mu sync.Mutex
+	written := make(map[string]string)
+	err := teeStream(strings.NewReader(content), targets, func(target string, reader io.Reader) *probe.Error {
+		data, e := ioutil.ReadAll(reader)
+		if e != nil {
+			return probe.NewError(e)
+		}
+		mu.Lock()
+		written[target] = string(data)
+		mu.Unlock()
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, target := range targets {
+		if written[target] != content {
+			t.Fatalf("%s: expected %d bytes, got %d", target, len(content), len(written[target]))
+		}
+	}
+
+	// A failing target aborts the others.
+	errTarget := errors.New("target failed")
+	var aborted int32
+	err = teeStream(strings.NewReader(content), targets, func(target string, reader io.Reader) *probe.Error {
+		if target == "backup/a" {
+			if _, e := io.CopyN(ioutil.Discard, reader, 10); e != nil {
+				return probe.NewError(e)
+			}
+			return probe.NewError(errTarget)
+		}
+		var buf bytes.Buffer
+		if _, e := io.Copy(&buf, reader); e != nil {
+			mu.Lock()
+			aborted++
+			mu.Unlock()
+			return probe.NewError(e)
+		}
+		return nil
+	})
+	if err == nil || err.ToGoError() != errTarget {
+		t.Fatalf("expected %v, got %v", errTarget, err)
+	}
+	if aborted != 2 {
+		t.Fatalf("expected the 2 other targets to be aborted, got %d", aborted)
+	}
+
+	if url := teeTargetURL("./backups/db.tar.gz", "s3/primary/"); url != "s3/primary/db.tar.gz" {
+		t.Fatalf("expected s3/primary/db.tar.gz, got %s", url)
+	}
+	if url := teeTargetURL("./backups/db.tar.gz", "s3/primary/db.tgz"); url != "s3/primary/db.tgz" {
+		t.Fatalf("expected s3/primary/db.tgz, got %s", url)
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// timeFormatFlags control how listing commands print timestamps in
+// their human readable output, JSON output is always RFC3339 in UTC.
+var timeFormatFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "time-format",
+		Usage: "print times with a Go time layout or one of '[rfc3339, unix, relative]'",
+	},
+	cli.StringFlag{
+		Name:  "tz",
+		Usage: "print times in this timezone, e.g. 'UTC', 'Local' or 'Europe/Berlin'",
+	},
+}
+
+// listTimeFormat renders timestamps for human readable output. The zero
+// value prints times in the local timezone using printDate.
+type listTimeFormat struct {
+	layout string
+	loc    *time.Location
+}
+
+// parseTimeFormat validates the values of --time-format and --tz, a
+// layout must hold at least one element of the reference time.
+func parseTimeFormat(layout, tz string) (listTimeFormat, *probe.Error) {
+	f := listTimeFormat{}
+	switch strings.ToLower(layout) {
+	case "":
+	case "rfc3339":
+		f.layout = time.RFC3339
+	case "unix", "relative":
+		f.layout = strings.ToLower(layout)
+	default:
+		if time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC).Format(layout) == layout {
+			return f, probe.NewError(fmt.Errorf("invalid --time-format `%s`, expected a Go time layout such as '2006-01-02'", layout))
+		}
+		f.layout = layout
+	}
+
+	switch tz {
+	case "", "Local", "local":
+	case "UTC", "utc":
+		f.loc = time.UTC
+	default:
+		loc, e := time.LoadLocation(tz)
+		if e != nil {
+			return f, probe.NewError(fmt.Errorf("invalid --tz `%s`: %v", tz, e))
+		}
+		f.loc = loc
+	}
+	return f, nil
+}
+
+// timeFormatFromContext returns the time format requested on the
+// command line, exiting on invalid values.
+func timeFormatFromContext(ctx *cli.Context) listTimeFormat {
+	f, err := parseTimeFormat(ctx.String("time-format"), ctx.String("tz"))
+	fatalIf(err.Trace(ctx.String("time-format"), ctx.String("tz")), "Unable to parse the time format.")
+	return f
+}
+
+func (f listTimeFormat) format(t time.Time) string {
+	if f.loc != nil {
+		t = t.In(f.loc)
+	} else {
+		t = t.Local()
+	}
+	switch f.layout {
+	case "":
+		return t.Format(printDate)
+	case "unix":
+		return strconv.FormatInt(t.Unix(), 10)
+	case "relative":
+		return humanize.Time(t)
+	}
+	return t.Format(f.layout)
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestListTimeFormat(t *testing.T) {
+	ts := time.Date(2021, 3, 4, 5, 6, 7, 0, time.UTC)
+	testCases := []struct {
+		layout   string
+		tz       string
+		expected string
+		success  bool
+	}{
+		{"rfc3339", "UTC", "2021-03-04T05:06:07Z", true},
+		{"RFC3339", "Asia/Tokyo", "2021-03-04T14:06:07+09:00", true},
+		{"unix", "", "1614834367", true},
+		{"", "UTC", "2021-03-04 05:06:07 UTC", true},
+		{"2006/01/02", "UTC", "2021/03/04", true},
+		{"", "Nowhere/Invalid", "", false},
+		{"yyyy-mm-dd", "UTC", "", false},
+	}
+
+	for i, testCase := range testCases {
+		f, err := parseTimeFormat(testCase.layout, testCase.tz)
+		if testCase.success != (err == nil) {
+			t.Fatalf("Test %d: expected success %v, got %v", i+1, testCase.success, err)
+		}
+		if err != nil {
+			continue
+		}
+		if got := f.format(ts); got != testCase.expected {
+			t.Fatalf("Test %d: expected %s, got %s", i+1, testCase.expected, got)
+		}
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/minio/cli"
+	"github.com/minio/mc/pkg/probe"
+)
+
+// transferHookFlags - flags for commands executed after each transfer, shared by cp and mirror.
+var transferHookFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "on-success",
+		Usage: "run a command after each successfully transferred object (see HOOKS)",
+	},
+	cli.StringFlag{
+		Name:  "on-failure",
+		Usage: "run a command after each object which failed to transfer (see HOOKS)",
+	},
+	cli.IntFlag{
+		Name:  "hook-limit",
+		Usage: "maximum number of hook commands running concurrently",
+		Value: 4,
+	},
+	cli.BoolFlag{
+		Name:  "hook-fail",
+		Usage: "exit with an error status when a hook command fails",
+	},
+}
+
+// transferHook runs user provided commands after each object transfer
+// with bounded concurrency.
+type transferHook struct {
+	onSuccess string
+	onFailure string
+	hookFail  bool
+
+	limitCh chan struct{}
+	wg      sync.WaitGroup
+
+	mu     sync.Mutex
+	failed bool
+}
+
+// newTransferHook returns a transferHook configured from the command
+// line, or nil when no hook command is requested.
+func newTransferHook(cliCtx *cli.Context) *transferHook {
+	onSuccess := strings.TrimSpace(cliCtx.String("on-success"))
+	onFailure := strings.TrimSpace(cliCtx.String("on-failure"))
+	if onSuccess == "" && onFailure == "" {
+		return nil
+	}
+	limit := cliCtx.Int("hook-limit")
+	if limit <= 0 {
+		fatalIf(errInvalidArgument().Trace(strconv.Itoa(limit)), "Invalid value for --hook-limit, must be a positive number.")
+	}
+	return &transferHook{
+		onSuccess: onSuccess,
+		onFailure: onFailure,
+		hookFail:  cliCtx.Bool("hook-fail"),
+		limitCh:   make(chan struct{}, limit),
+	}
+}
+
+// expandHookCommand splits command into arguments on spaces, then
+// substitutes {key}, {size} and {etag} in each of them with the target
+// path, the size and the source ETag of the transferred object, so that
+// a key with spaces stays a single argument.
+func expandHookCommand(command string, urls URLs) []string {
+	var key, etag string
+	var size int64
+	if urls.TargetContent != nil {
+		key = filepath.ToSlash(filepath.Join(urls.TargetAlias, urls.TargetContent.URL.Path))
+	}
+	if urls.SourceContent != nil {
+		size = urls.SourceContent.Size
+		etag = urls.SourceContent.ETag
+	}
+	replacer := strings.NewReplacer(
+		"{key}", key,
+		"{size}", strconv.FormatInt(size, 10),
+		"{etag}", etag,
+	)
+	args := strings.Fields(command)
+	for i := range args {
+		args[i] = replacer.Replace(args[i])
+	}
+	return args
+}
+
+// run executes the hook command matching the outcome of the transfer,
+// skipped objects run none.
+func (h *transferHook) run(urls URLs) {
+	if h == nil || urls.notTransferred {
+		return
+	}
+	command := h.onSuccess
+	if urls.Error != nil {
+		command = h.onFailure
+	}
+	if command == "" {
+		return
+	}
+	commandArgs := expandHookCommand(command, urls)
+
+	h.limitCh <- struct{}{}
+	h.wg.Add(1)
+	go func() {
+		defer func() {
+			<-h.limitCh
+			h.wg.Done()
+		}()
+		cmd := exec.Command(commandArgs[0], commandArgs[1:]...)
+		var stderr bytes.Buffer
+		cmd.Stderr = &stderr
+		if e := cmd.Run(); e != nil {
+			errorIf(probe.NewError(e).Trace(commandArgs...), "Hook command `%s` failed: %s", strings.Join(commandArgs, " "), strings.TrimSpace(stderr.String()))
+			h.mu.Lock()
+			h.failed = true
+			h.mu.Unlock()
+		}
+	}()
+}
+
+// wait waits for all running hook commands, it returns true if any of
+// them failed and --hook-fail was requested.
+func (h *transferHook) wait() bool {
+	if h == nil {
+		return false
+	}
+	h.wg.Wait()
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.failed && h.hookFail
+}
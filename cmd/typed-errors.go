@@ -126,3 +126,10 @@ var errConflictSSE = func(sseServer, sseKeys string) *probe.Error {
 	err := fmt.Errorf("SSE alias '%s' overlaps with SSE-C aliases '%s'", sseServer, sseKeys)
 	return probe.NewError(conflictSSEErr(err)).Untrace()
 }
+
+type verifyMismatchErr error
+
+var errVerifyMismatch = func(URL string) *probe.Error {
+	msg := "Content of `" + URL + "` does not match its source after upload."
+	return probe.NewError(verifyMismatchErr(errors.New(msg))).Untrace()
+}
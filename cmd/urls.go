@@ -23,17 +23,39 @@ import (
 
 // URLs contains source and target urls
 type URLs struct {
-	SourceAlias      string
-	SourceContent    *ClientContent
-	TargetAlias      string
-	TargetContent    *ClientContent
-	TotalCount       int64
-	TotalSize        int64
-	MD5              bool
-	DisableMultipart bool
-	encKeyDB         map[string][]prefixSSEPair
-	Error            *probe.Error `json:"-"`
-	ErrorCond        differType   `json:"-"`
+	SourceAlias       string
+	SourceContent     *ClientContent
+	TargetAlias       string
+	TargetContent     *ClientContent
+	TotalCount        int64
+	TotalSize         int64
+	MD5               bool
+	DisableMultipart  bool
+	AdaptivePartSize  bool
+	PreserveVersionID bool
+	encKeyDB          map[string][]prefixSSEPair
+	Error             *probe.Error `json:"-"`
+	ErrorCond         differType   `json:"-"`
+
+	// withChecksum requests the SHA256 checksum of the streamed data
+	// and the ETag of the target to be recorded, see checksumManifest.
+	withChecksum bool
+	checksum     string
+
+	// verifyAfter requests the target to be downloaded and compared
+	// with its source once uploaded, see verifyCopy. The version of
+	// the target uploaded is kept to verify this one only.
+	verifyAfter      bool
+	removeOnMismatch bool
+	targetVersionID  string
+
+	// metadataExclude lists the patterns of the metadata not copied
+	// over to the target, see excludeMetadata.
+	metadataExclude []string
+
+	// notTransferred is set on the results of objects skipped or only
+	// faked, which do not run the transfer hooks.
+	notTransferred bool
 }
 
 // WithError sets the error and returns object
@@ -152,6 +152,8 @@ func NewS3Config(urlStr string, aliasCfg *aliasConfigV10) *Config {
 		s3Config.SecretKey = aliasCfg.SecretKey
 		s3Config.SessionToken = aliasCfg.SessionToken
 		s3Config.Signature = aliasCfg.API
+		s3Config.Region = aliasCfg.Region
+		s3Config.Endpoints = aliasCfg.Endpoints
 	}
 	s3Config.Lookup = getLookupType(aliasCfg.Path)
 	return s3Config